@@ -1,9 +1,19 @@
 package main
 
 import (
+	"flag"
+	"os"
+
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app"
 )
 
 func main() {
+	demo := flag.Bool("demo", false, "run on in-memory sample data with local file storage (same as APP_MODE=demo)")
+	flag.Parse()
+
+	if *demo {
+		os.Setenv("APP_MODE", "demo")
+	}
+
 	app.Run()
 }
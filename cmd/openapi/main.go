@@ -0,0 +1,103 @@
+// Command openapi writes the OpenAPI document the server serves at
+// /openapi.json and checks it against the routes the router registers.
+//
+//	go run ./cmd/openapi -check            # exit 1 when a route is undocumented
+//	go run ./cmd/openapi -out openapi.json
+//
+// The router is built on an empty in-memory store, so neither a database nor
+// storage is needed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/router"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/memory"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/openapi"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+func main() {
+	check := flag.Bool("check", false, "compare the document with the registered routes instead of writing it")
+	out := flag.String("out", "", "write the document to this file instead of stdout")
+	flag.Parse()
+
+	if err := utils.ConfigureLogger("text", "warn"); err != nil {
+		fail(err)
+	}
+	gin.SetMode(gin.ReleaseMode)
+
+	if *check {
+		os.Exit(runCheck())
+	}
+
+	body, err := json.MarshalIndent(router.OpenAPI(false), "", "  ")
+	if err != nil {
+		fail(err)
+	}
+	body = append(body, '\n')
+	if *out == "" {
+		_, err = os.Stdout.Write(body)
+	} else {
+		err = os.WriteFile(*out, body, 0o644)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// runCheck diffs the routes of a normal boot, then of a demo boot, which
+// also serves uploaded files.
+func runCheck() int {
+	cfg := config.Default()
+	repos := memory.NewRepositories(memory.NewStore())
+	//? only the routes are compared, no service runs, so no storage is needed
+	c := &container.Container{Config: &cfg, Repos: repos, Services: container.NewServices(repos, nil, nil)}
+
+	failed := report("api", router.SetupRouter(c), router.OpenAPI(false))
+
+	dir, err := os.MkdirTemp("", "openapi-check-")
+	if err != nil {
+		fail(err)
+	}
+	defer os.RemoveAll(dir)
+	storage, err := utils.NewLocalStorage(dir, "http://localhost")
+	if err != nil {
+		fail(err)
+	}
+	c = &container.Container{Config: &cfg, Repos: repos, Services: container.NewServices(repos, nil, storage), Storage: storage}
+	failed = report("demo", router.SetupRouter(c), router.OpenAPI(true)) || failed
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// report prints the differences between the routes of r and doc, returning
+// true when a route is undocumented.
+func report(name string, r *gin.Engine, doc *openapi.Document) bool {
+	routes := r.Routes()
+	missing, stale := openapi.Diff(doc, routes)
+	for _, route := range missing {
+		fmt.Printf("%s: missing from the document: %s\n", name, route)
+	}
+	for _, route := range stale {
+		fmt.Printf("%s: documented but not registered: %s\n", name, route)
+	}
+	if len(missing) == 0 && len(stale) == 0 {
+		fmt.Printf("%s: all %d routes documented\n", name, len(routes))
+	}
+	return len(missing) > 0
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "openapi:", err)
+	os.Exit(1)
+}
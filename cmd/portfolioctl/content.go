@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+)
+
+var contentCommands = map[string]command{
+	"list": {
+		summary: "list blogs or projects with their status",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			kind := fs.String("type", "blog", "content type: blog or project")
+			status := fs.String("status", "", "filter by status: Draft, InReview, Approved or Published")
+			page := fs.Int("page", 1, "page")
+			limit := fs.Int("limit", 50, "items per page")
+			return func(ctx context.Context, e *env) error {
+				switch *kind {
+				case "blog":
+					blogs, _, err := e.c.Services.Blog.GetAllBlogs(ctx, blog.GetAllBlogParams{
+						Page: *page, Limit: *limit, Order: "id", Sort: "ASC", Status: *status,
+					})
+					if err != nil {
+						return err
+					}
+					rows := make([][]string, 0, len(blogs))
+					for _, b := range blogs {
+						rows = append(rows, []string{itoa(b.ID), b.Slug, b.Title, b.Status, optional(b.PublishedAt)})
+					}
+					return e.out.print(blogs, contentHeader, rows)
+				case "project":
+					projects, _, err := e.c.Services.Project.GetAllProjects(ctx, project.GetAllProjectParams{
+						Page: *page, Limit: *limit, Order: "id", Sort: "ASC", Status: *status,
+					})
+					if err != nil {
+						return err
+					}
+					rows := make([][]string, 0, len(projects))
+					for _, p := range projects {
+						rows = append(rows, []string{itoa(p.ID), p.Slug, p.Title, p.Status, optional(p.PublishedAt)})
+					}
+					return e.out.print(projects, contentHeader, rows)
+				default:
+					return fmt.Errorf("unknown content type %q, use blog or project", *kind)
+				}
+			}
+		},
+	},
+	"status": {
+		summary: "move a blog or project to a status, e.g. publish it",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			kind := fs.String("type", "blog", "content type: blog or project")
+			id := fs.Int("id", 0, "blog or project id (required)")
+			status := fs.String("status", string(editorial.StatusPublished), "target status: Draft, InReview, Approved or Published")
+			comment := fs.String("comment", "", "comment recorded with the last transition")
+			userID := fs.Int("user", 0, "admin user recorded as the actor (0 records no user)")
+			return func(ctx context.Context, e *env) error {
+				actor, err := adminActor(ctx, e, *userID)
+				if err != nil {
+					return err
+				}
+				target := editorial.Status(*status)
+				if editorial.Path(editorial.StatusDraft, target) == nil {
+					return fmt.Errorf("unknown status %q", *status)
+				}
+
+				switch *kind {
+				case "blog":
+					current, err := e.c.Services.Blog.GetBlogById(ctx, *id)
+					if err != nil {
+						return err
+					}
+					//? the workflow only allows single steps, so walk every one of them
+					steps := editorial.Path(editorial.ParseStatus(current.Status), target)
+					results := []blog.BlogChangeStatusResponse{}
+					for i, step := range steps {
+						req := blog.BlogChangeStatusRequest{ID: *id, Status: string(step)}
+						if i == len(steps)-1 && *comment != "" {
+							req.Comment = comment
+						}
+						res, err := e.c.Services.Blog.ChangeStatusBlog(ctx, actor, req)
+						if err != nil {
+							return err
+						}
+						results = append(results, res)
+					}
+					rows := make([][]string, 0, len(results))
+					for _, r := range results {
+						rows = append(rows, []string{itoa(r.ID), r.Title, r.Status, optional(r.PublishedAt)})
+					}
+					return e.out.print(results, transitionHeader, rows)
+				case "project":
+					current, err := e.c.Services.Project.GetProjectById(ctx, *id)
+					if err != nil {
+						return err
+					}
+					steps := editorial.Path(editorial.ParseStatus(current.Status), target)
+					results := []project.ProjectChangeStatusResponse{}
+					for i, step := range steps {
+						req := project.ProjectChangeStatusRequest{ID: *id, Status: string(step)}
+						if i == len(steps)-1 && *comment != "" {
+							req.Comment = comment
+						}
+						res, err := e.c.Services.Project.ChangeStatusProject(ctx, actor, req)
+						if err != nil {
+							return err
+						}
+						results = append(results, res)
+					}
+					rows := make([][]string, 0, len(results))
+					for _, r := range results {
+						rows = append(rows, []string{itoa(r.ID), r.Title, r.Status, optional(r.PublishedAt)})
+					}
+					return e.out.print(results, transitionHeader, rows)
+				default:
+					return fmt.Errorf("unknown content type %q, use blog or project", *kind)
+				}
+			}
+		},
+	},
+}
+
+var (
+	contentHeader    = []string{"ID", "SLUG", "TITLE", "STATUS", "PUBLISHED"}
+	transitionHeader = []string{"ID", "TITLE", "STATUS", "PUBLISHED"}
+)
+
+// adminActor returns the admin acting for userID, or an admin that no user
+// row backs when userID is 0.
+func adminActor(ctx context.Context, e *env, userID int) (user.Actor, error) {
+	if userID == 0 {
+		return user.Actor{Role: user.RoleAdmin}, nil
+	}
+	actor, err := e.c.Services.User.GetActor(ctx, userID)
+	if err != nil {
+		return user.Actor{}, err
+	}
+	if !actor.IsAdmin() {
+		return user.Actor{}, fmt.Errorf("user %d is not an admin", userID)
+	}
+	return actor, nil
+}
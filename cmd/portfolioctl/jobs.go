@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+)
+
+var jobsCommands = map[string]command{
+	"reading-times": {
+		summary: "recompute blog reading times from their current content",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			id := fs.Int("id", 0, "only this blog (0 recomputes every blog)")
+			return func(ctx context.Context, e *env) error {
+				ids := []int{*id}
+				if *id == 0 {
+					var err error
+					if ids, err = allBlogIDs(ctx, e); err != nil {
+						return err
+					}
+				}
+
+				type result struct {
+					BlogID  int    `json:"blog_id"`
+					Minutes int    `json:"minutes"`
+					Words   int    `json:"word_count"`
+					Error   string `json:"error,omitempty"`
+				}
+				results := []result{}
+				rows := [][]string{}
+				failed := 0
+				for _, blogID := range ids {
+					res := result{BlogID: blogID}
+					rt, err := e.c.Services.Blog.RecomputeReadingTime(ctx, blogID)
+					if err != nil {
+						//? one broken blog should not stop the others
+						res.Error = err.Error()
+						failed++
+					} else {
+						res.Minutes, res.Words = rt.Minutes, rt.WordCount
+					}
+					results = append(results, res)
+					rows = append(rows, []string{itoa(res.BlogID), itoa(res.Minutes), itoa(res.Words), res.Error})
+				}
+				if err := e.out.print(results, []string{"BLOG", "MINUTES", "WORDS", "ERROR"}, rows); err != nil {
+					return err
+				}
+				if failed > 0 {
+					return fmt.Errorf("%d of %d blogs failed", failed, len(ids))
+				}
+				return nil
+			}
+		},
+	},
+	"purge-images": {
+		summary: "delete content images that were uploaded but never attached",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			olderThan := fs.Duration("older-than", 24*time.Hour, "only images uploaded longer ago than this, so open editors keep theirs")
+			dryRun := fs.Bool("dry-run", false, "list the images without deleting them")
+			return func(ctx context.Context, e *env) error {
+				before := time.Now().Add(-*olderThan)
+
+				type orphan struct {
+					Type     string `json:"type"`
+					ID       int    `json:"id"`
+					ImageUrl string `json:"image_url"`
+					Deleted  bool   `json:"deleted"`
+				}
+				orphans := []orphan{}
+
+				blogImages, err := e.c.Services.BlogContentImage.GetUnlinkedImages(ctx, before)
+				if err != nil {
+					return err
+				}
+				for _, img := range blogImages {
+					o := orphan{Type: "blog", ID: img.ID, ImageUrl: img.ImageUrl}
+					if !*dryRun {
+						if _, err := e.c.Services.BlogContentImage.DeleteBlogContentImage(ctx, img.ID); err != nil {
+							return err
+						}
+						o.Deleted = true
+					}
+					orphans = append(orphans, o)
+				}
+
+				projectImages, err := e.c.Services.ProjectContentImage.GetUnlinkedImages(ctx, before)
+				if err != nil {
+					return err
+				}
+				for _, img := range projectImages {
+					o := orphan{Type: "project", ID: img.ID, ImageUrl: img.ImageUrl}
+					if !*dryRun {
+						if _, err := e.c.Services.ProjectContentImage.DeleteProjectContentImage(ctx, img.ID); err != nil {
+							return err
+						}
+						o.Deleted = true
+					}
+					orphans = append(orphans, o)
+				}
+
+				rows := make([][]string, 0, len(orphans))
+				for _, o := range orphans {
+					rows = append(rows, []string{o.Type, itoa(o.ID), o.ImageUrl, fmt.Sprint(o.Deleted)})
+				}
+				return e.out.print(orphans, []string{"TYPE", "ID", "IMAGE", "DELETED"}, rows)
+			}
+		},
+	},
+	"purge-idempotency-keys": {
+		summary: "delete Idempotency-Keys whose responses are no longer replayed",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			return func(ctx context.Context, e *env) error {
+				deleted, err := e.c.Services.Idempotency.PurgeExpiredKeys(ctx, time.Now())
+				if err != nil {
+					return err
+				}
+				result := struct {
+					Deleted int64 `json:"deleted"`
+				}{deleted}
+				return e.out.print(result, []string{"DELETED"}, [][]string{{fmt.Sprint(deleted)}})
+			}
+		},
+	},
+}
+
+// allBlogIDs pages through every blog.
+func allBlogIDs(ctx context.Context, e *env) ([]int, error) {
+	const limit = 100
+	var ids []int
+	for page := 1; ; page++ {
+		blogs, total, err := e.c.Services.Blog.GetAllBlogs(ctx, blog.GetAllBlogParams{Page: page, Limit: limit, Order: "id", Sort: "ASC"})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range blogs {
+			ids = append(ids, b.ID)
+		}
+		if len(blogs) < limit || len(ids) >= total {
+			return ids, nil
+		}
+	}
+}
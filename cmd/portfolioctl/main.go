@@ -0,0 +1,134 @@
+// Command portfolioctl runs operational tasks against the configured database
+// and storage through the same services as the API:
+//
+//	portfolioctl [-o table|json] <group> <command> [flags]
+//
+// Run it without arguments for the list of commands.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// command is one leaf of the command tree, e.g. "users create". bind
+// registers its flags and returns its body, so flags are checked before
+// anything connects.
+type command struct {
+	summary string
+	bind    func(fs *flag.FlagSet) func(ctx context.Context, e *env) error
+}
+
+// env is what every command runs with.
+type env struct {
+	c   *container.Container
+	out *printer
+}
+
+var groups = map[string]map[string]command{
+	"users":   usersCommands,
+	"content": contentCommands,
+	"jobs":    jobsCommands,
+	"storage": storageCommands,
+}
+
+func main() {
+	format := flag.String("o", "table", "output format: table or json")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := groups[args[0]][args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", strings.Join(args[:2], " "))
+		usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(os.Stdout, *format)
+	if err != nil {
+		fatal(err)
+	}
+	fs := flag.NewFlagSet("portfolioctl "+args[0]+" "+args[1], flag.ExitOnError)
+	run := cmd.bind(fs)
+	fs.Parse(args[2:])
+
+	utils.InitLogger()
+	cfg, err := config.Load()
+	if err != nil {
+		fatal(err)
+	}
+	//? info logs like "DB Connected" would only clutter the command's output
+	if err := utils.ConfigureLogger(cfg.Log.Format, "warn"); err != nil {
+		fatal(err)
+	}
+	if cfg.App.IsDemo() {
+		fatal(fmt.Errorf("demo mode keeps its data inside the server process, unset APP_MODE to manage a database"))
+	}
+
+	storage, err := container.NewStorage(cfg)
+	if err != nil {
+		fatal(fmt.Errorf("invalid storage configuration: %w", err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := config.InitDB(ctx, cfg.Database)
+	if err != nil {
+		fatal(fmt.Errorf("failed to connect to DB: %w", err))
+	}
+
+	runErr := run(ctx, &env{c: container.New(cfg, db, db, storage), out: out})
+	if err := config.CloseDB(db); err != nil {
+		utils.Logger.Error("closing DB: ", err)
+	}
+	if runErr != nil {
+		fatal(runErr)
+	}
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintln(w, "usage: portfolioctl [-o table|json] <group> <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "flags:")
+	flag.PrintDefaults()
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "\n%s:\n", name)
+		cmds := make([]string, 0, len(groups[name]))
+		for cmd := range groups[name] {
+			cmds = append(cmds, cmd)
+		}
+		sort.Strings(cmds)
+		for _, cmd := range cmds {
+			fmt.Fprintf(w, "  %-16s %s\n", cmd, groups[name][cmd].summary)
+		}
+	}
+	fmt.Fprintln(w, "\nRun a command with -h for its flags.")
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "portfolioctl:", err)
+	os.Exit(1)
+}
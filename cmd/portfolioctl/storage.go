@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+var storageCommands = map[string]command{
+	"ping": {
+		summary: "check that the bucket is reachable",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			return func(ctx context.Context, e *env) error {
+				status := struct {
+					Bucket string `json:"bucket"`
+					Status string `json:"status"`
+				}{e.c.Storage.Bucket(), "ok"}
+				err := e.c.Storage.Ping(ctx)
+				if err != nil {
+					status.Status = err.Error()
+				}
+				if printErr := e.out.print(status, []string{"BUCKET", "STATUS"}, [][]string{{status.Bucket, status.Status}}); printErr != nil {
+					return printErr
+				}
+				return err
+			}
+		},
+	},
+	"ls": {
+		summary: "list stored files",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			prefix := fs.String("prefix", "", "only keys starting with this, e.g. blog/")
+			return func(ctx context.Context, e *env) error {
+				objects, err := e.c.Storage.List(ctx, *prefix)
+				if err != nil {
+					return err
+				}
+				if objects == nil {
+					objects = []utils.StoredObject{}
+				}
+				rows := make([][]string, 0, len(objects))
+				for _, o := range objects {
+					rows = append(rows, []string{o.Key, fmt.Sprint(o.Size), o.LastModified.Format(time.DateTime)})
+				}
+				return e.out.print(objects, []string{"KEY", "SIZE", "MODIFIED"}, rows)
+			}
+		},
+	},
+	"rm": {
+		summary: "delete stored files by key, without touching the rows that link them",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			return func(ctx context.Context, e *env) error {
+				keys := fs.Args()
+				if len(keys) == 0 {
+					return errors.New("pass the keys to delete, see storage ls")
+				}
+
+				type removal struct {
+					Key   string `json:"key"`
+					Error string `json:"error,omitempty"`
+				}
+				results := []removal{}
+				rows := [][]string{}
+				var errs []error
+				for _, key := range keys {
+					r := removal{Key: key}
+					if err := e.c.Storage.Delete(ctx, key); err != nil {
+						r.Error = err.Error()
+						errs = append(errs, err)
+					}
+					results = append(results, r)
+					rows = append(rows, []string{r.Key, r.Error})
+				}
+				if err := e.out.print(results, []string{"KEY", "ERROR"}, rows); err != nil {
+					return err
+				}
+				return errors.Join(errs...)
+			}
+		},
+	},
+}
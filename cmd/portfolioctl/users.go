@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+var usersCommands = map[string]command{
+	"list": {
+		summary: "list users",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			email := fs.String("email", "", "filter by email (contains)")
+			username := fs.String("username", "", "filter by username (contains)")
+			page := fs.Int("page", 1, "page")
+			limit := fs.Int("limit", 50, "users per page")
+			return func(ctx context.Context, e *env) error {
+				users, total, err := e.c.Services.User.GetAllUsers(ctx, user.GetAllUserParams{
+					Page: *page, Limit: *limit, Order: "id", Sort: "ASC",
+					Email: *email, Username: *username,
+				})
+				if err != nil {
+					return err
+				}
+				rows := make([][]string, 0, len(users))
+				for _, u := range users {
+					rows = append(rows, userRow(u))
+				}
+				if err := e.out.print(users, userHeader, rows); err != nil {
+					return err
+				}
+				if !e.out.json && total > len(users) {
+					fmt.Fprintf(e.out.w, "\n%d of %d users, see -page\n", len(users), total)
+				}
+				return nil
+			}
+		},
+	},
+	"create": {
+		summary: "create a user with any role, e.g. the first admin",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			username := fs.String("username", "", "username (required)")
+			email := fs.String("email", "", "email (required)")
+			password := fs.String("password", "", "password, generated and printed when empty")
+			role := fs.String("role", user.RoleAdmin, "role: admin, editor or author")
+			return func(ctx context.Context, e *env) error {
+				generated, err := passwordOrGenerate(password)
+				if err != nil {
+					return err
+				}
+				req := user.UserCreateRequest{Username: *username, Email: *email, Password: *password, Role: *role}
+				if err := validate(&req); err != nil {
+					return err
+				}
+				data, err := e.c.Services.User.CreateUser(ctx, req)
+				if err != nil {
+					return err
+				}
+				return e.printUserWithPassword(data, generated, *password)
+			}
+		},
+	},
+	"reset-password": {
+		summary: "set a new password for a user",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			id := fs.Int("id", 0, "user id (required)")
+			password := fs.String("password", "", "new password, generated and printed when empty")
+			return func(ctx context.Context, e *env) error {
+				generated, err := passwordOrGenerate(password)
+				if err != nil {
+					return err
+				}
+				req := user.UserResetPasswordRequest{ID: *id, Password: *password}
+				if err := validate(&req); err != nil {
+					return err
+				}
+				if err := e.c.Services.User.ResetPassword(ctx, req); err != nil {
+					return err
+				}
+				data, err := e.c.Services.User.GetUserById(ctx, *id)
+				if err != nil {
+					return err
+				}
+				return e.printUserWithPassword(data, generated, *password)
+			}
+		},
+	},
+	"set-role": {
+		summary: "change the role of a user",
+		bind: func(fs *flag.FlagSet) func(context.Context, *env) error {
+			id := fs.Int("id", 0, "user id (required)")
+			role := fs.String("role", "", "role: admin, editor or author (required)")
+			return func(ctx context.Context, e *env) error {
+				req := user.UserChangeRoleRequest{ID: *id, Role: *role}
+				if err := validate(&req); err != nil {
+					return err
+				}
+				data, err := e.c.Services.User.ChangeRole(ctx, req)
+				if err != nil {
+					return err
+				}
+				return e.out.print(data, userHeader, [][]string{userRow(data)})
+			}
+		},
+	},
+}
+
+var userHeader = []string{"ID", "USERNAME", "EMAIL", "ROLE", "AUTHOR", "CREATED"}
+
+func userRow(u user.UserResponse) []string {
+	return []string{itoa(u.ID), u.Username, u.Email, u.Role, optional(u.AuthorID), u.CreatedAt}
+}
+
+// printUserWithPassword shows a generated password once, since it cannot be
+// recovered from the hash afterwards.
+func (e *env) printUserWithPassword(u user.UserResponse, generated bool, password string) error {
+	if !generated {
+		return e.out.print(u, userHeader, [][]string{userRow(u)})
+	}
+	v := struct {
+		user.UserResponse
+		Password string `json:"password"`
+	}{u, password}
+	return e.out.print(v, append(userHeader, "PASSWORD"), [][]string{append(userRow(u), password)})
+}
+
+// passwordOrGenerate fills an empty password with a random one and reports
+// whether it did.
+func passwordOrGenerate(password *string) (bool, error) {
+	if *password != "" {
+		return false, nil
+	}
+	raw := make([]byte, 12)
+	if _, err := rand.Read(raw); err != nil {
+		return false, err
+	}
+	*password = base64.RawURLEncoding.EncodeToString(raw)
+	return true, nil
+}
+
+// validate applies the request's validate tags, like the handlers do.
+func validate(req any) error {
+	if errs := utils.ValidateRequest(req); len(errs) > 0 {
+		return fmt.Errorf("%s: %s", errs[0].Field, errs[0].Message)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Command seed loads portfolio content from a YAML or JSON fixture file into
+// the configured database, uploading images to the configured storage.
+//
+//	go run ./cmd/seed -file fixtures/example.yaml -placeholders
+//
+// Records are matched by their natural key, so the command can be re-run
+// after editing the fixtures.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/seed"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+func main() {
+	file := flag.String("file", "fixtures/example.yaml", "fixture file, .json for JSON, YAML otherwise")
+	placeholders := flag.Bool("placeholders", false, "upload a generated image for records whose fixture names none")
+	userID := flag.Int("user", 0, "id of the admin user who owns the seeded blogs (0 seeds without a user)")
+	flag.Parse()
+
+	utils.InitLogger()
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.Logger.Fatal("❌ ", err)
+	}
+	if cfg.App.IsDemo() {
+		utils.Logger.Fatal("❌ Demo mode keeps its data in memory and seeds itself, unset APP_MODE to seed a database")
+	}
+
+	fixtures, err := seed.Load(*file)
+	if err != nil {
+		utils.Logger.Fatal("❌ ", err)
+	}
+
+	storage, err := container.NewStorage(cfg)
+	if err != nil {
+		utils.Logger.Fatal("❌ Invalid storage configuration: ", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := config.InitDB(ctx, cfg.Database)
+	if err != nil {
+		utils.Logger.Fatal("❌ Failed to connect to DB: ", err)
+	}
+	c := container.New(cfg, db, db, storage)
+
+	//? without -user the seeder acts as an admin that no user row backs
+	actor := user.Actor{Role: user.RoleAdmin}
+	if *userID > 0 {
+		if actor, err = c.Services.User.GetActor(ctx, *userID); err != nil {
+			utils.Logger.Fatal("❌ Unknown user: ", err)
+		}
+		if actor.Role != user.RoleAdmin {
+			utils.Logger.Fatalf("❌ User %d is not an admin", *userID)
+		}
+	}
+
+	seeder := seed.New(c.Services, actor, seed.ImageOptions{
+		Placeholders: *placeholders,
+		Extensions:   cfg.Upload.ImageExtensions,
+		MaxSize:      cfg.Upload.MaxImageSize,
+	})
+	report, runErr := seeder.Run(ctx, fixtures)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tCREATED\tUPDATED")
+	for _, c := range report {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", c.Kind, c.Created, c.Updated)
+	}
+	w.Flush()
+
+	if err := config.CloseDB(db); err != nil {
+		utils.Logger.Error("❌ Closing DB: ", err)
+	}
+	if runErr != nil {
+		utils.Logger.Fatal("❌ Seeding stopped: ", runErr)
+	}
+	utils.Logger.Infof("✅ Seeded %s", *file)
+}
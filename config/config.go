@@ -0,0 +1,499 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the whole application configuration. It is loaded once at startup
+// by Load and passed to whatever needs it; nothing else reads the environment.
+type Config struct {
+	App         AppConfig         `yaml:"app"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Storage     StorageConfig     `yaml:"storage"`
+	JWT         JWTConfig         `yaml:"jwt"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Upload      UploadConfig      `yaml:"upload"`
+	Log         LogConfig         `yaml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+}
+
+// ModeDemo runs the API on in-memory repositories with seeded sample content
+// and local file storage, without MySQL, PostgreSQL or MinIO.
+const ModeDemo = "demo"
+
+type AppConfig struct {
+	Env string `yaml:"env"`
+	// Mode is empty for a normal run or demo.
+	Mode string `yaml:"mode"`
+}
+
+// IsDemo reports whether the app runs in demo mode.
+func (c AppConfig) IsDemo() bool {
+	return c.Mode == ModeDemo
+}
+
+// IsProduction reports whether APP_ENV is production.
+func (c AppConfig) IsProduction() bool {
+	return strings.EqualFold(c.Env, "production")
+}
+
+type ServerConfig struct {
+	Port              string        `yaml:"port"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	RequestTimeout    time.Duration `yaml:"request_timeout"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+	TLSCertFile       string        `yaml:"tls_cert_file"`
+	TLSKeyFile        string        `yaml:"tls_key_file"`
+}
+
+// TLSEnabled reports whether the server should serve HTTPS from cert files.
+func (c ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+)
+
+type DatabaseConfig struct {
+	// Driver is mysql or postgres.
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	// SSLMode only applies to postgres.
+	SSLMode            string        `yaml:"sslmode"`
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold"`
+	LogParams          bool          `yaml:"log_params"`
+	MaxOpenConns       int           `yaml:"max_open_conns"`
+	MaxIdleConns       int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime    time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime    time.Duration `yaml:"conn_max_idle_time"`
+	ConnectRetries     int           `yaml:"connect_retries"`
+	ConnectBackoff     time.Duration `yaml:"connect_backoff"`
+	// ReplicaDSN is an optional read replica used by the public read endpoints.
+	ReplicaDSN string `yaml:"replica_dsn"`
+}
+
+type StorageConfig struct {
+	UploadEndpoint  string `yaml:"upload_endpoint"`
+	ViewEndpoint    string `yaml:"view_endpoint"`
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	UseSSL          bool   `yaml:"use_ssl"`
+	Bucket          string `yaml:"bucket"`
+	// LocalDir is where uploads are stored in demo mode.
+	LocalDir string `yaml:"local_dir"`
+}
+
+type JWTConfig struct {
+	KeysDir   string        `yaml:"keys_dir"`
+	ActiveKid string        `yaml:"active_kid"`
+	TokenTTL  time.Duration `yaml:"token_ttl"`
+}
+
+type CORSConfig struct {
+	AllowOrigins []string `yaml:"allow_origins"`
+}
+
+type UploadConfig struct {
+	MaxImageSize    int64    `yaml:"max_image_size"`
+	ImageExtensions []string `yaml:"image_extensions"`
+}
+
+type LogConfig struct {
+	Format string `yaml:"format"`
+	Level  string `yaml:"level"`
+}
+
+// TracingConfig selects where spans go. Exporter is none, otlp (OTLP over
+// HTTP), stdout or file; SampleRatio is the share of new traces that are kept.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`
+	ServiceName string  `yaml:"service_name"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	FilePath    string  `yaml:"file_path"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// IdempotencyConfig controls the Idempotency-Key of create requests: TTL is
+// how long a response is replayed to retries, LockTimeout how long a request
+// that never finished, e.g. because the server stopped, keeps its key, and
+// PurgeInterval how often the server deletes expired keys.
+type IdempotencyConfig struct {
+	TTL           time.Duration `yaml:"ttl"`
+	LockTimeout   time.Duration `yaml:"lock_timeout"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// Default returns the configuration used for every value that is not set in
+// the YAML file or the environment.
+func Default() Config {
+	return Config{
+		App: AppConfig{Env: "development"},
+		Server: ServerConfig{
+			Port:              "4000",
+			ReadTimeout:       60 * time.Second,
+			ReadHeaderTimeout: 10 * time.Second,
+			WriteTimeout:      120 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+			RequestTimeout:    60 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+		},
+		Database: DatabaseConfig{
+			Driver:             DriverMySQL,
+			Host:               "127.0.0.1",
+			User:               "root",
+			Name:               "project_db",
+			SSLMode:            "disable",
+			SlowQueryThreshold: 200 * time.Millisecond,
+			MaxOpenConns:       25,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    30 * time.Minute,
+			ConnMaxIdleTime:    5 * time.Minute,
+			ConnectRetries:     10,
+			ConnectBackoff:     time.Second,
+		},
+		Storage: StorageConfig{
+			LocalDir: "tmp/uploads",
+		},
+		JWT: JWTConfig{
+			KeysDir:  "keys/jwt",
+			TokenTTL: 7 * 24 * time.Hour,
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{
+				"http://localhost:3000",
+				"http://43.134.162.211:3000",
+				"https://www.dimasroger.com",
+				"https://dimasroger.com",
+			},
+		},
+		Upload: UploadConfig{
+			MaxImageSize:    2 * 1024 * 1024,
+			ImageExtensions: []string{".jpg", ".jpeg", ".png", ".webp"},
+		},
+		Log: LogConfig{
+			Format: "text",
+			Level:  "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "go-portofolio-api",
+			FilePath:    "traces.json",
+			SampleRatio: 1,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           24 * time.Hour,
+			LockTimeout:   2 * time.Minute,
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the optional YAML file named by
+// CONFIG_FILE (default config.yaml, skipped when missing), then .env and the
+// process environment, which win over the file. The result is validated.
+func Load() (*Config, error) {
+	LoadEnv()
+
+	cfg := Default()
+
+	path := os.Getenv("CONFIG_FILE")
+	if err := cfg.loadYAML(path); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+	cfg.Database.applyDriverDefaults()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (c *Config) loadYAML(path string) error {
+	explicit := path != ""
+	if !explicit {
+		path = "config.yaml"
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(raw, c); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// envLoader copies set environment variables into the config and collects
+// every parse error so they can be reported together.
+type envLoader struct {
+	errs []error
+}
+
+// applyDriverDefaults fills in the port when none was configured, since it
+// depends on the driver.
+func (c *DatabaseConfig) applyDriverDefaults() {
+	if c.Port != 0 {
+		return
+	}
+	switch c.Driver {
+	case DriverPostgres:
+		c.Port = 5432
+	default:
+		c.Port = 3306
+	}
+}
+
+func (l *envLoader) string(key string, target *string) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		*target = value
+	}
+}
+
+func (l *envLoader) int(key string, target *int) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s must be an integer, got %q", key, value))
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) int64(key string, target *int64) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s must be an integer, got %q", key, value))
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) bool(key string, target *bool) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s must be true or false, got %q", key, value))
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) float(key string, target *float64) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s must be a number, got %q", key, value))
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) duration(key string, target *time.Duration) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s must be a duration like 30s or 2m, got %q", key, value))
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) list(key string, target *[]string) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*target = items
+	}
+}
+
+func (c *Config) loadEnv() error {
+	l := &envLoader{}
+
+	l.string("APP_ENV", &c.App.Env)
+	l.string("APP_MODE", &c.App.Mode)
+
+	l.string("APP_PORT", &c.Server.Port)
+	l.duration("HTTP_READ_TIMEOUT", &c.Server.ReadTimeout)
+	l.duration("HTTP_READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout)
+	l.duration("HTTP_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	l.duration("HTTP_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	l.duration("HTTP_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	l.duration("HTTP_REQUEST_TIMEOUT", &c.Server.RequestTimeout)
+	l.int("HTTP_MAX_HEADER_BYTES", &c.Server.MaxHeaderBytes)
+	l.string("TLS_CERT_FILE", &c.Server.TLSCertFile)
+	l.string("TLS_KEY_FILE", &c.Server.TLSKeyFile)
+
+	l.string("DB_DRIVER", &c.Database.Driver)
+	l.string("DB_HOST", &c.Database.Host)
+	l.int("DB_PORT", &c.Database.Port)
+	l.string("DB_USER", &c.Database.User)
+	l.string("DB_PASSWORD", &c.Database.Password)
+	l.string("DB_NAME", &c.Database.Name)
+	l.string("DB_SSLMODE", &c.Database.SSLMode)
+	l.duration("DB_SLOW_QUERY_THRESHOLD", &c.Database.SlowQueryThreshold)
+	l.bool("DB_LOG_PARAMS", &c.Database.LogParams)
+	l.int("DB_MAX_OPEN_CONNS", &c.Database.MaxOpenConns)
+	l.int("DB_MAX_IDLE_CONNS", &c.Database.MaxIdleConns)
+	l.duration("DB_CONN_MAX_LIFETIME", &c.Database.ConnMaxLifetime)
+	l.duration("DB_CONN_MAX_IDLE_TIME", &c.Database.ConnMaxIdleTime)
+	l.int("DB_CONNECT_RETRIES", &c.Database.ConnectRetries)
+	l.duration("DB_CONNECT_BACKOFF", &c.Database.ConnectBackoff)
+	l.string("DB_REPLICA_DSN", &c.Database.ReplicaDSN)
+
+	l.string("MINIO_ENDPOINT_UPLOAD", &c.Storage.UploadEndpoint)
+	l.string("MINIO_ENDPOINT_VIEW", &c.Storage.ViewEndpoint)
+	l.string("MINIO_KEY_ID", &c.Storage.AccessKeyID)
+	l.string("MINIO_KEY_SECRET", &c.Storage.SecretAccessKey)
+	l.bool("MINIO_SSL", &c.Storage.UseSSL)
+	l.string("MINIO_BUCKET", &c.Storage.Bucket)
+	l.string("STORAGE_LOCAL_DIR", &c.Storage.LocalDir)
+
+	l.string("JWT_KEYS_DIR", &c.JWT.KeysDir)
+	l.string("JWT_ACTIVE_KID", &c.JWT.ActiveKid)
+	l.duration("JWT_TOKEN_TTL", &c.JWT.TokenTTL)
+
+	l.list("CORS_ALLOW_ORIGINS", &c.CORS.AllowOrigins)
+
+	l.int64("UPLOAD_MAX_IMAGE_SIZE", &c.Upload.MaxImageSize)
+	l.list("UPLOAD_IMAGE_EXTENSIONS", &c.Upload.ImageExtensions)
+
+	l.string("LOG_FORMAT", &c.Log.Format)
+	l.string("LOG_LEVEL", &c.Log.Level)
+
+	l.string("TRACING_EXPORTER", &c.Tracing.Exporter)
+	l.string("TRACING_SERVICE_NAME", &c.Tracing.ServiceName)
+	l.string("TRACING_ENDPOINT", &c.Tracing.Endpoint)
+	l.bool("TRACING_INSECURE", &c.Tracing.Insecure)
+	l.string("TRACING_FILE", &c.Tracing.FilePath)
+	l.float("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	l.duration("IDEMPOTENCY_TTL", &c.Idempotency.TTL)
+	l.duration("IDEMPOTENCY_LOCK_TIMEOUT", &c.Idempotency.LockTimeout)
+	l.duration("IDEMPOTENCY_PURGE_INTERVAL", &c.Idempotency.PurgeInterval)
+
+	if len(l.errs) > 0 {
+		return fmt.Errorf("invalid environment: %w", errors.Join(l.errs...))
+	}
+	return nil
+}
+
+// Validate checks the configuration and reports every problem at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port (APP_PORT) must be a port number, got %q", c.Server.Port))
+	}
+	check(c.Server.ReadTimeout > 0, "server.read_timeout (HTTP_READ_TIMEOUT) must be positive")
+	check(c.Server.ReadHeaderTimeout > 0, "server.read_header_timeout (HTTP_READ_HEADER_TIMEOUT) must be positive")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout (HTTP_WRITE_TIMEOUT) must be positive")
+	check(c.Server.IdleTimeout > 0, "server.idle_timeout (HTTP_IDLE_TIMEOUT) must be positive")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout (HTTP_SHUTDOWN_TIMEOUT) must be positive")
+	check(c.Server.RequestTimeout >= 0, "server.request_timeout (HTTP_REQUEST_TIMEOUT) cannot be negative")
+	check(c.Server.MaxHeaderBytes > 0, "server.max_header_bytes (HTTP_MAX_HEADER_BYTES) must be positive")
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""), "server.tls_cert_file (TLS_CERT_FILE) and server.tls_key_file (TLS_KEY_FILE) must be set together")
+
+	check(c.App.Mode == "" || c.App.Mode == ModeDemo, "app.mode (APP_MODE) must be empty or demo, got %q", c.App.Mode)
+
+	//? demo mode needs neither a database nor MinIO
+	if c.App.IsDemo() {
+		check(c.Storage.LocalDir != "", "storage.local_dir (STORAGE_LOCAL_DIR) is required in demo mode")
+	} else {
+		check(c.Database.Driver == DriverMySQL || c.Database.Driver == DriverPostgres, "database.driver (DB_DRIVER) must be mysql or postgres, got %q", c.Database.Driver)
+		check(c.Database.Host != "", "database.host (DB_HOST) is required")
+		check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port (DB_PORT) must be a port number, got %d", c.Database.Port)
+		check(c.Database.User != "", "database.user (DB_USER) is required")
+		check(c.Database.Name != "", "database.name (DB_NAME) is required")
+		check(c.Database.SlowQueryThreshold >= 0, "database.slow_query_threshold (DB_SLOW_QUERY_THRESHOLD) cannot be negative")
+		check(c.Database.MaxOpenConns > 0, "database.max_open_conns (DB_MAX_OPEN_CONNS) must be positive")
+		check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns, "database.max_idle_conns (DB_MAX_IDLE_CONNS) must be between 0 and max_open_conns")
+		check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime (DB_CONN_MAX_LIFETIME) cannot be negative")
+		check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time (DB_CONN_MAX_IDLE_TIME) cannot be negative")
+		check(c.Database.ConnectRetries >= 0, "database.connect_retries (DB_CONNECT_RETRIES) cannot be negative")
+		check(c.Database.ConnectBackoff > 0, "database.connect_backoff (DB_CONNECT_BACKOFF) must be positive")
+
+		check(c.Storage.UploadEndpoint != "", "storage.upload_endpoint (MINIO_ENDPOINT_UPLOAD) is required")
+		check(c.Storage.ViewEndpoint != "", "storage.view_endpoint (MINIO_ENDPOINT_VIEW) is required")
+		check(c.Storage.AccessKeyID != "", "storage.access_key_id (MINIO_KEY_ID) is required")
+		check(c.Storage.SecretAccessKey != "", "storage.secret_access_key (MINIO_KEY_SECRET) is required")
+		check(c.Storage.Bucket != "", "storage.bucket (MINIO_BUCKET) is required")
+	}
+
+	check(c.JWT.KeysDir != "", "jwt.keys_dir (JWT_KEYS_DIR) is required")
+	check(c.JWT.TokenTTL > 0, "jwt.token_ttl (JWT_TOKEN_TTL) must be positive")
+
+	check(len(c.CORS.AllowOrigins) > 0, "cors.allow_origins (CORS_ALLOW_ORIGINS) needs at least one origin")
+	for _, origin := range c.CORS.AllowOrigins {
+		check(origin != "*", "cors.allow_origins (CORS_ALLOW_ORIGINS) cannot contain * because credentials are allowed")
+	}
+
+	check(c.Upload.MaxImageSize > 0, "upload.max_image_size (UPLOAD_MAX_IMAGE_SIZE) must be positive")
+	check(len(c.Upload.ImageExtensions) > 0, "upload.image_extensions (UPLOAD_IMAGE_EXTENSIONS) needs at least one extension")
+	for _, ext := range c.Upload.ImageExtensions {
+		check(strings.HasPrefix(ext, "."), "upload.image_extensions (UPLOAD_IMAGE_EXTENSIONS) must start with a dot, got %q", ext)
+	}
+
+	check(c.Log.Format == "text" || c.Log.Format == "json", "log.format (LOG_FORMAT) must be text or json, got %q", c.Log.Format)
+	check(slices.Contains([]string{"trace", "debug", "info", "warn", "warning", "error", "fatal", "panic"}, c.Log.Level), "log.level (LOG_LEVEL) must be one of debug, info, warn or error, got %q", c.Log.Level)
+
+	check(slices.Contains([]string{"none", "otlp", "stdout", "file"}, c.Tracing.Exporter), "tracing.exporter (TRACING_EXPORTER) must be none, otlp, stdout or file, got %q", c.Tracing.Exporter)
+	check(c.Tracing.ServiceName != "", "tracing.service_name (TRACING_SERVICE_NAME) is required")
+	check(c.Tracing.Exporter != "file" || c.Tracing.FilePath != "", "tracing.file_path (TRACING_FILE) is required for the file exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio (TRACING_SAMPLE_RATIO) must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	check(c.Idempotency.TTL > 0, "idempotency.ttl (IDEMPOTENCY_TTL) must be positive")
+	check(c.Idempotency.LockTimeout > 0, "idempotency.lock_timeout (IDEMPOTENCY_LOCK_TIMEOUT) must be positive")
+	check(c.Idempotency.PurgeInterval > 0, "idempotency.purge_interval (IDEMPOTENCY_PURGE_INTERVAL) must be positive")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
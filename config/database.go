@@ -1,53 +1,179 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"net"
+	"net/url"
 	"strconv"
+	"time"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
-func InitDB() *gorm.DB {
-	// Load .env
+const maxConnectBackoff = 30 * time.Second
+
+// LoadEnv loads .env into the process environment when the file exists.
+func LoadEnv() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("⚠️ No .env file found, using environment variables")
 	}
+}
 
-	host := getEnv("DB_HOST", "127.0.0.1")
-	portStr := getEnv("DB_PORT", "3306")
-	user := getEnv("DB_USER", "root")
-	password := getEnv("DB_PASSWORD", "")
-	name := getEnv("DB_NAME", "project_db")
-
+// InitDB opens the primary connection pool for cfg.Driver. A database that is
+// still starting (e.g. under docker compose) is retried with exponential backoff until
+// cfg.ConnectRetries is used up or ctx is cancelled.
+func InitDB(ctx context.Context, cfg DatabaseConfig) (*gorm.DB, error) {
 	// // If running inside Docker, use host.docker.internal (for Docker Desktop on macOS/Windows)
 	// if _, err := os.Stat("/.dockerenv"); err == nil {
 	// 	// Running inside Docker
 	// 	host = "host.docker.internal"
 	// }
 
-	port, err := strconv.Atoi(portStr)
+	db, err := openDB(ctx, "primary", primaryDSN(cfg), cfg)
 	if err != nil {
-		log.Fatalf("❌ Invalid DB_PORT: %v", err)
+		return nil, err
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&loc=Local", user, password, host, port, name)
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{SkipDefaultTransaction: true})
+	utils.Logger.Info("✅ DB Connected: ", cfg.Name)
+	return db, nil
+}
+
+// InitReadDB opens the read replica used for public reads. Without
+// cfg.ReplicaDSN it returns primary, so callers never need to check.
+func InitReadDB(ctx context.Context, cfg DatabaseConfig, primary *gorm.DB) (*gorm.DB, error) {
+	if cfg.ReplicaDSN == "" {
+		return primary, nil
+	}
+
+	dsn, err := replicaDSN(cfg.Driver, cfg.ReplicaDSN)
 	if err != nil {
-		log.Fatal("❌ Failed to connect to DB: ", err)
+		return nil, err
 	}
 
-	log.Println("✅ DB Connected:", name)
-	return db
+	db, err := openDB(ctx, "replica", dsn, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Logger.Info("✅ DB replica connected")
+	return db, nil
+}
+
+func primaryDSN(cfg DatabaseConfig) string {
+	if cfg.Driver == DriverPostgres {
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.User, cfg.Password),
+			Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+			Path:     "/" + cfg.Name,
+			RawQuery: url.Values{"sslmode": {cfg.SSLMode}}.Encode(),
+		}
+		return dsn.String()
+	}
+
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&loc=Local", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+}
+
+// replicaDSN forces the options the primary MySQL DSN sets, so rows scan the
+// same way. PostgreSQL DSNs are used as given.
+func replicaDSN(driver, dsn string) (string, error) {
+	if driver == DriverPostgres {
+		return dsn, nil
+	}
+
+	parsed, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return "", fmt.Errorf("invalid replica DSN: %w", err)
+	}
+	parsed.ParseTime = true
+	parsed.Loc = time.Local
+	return parsed.FormatDSN(), nil
+}
+
+func openDB(ctx context.Context, name, dsn string, cfg DatabaseConfig) (*gorm.DB, error) {
+	backoff := cfg.ConnectBackoff
+
+	for attempt := 0; ; attempt++ {
+		//? failed attempts are reported below, so gorm itself stays quiet until connected
+		db, err := gorm.Open(dialector(cfg.Driver, dsn), &gorm.Config{
+			SkipDefaultTransaction: true,
+			Logger:                 logger.Discard,
+		})
+		if err == nil {
+			db.Logger = utils.NewGormLogger(cfg.SlowQueryThreshold, cfg.LogParams)
+			if err := configureDB(db, cfg); err != nil {
+				return nil, err
+			}
+			return db, nil
+		}
+
+		if attempt >= cfg.ConnectRetries {
+			return nil, fmt.Errorf("connect to %s DB after %d attempts: %w", name, attempt+1, err)
+		}
+
+		utils.Logger.WithField("attempt", attempt+1).Warnf("⚠️ %s DB not reachable, retrying in %s: %v", name, backoff, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+func dialector(driver, dsn string) gorm.Dialector {
+	if driver == DriverPostgres {
+		return postgres.Open(dsn)
+	}
+	return mysql.Open(dsn)
+}
+
+func configureDB(db *gorm.DB, cfg DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	//? spans follow the same rule as the SQL log: bound values only when asked for
+	tracingOpts := []tracing.Option{tracing.WithoutMetrics(), tracing.WithDBName(cfg.Name)}
+	if !cfg.LogParams {
+		tracingOpts = append(tracingOpts, tracing.WithoutQueryVariables())
+	}
+	if err := db.Use(tracing.NewPlugin(tracingOpts...)); err != nil {
+		return fmt.Errorf("enable DB tracing: %w", err)
+	}
+	return nil
+}
+
+// CloseDB closes the underlying connection pool.
+func CloseDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
-// getEnv gets env variable or fallback
-func getEnv(key, fallback string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// CloseDBs closes primary and, when it is a separate pool, the read replica.
+func CloseDBs(primary, read *gorm.DB) error {
+	err := CloseDB(primary)
+	if read != primary {
+		err = errors.Join(err, CloseDB(read))
 	}
-	return fallback
+	return err
 }
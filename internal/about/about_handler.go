@@ -12,9 +12,9 @@ import (
 )
 
 func (h *handler) GetAll(c *gin.Context) {
-	data, err := h.service.GetAllAbouts()
+	data, err := h.service.GetAllAbouts(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
@@ -26,11 +26,12 @@ func (h *handler) GetAboutById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetAboutById(id)
+	data, err := h.service.GetAboutById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success get data", data)
 }
 
@@ -38,14 +39,13 @@ func (h *handler) ValidateAvatar(c *gin.Context, validationCheck []string) (file
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	avatar_file, err := c.FormFile("avatar_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "avatar_file")
-		errors = utils.GenerateFieldErrorResponse("avatar_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("avatar_file", "{0} is required", "avatar_file")
 		return nil, errors, err
 	}
 
@@ -60,8 +60,7 @@ func (h *handler) ValidateAvatar(c *gin.Context, validationCheck []string) (file
 		// Step 3: Validate size
 		if avatar_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "avatar_file")
-			errors := utils.GenerateFieldErrorResponse("avatar_file", err_name)
+			errors := utils.GenerateFieldErrorResponse("avatar_file", "{0} exceeds max size", "avatar_file")
 			return nil, errors, err
 		}
 	}
@@ -87,68 +86,104 @@ func (h *handler) CreateAbout(c *gin.Context) {
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.CreateAbout(req)
+	data, err := h.service.CreateAbout(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateAbout(c *gin.Context) {
 	// Validate the struct using validator
 	id, _ := strconv.Atoi(c.PostForm("id"))
 
-	title := c.PostForm("title")
-	description_html := c.PostForm("description_html")
-	is_used := c.PostForm("is_used")
+	h.updateAbout(c, UpdateAboutRequest{ID: id})
+}
 
-	validationCheck := []string{"extension", "size"}
-	avatar_file, errors, err := h.ValidateAvatar(c, validationCheck)
+// PatchAbout updates the fields the form names and keeps the others.
+func (h *handler) PatchAbout(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetAboutById(c.Request.Context(), id)
 	if err != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	req := UpdateAboutRequest{
+	h.updateAbout(c, UpdateAboutRequest{
 		ID:              id,
-		Title:           title,
-		DescriptionHTML: description_html,
-		AvatarFile:      avatar_file,
-		IsUsed:          is_used,
+		Title:           current.Title,
+		DescriptionHTML: current.DescriptionHTML,
+		IsUsed:          current.IsUsed,
+		Version:         current.Version,
+	})
+}
+
+// updateAbout overlays the form values on req before saving it, provided the
+// about is still at the version the client sent.
+func (h *handler) updateAbout(c *gin.Context, req UpdateAboutRequest) {
+	req.Title = utils.PostFormOr(c, "title", req.Title)
+	req.DescriptionHTML = utils.PostFormOr(c, "description_html", req.DescriptionHTML)
+	req.IsUsed = utils.PostFormOr(c, "is_used", req.IsUsed)
+	version, ok := utils.RequestVersion(c, req.Version)
+	if !ok {
+		return
 	}
+	req.Version = version
+
+	validationCheck := []string{"extension", "size"}
+	avatar_file, errors, err := h.ValidateAvatar(c, validationCheck)
+	if err != nil {
+		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		return
+	}
+	req.AvatarFile = avatar_file
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	err = h.service.UpdateAbout(req)
+	err = h.service.UpdateAbout(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	utils.SetETag(c, req.Version+1)
 	utils.Success(c, "success updated data", nil)
 }
 
 func (h *handler) DeleteAbout(c *gin.Context) {
 	var req AboutDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteAbout(c, req.ID)
+}
+
+func (h *handler) DeleteAboutById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteAbout(c, id)
+}
 
-	err := h.service.DeleteAbout(id)
+func (h *handler) deleteAbout(c *gin.Context, id int) {
+	err := h.service.DeleteAbout(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", nil)
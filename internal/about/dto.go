@@ -18,6 +18,7 @@ type UpdateAboutRequest struct {
 	DescriptionHTML string                `json:"description_html" validate:"required"`
 	AvatarFile      *multipart.FileHeader `json:"avatar_file"`
 	IsUsed          string                `json:"is_used" validate:"required,oneof=Y N"`
+	Version         int                   `json:"version" validate:"required"`
 }
 
 type CreateAboutDTO struct {
@@ -30,6 +31,7 @@ type CreateAboutDTO struct {
 
 type UpdateAboutDTO struct {
 	ID              int
+	Version         int
 	Title           string
 	DescriptionHTML string
 	AvatarUrl       string
@@ -44,6 +46,7 @@ type AboutResponse struct {
 	AvatarUrl       string `json:"avatar_url"`
 	AvatarFileName  string `json:"avatar_file_name"`
 	IsUsed          string `json:"is_used"`
+	Version         int    `json:"version"`
 	CreatedAt       string `json:"created_at"`
 }
 
@@ -59,6 +62,7 @@ func ToAboutResponse(p About) AboutResponse {
 		AvatarUrl:       p.AvatarUrl,
 		AvatarFileName:  p.AvatarFileName,
 		IsUsed:          utils.BoolToYN(p.IsUsed),
+		Version:         p.Version,
 		CreatedAt:       p.CreatedAt.Format("2006-01-02"),
 	}
 }
@@ -2,17 +2,16 @@ package about
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
 )
 
 type handler struct {
 	service Service
+	upload  config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
-	h := handler{service: service}
+func RegisterRoutes(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
 
 	about := r.Group("/abouts")
 	{
@@ -23,3 +22,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		about.POST("/delete", h.DeleteAbout)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
+
+	about := r.Group("/abouts")
+	{
+		about.GET("", h.GetAll)
+		about.GET("/:id", h.GetAboutById)
+		about.POST("", h.CreateAbout)
+		about.PATCH("/:id", h.PatchAbout)
+		about.DELETE("/:id", h.DeleteAboutById)
+	}
+}
@@ -13,6 +13,7 @@ type About struct {
 	AvatarUrl       string `json:"avatar_url"`
 	AvatarFileName  string `json:"avatar_file_name"`
 	IsUsed          bool   `json:"is_used"`
+	Version         int    `json:"version" gorm:"not null;default:1"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
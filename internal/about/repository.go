@@ -1,15 +1,18 @@
 package about
 
 import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll() ([]About, error)
-	FindById(id int) (About, error)
-	CreateAbout(p CreateAboutDTO) (About, error)
-	UpdateAbout(p UpdateAboutDTO) error
-	DeleteAbout(id int) error
+	FindAll(ctx context.Context) ([]About, error)
+	FindById(ctx context.Context, id int) (About, error)
+	CreateAbout(ctx context.Context, p CreateAboutDTO) (About, error)
+	UpdateAbout(ctx context.Context, p UpdateAboutDTO) error
+	DeleteAbout(ctx context.Context, id int) error
 }
 
 type repository struct {
@@ -20,29 +23,31 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll() ([]About, error) {
+func (r *repository) FindAll(ctx context.Context) ([]About, error) {
 	var abouts []About
-	err := r.db.Find(&abouts).Error
+	err := r.db.WithContext(ctx).Find(&abouts).Error
 	return abouts, err
 }
 
-func (r *repository) FindById(id int) (About, error) {
+func (r *repository) FindById(ctx context.Context, id int) (About, error) {
 	var about About
-	err := r.db.Where("id = ?", id).First(&about).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&about).Error
 	return about, err
 }
 
-func (r *repository) CreateAbout(p CreateAboutDTO) (About, error) {
+func (r *repository) CreateAbout(ctx context.Context, p CreateAboutDTO) (About, error) {
 	about := About{
 		Title:           p.Title,
 		DescriptionHTML: p.DescriptionHTML,
 		AvatarUrl:       p.AvatarUrl,
-		AvatarFileName:  p.AvatarFileName}
-	err := r.db.Create(&about).Error
+		AvatarFileName:  p.AvatarFileName,
+		Version:         1,
+	}
+	err := r.db.WithContext(ctx).Create(&about).Error
 	return about, err
 }
 
-func (r *repository) UpdateAbout(p UpdateAboutDTO) error {
+func (r *repository) UpdateAbout(ctx context.Context, p UpdateAboutDTO) error {
 	updateMap := map[string]interface{}{
 		"id":               p.ID,
 		"title":            p.Title,
@@ -51,13 +56,12 @@ func (r *repository) UpdateAbout(p UpdateAboutDTO) error {
 		"avatar_file_name": p.AvatarFileName,
 		"is_used":          p.IsUsed,
 	}
-	err := r.db.Table("abouts").Where("id = ?", p.ID).Updates(updateMap).Error
-	return err
+	return utils.UpdateVersioned(r.db.WithContext(ctx), "abouts", p.ID, p.Version, updateMap)
 }
 
-func (r *repository) DeleteAbout(id int) error {
+func (r *repository) DeleteAbout(ctx context.Context, id int) error {
 	// Hard Delete
-	if err := r.db.Unscoped().Where("id = ?", id).Delete(&About{}).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&About{}).Error; err != nil {
 		return err
 	}
 
@@ -7,23 +7,24 @@ import (
 )
 
 type Service interface {
-	GetAllAbouts() ([]AboutResponse, error)
-	GetAboutById(id int) (AboutResponse, error)
-	CreateAbout(p CreateAboutRequest) (AboutResponse, error)
-	UpdateAbout(p UpdateAboutRequest) error
-	DeleteAbout(id int) error
+	GetAllAbouts(ctx context.Context) ([]AboutResponse, error)
+	GetAboutById(ctx context.Context, id int) (AboutResponse, error)
+	CreateAbout(ctx context.Context, p CreateAboutRequest) (AboutResponse, error)
+	UpdateAbout(ctx context.Context, p UpdateAboutRequest) error
+	DeleteAbout(ctx context.Context, id int) error
 }
 
 type service struct {
-	repo Repository
+	repo    Repository
+	storage utils.Storage
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
 }
 
-func (s *service) GetAllAbouts() ([]AboutResponse, error) {
-	abouts, err := s.repo.FindAll()
+func (s *service) GetAllAbouts(ctx context.Context) ([]AboutResponse, error) {
+	abouts, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -35,16 +36,16 @@ func (s *service) GetAllAbouts() ([]AboutResponse, error) {
 	return result, nil
 }
 
-func (s *service) GetAboutById(id int) (AboutResponse, error) {
-	about, err := s.repo.FindById(id)
+func (s *service) GetAboutById(ctx context.Context, id int) (AboutResponse, error) {
+	about, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return AboutResponse{}, err
 	}
 	return ToAboutResponse(about), nil
 }
 
-func (s *service) CreateAbout(p CreateAboutRequest) (AboutResponse, error) {
-	avatarRes, err := utils.HandlUploadFile(p.AvatarFile, "about")
+func (s *service) CreateAbout(ctx context.Context, p CreateAboutRequest) (AboutResponse, error) {
+	avatarRes, err := s.storage.Upload(ctx, p.AvatarFile, "about")
 	if err != nil {
 		return AboutResponse{}, err
 	}
@@ -57,20 +58,23 @@ func (s *service) CreateAbout(p CreateAboutRequest) (AboutResponse, error) {
 		IsUsed:          false,
 	}
 
-	about, err := s.repo.CreateAbout(payload)
+	about, err := s.repo.CreateAbout(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), avatarRes.FileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), avatarRes.FileName)
 		return AboutResponse{}, err
 	}
 	return ToAboutResponse(about), nil
 }
 
-func (s *service) UpdateAbout(p UpdateAboutRequest) error {
+func (s *service) UpdateAbout(ctx context.Context, p UpdateAboutRequest) error {
 	//todo: Get About
-	about, err := s.repo.FindById(p.ID)
+	about, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
+	if err := utils.CheckVersion(p.Version, about.Version); err != nil {
+		return err
+	}
 
 	//todo: set oldFileName
 	oldFileName := ""
@@ -83,7 +87,7 @@ func (s *service) UpdateAbout(p UpdateAboutRequest) error {
 
 	//todo: Upload File
 	if p.AvatarFile != nil {
-		logoRes, err := utils.HandlUploadFile(p.AvatarFile, "about")
+		logoRes, err := s.storage.Upload(ctx, p.AvatarFile, "about")
 		if err != nil {
 			return err
 		}
@@ -97,6 +101,7 @@ func (s *service) UpdateAbout(p UpdateAboutRequest) error {
 
 	payload := UpdateAboutDTO{
 		ID:              p.ID,
+		Version:         p.Version,
 		Title:           p.Title,
 		DescriptionHTML: p.DescriptionHTML,
 		AvatarUrl:       newFileURL,
@@ -104,34 +109,36 @@ func (s *service) UpdateAbout(p UpdateAboutRequest) error {
 		IsUsed:          p.IsUsed == "Y",
 	}
 
-	err = s.repo.UpdateAbout(payload)
+	err = s.repo.UpdateAbout(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), newFileName)
+		if p.AvatarFile != nil {
+			_ = s.storage.Delete(context.WithoutCancel(ctx), newFileName)
+		}
 		return err
 	}
 
 	//todo: Delete Old Image
 	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), oldFileName)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteAbout(id int) error {
+func (s *service) DeleteAbout(ctx context.Context, id int) error {
 	//todo: Get About
-	about, err := s.repo.FindById(id)
+	about, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.DeleteAbout(id)
+	err = s.repo.DeleteAbout(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	//todo: Delete Old Image
-	_ = utils.DeleteFromMinio(context.Background(), about.AvatarFileName)
+	_ = s.storage.Delete(context.WithoutCancel(ctx), about.AvatarFileName)
 
 	return nil
 }
@@ -0,0 +1,108 @@
+package api_key
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+func (h *handler) GetAll(c *gin.Context) {
+	userID, ok := utils.GetAuthUserID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return
+	}
+
+	data, err := h.service.GetAllApiKeys(c.Request.Context(), userID)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success get all data", data)
+}
+
+func (h *handler) GetApiKeyById(c *gin.Context) {
+	userID, ok := utils.GetAuthUserID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, "invalid ID")
+		return
+	}
+
+	data, err := h.service.GetApiKeyById(c.Request.Context(), id, userID)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success get data", data)
+}
+
+func (h *handler) CreateApiKey(c *gin.Context) {
+	userID, ok := utils.GetAuthUserID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return
+	}
+
+	var req CreateApiKeyRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	data, err := h.service.CreateApiKey(c.Request.Context(), userID, req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SetLocation(c, data.ID)
+	utils.Created(c, "success create data", data)
+}
+
+func (h *handler) DeleteApiKey(c *gin.Context) {
+	userID, ok := utils.GetAuthUserID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return
+	}
+
+	var req ApiKeyDeleteRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.deleteApiKey(c, req.ID, userID)
+}
+
+func (h *handler) DeleteApiKeyById(c *gin.Context) {
+	userID, ok := utils.GetAuthUserID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return
+	}
+
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	h.deleteApiKey(c, id, userID)
+}
+
+func (h *handler) deleteApiKey(c *gin.Context, id int, userID int) {
+	err := h.service.DeleteApiKey(c.Request.Context(), id, userID)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success deleted data", nil)
+}
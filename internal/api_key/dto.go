@@ -0,0 +1,66 @@
+package api_key
+
+import (
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+type CreateApiKeyRequest struct {
+	Name      string   `json:"name" binding:"required,max=100"`
+	Scopes    []string `json:"scopes" binding:"required,min=1,dive,oneof=read-only content-write"`
+	ExpiresAt *string  `json:"expires_at" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type CreateApiKeyDTO struct {
+	UserID    int
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    string
+	ExpiresAt *time.Time
+}
+
+type ApiKeyResponse struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  *string  `json:"expires_at"`
+	LastUsedAt *string  `json:"last_used_at"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// ApiKeyCreatedResponse is the only response that carries the plain key; it cannot be retrieved again.
+type ApiKeyCreatedResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
+
+type ApiKeyDeleteRequest struct {
+	ID int `json:"id" binding:"required"`
+}
+
+func ToApiKeyResponse(p ApiKey) ApiKeyResponse {
+	var expiresAtPointer *string
+	if p.ExpiresAt != nil {
+		formattedExpiresAt := p.ExpiresAt.Format("2006-01-02 15:04:05")
+		expiresAtPointer = &formattedExpiresAt
+	}
+
+	var lastUsedAtPointer *string
+	if p.LastUsedAt != nil {
+		formattedLastUsedAt := p.LastUsedAt.Format("2006-01-02 15:04:05")
+		lastUsedAtPointer = &formattedLastUsedAt
+	}
+
+	return ApiKeyResponse{
+		ID:         p.ID,
+		Name:       p.Name,
+		Prefix:     p.Prefix,
+		Scopes:     utils.SplitScopes(p.Scopes),
+		ExpiresAt:  expiresAtPointer,
+		LastUsedAt: lastUsedAtPointer,
+		CreatedAt:  p.CreatedAt.Format("2006-01-02"),
+	}
+}
@@ -0,0 +1,38 @@
+package api_key
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+type handler struct {
+	service Service
+}
+
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	apiKey := r.Group("/api-keys")
+	//? keys are managed by a logged-in user only, never by another key
+	apiKey.Use(utils.RequireUserLogin())
+	{
+		apiKey.GET("", h.GetAll)
+		apiKey.GET("/:id", h.GetApiKeyById)
+		apiKey.POST("/store", h.CreateApiKey)
+		apiKey.POST("/delete", h.DeleteApiKey)
+	}
+}
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	apiKey := r.Group("/api-keys")
+	apiKey.Use(utils.RequireUserLogin())
+	{
+		apiKey.GET("", h.GetAll)
+		apiKey.GET("/:id", h.GetApiKeyById)
+		apiKey.POST("", h.CreateApiKey)
+		apiKey.DELETE("/:id", h.DeleteApiKeyById)
+	}
+}
@@ -0,0 +1,21 @@
+package api_key
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ApiKey struct {
+	ID         int    `json:"id" gorm:"primaryKey"`
+	UserID     int    `json:"user_id"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	KeyHash    string `json:"key_hash"`
+	Scopes     string `json:"scopes"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
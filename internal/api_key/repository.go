@@ -0,0 +1,89 @@
+package api_key
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAllByUser(ctx context.Context, userID int) ([]ApiKey, error)
+	FindByIdAndUser(ctx context.Context, id int, userID int) (ApiKey, error)
+	FindActiveByHash(ctx context.Context, keyHash string) (ApiKey, string, error)
+	CreateApiKey(ctx context.Context, p CreateApiKeyDTO) (ApiKey, error)
+	TouchLastUsed(ctx context.Context, id int, usedAt time.Time) error
+	DeleteApiKey(ctx context.Context, id int, userID int) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindAllByUser(ctx context.Context, userID int) ([]ApiKey, error) {
+	var keys []ApiKey
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *repository) FindByIdAndUser(ctx context.Context, id int, userID int) (ApiKey, error) {
+	var key ApiKey
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&key).Error
+	return key, err
+}
+
+// FindActiveByHash returns a non-expired key together with its owner's username.
+func (r *repository) FindActiveByHash(ctx context.Context, keyHash string) (ApiKey, string, error) {
+	var row struct {
+		ApiKey
+		Username string
+	}
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			k.*,
+			u.username
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id AND u.deleted_at IS NULL
+		WHERE
+			k.key_hash = ? AND
+			k.deleted_at IS NULL AND
+			(k.expires_at IS NULL OR k.expires_at > ?)
+		LIMIT 1
+	`, keyHash, time.Now()).Scan(&row).Error
+
+	if err != nil {
+		return ApiKey{}, "", err
+	}
+
+	if row.ID == 0 {
+		return ApiKey{}, "", gorm.ErrRecordNotFound
+	}
+
+	return row.ApiKey, row.Username, nil
+}
+
+func (r *repository) CreateApiKey(ctx context.Context, p CreateApiKeyDTO) (ApiKey, error) {
+	data := ApiKey{
+		UserID:    p.UserID,
+		Name:      p.Name,
+		Prefix:    p.Prefix,
+		KeyHash:   p.KeyHash,
+		Scopes:    p.Scopes,
+		ExpiresAt: p.ExpiresAt,
+	}
+	err := r.db.WithContext(ctx).Create(&data).Error
+	return data, err
+}
+
+func (r *repository) TouchLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&ApiKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+}
+
+func (r *repository) DeleteApiKey(ctx context.Context, id int, userID int) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&ApiKey{}).Error
+}
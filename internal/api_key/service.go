@@ -0,0 +1,116 @@
+package api_key
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// lastUsedResolution limits how often last_used_at is written for a busy key.
+const lastUsedResolution = time.Minute
+
+type Service interface {
+	GetAllApiKeys(ctx context.Context, userID int) ([]ApiKeyResponse, error)
+	GetApiKeyById(ctx context.Context, id int, userID int) (ApiKeyResponse, error)
+	CreateApiKey(ctx context.Context, userID int, req CreateApiKeyRequest) (ApiKeyCreatedResponse, error)
+	DeleteApiKey(ctx context.Context, id int, userID int) error
+	Authenticate(ctx context.Context, rawKey string) (utils.APIKeyPrincipal, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{repo: r}
+}
+
+func (s *service) GetAllApiKeys(ctx context.Context, userID int) ([]ApiKeyResponse, error) {
+	datas, err := s.repo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ApiKeyResponse
+	for _, p := range datas {
+		result = append(result, ToApiKeyResponse(p))
+	}
+	return result, nil
+}
+
+func (s *service) GetApiKeyById(ctx context.Context, id int, userID int) (ApiKeyResponse, error) {
+	data, err := s.repo.FindByIdAndUser(ctx, id, userID)
+	if err != nil {
+		return ApiKeyResponse{}, err
+	}
+	return ToApiKeyResponse(data), nil
+}
+
+func (s *service) CreateApiKey(ctx context.Context, userID int, req CreateApiKeyRequest) (ApiKeyCreatedResponse, error) {
+	//todo: Parse Expiry
+	expiresAt, err := utils.ParseStringPtrToTimePtr(req.ExpiresAt, "2006-01-02")
+	if err != nil {
+		return ApiKeyCreatedResponse{}, err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return ApiKeyCreatedResponse{}, utils.NewError("expires_at must be in the future")
+	}
+
+	//todo: Generate Key
+	rawKey, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		return ApiKeyCreatedResponse{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	payload := CreateApiKeyDTO{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   utils.HashAPIKey(rawKey),
+		Scopes:    strings.Join(req.Scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+
+	data, err := s.repo.CreateApiKey(ctx, payload)
+	if err != nil {
+		return ApiKeyCreatedResponse{}, err
+	}
+
+	return ApiKeyCreatedResponse{
+		ApiKeyResponse: ToApiKeyResponse(data),
+		Key:            rawKey,
+	}, nil
+}
+
+func (s *service) DeleteApiKey(ctx context.Context, id int, userID int) error {
+	_, err := s.repo.FindByIdAndUser(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteApiKey(ctx, id, userID)
+}
+
+func (s *service) Authenticate(ctx context.Context, rawKey string) (utils.APIKeyPrincipal, error) {
+	key, username, err := s.repo.FindActiveByHash(ctx, utils.HashAPIKey(rawKey))
+	if err != nil {
+		return utils.APIKeyPrincipal{}, err
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > lastUsedResolution {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			utils.LoggerFrom(ctx).WithField("api_key_id", key.ID).Warn("failed to update api key last_used_at: ", err)
+		}
+	}
+
+	return utils.APIKeyPrincipal{
+		KeyID:    key.ID,
+		UserID:   key.UserID,
+		Username: username,
+		Scopes:   utils.SplitScopes(key.Scopes),
+	}, nil
+}
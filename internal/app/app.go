@@ -1,22 +1,175 @@
 package app
 
 import (
-	"os"
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/router"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/idempotency"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/system"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"gorm.io/gorm"
 )
 
 func Run() {
 	utils.InitLogger()
 
-	db := config.InitDB()
-	r := router.SetupRouter(db)
+	cfg, err := config.Load()
+	if err != nil {
+		utils.Logger.Fatal("❌ ", err)
+	}
+	serverCfg := cfg.Server
+
+	if err := utils.ConfigureLogger(cfg.Log.Format, cfg.Log.Level); err != nil {
+		utils.Logger.Fatal("❌ Invalid log configuration: ", err)
+	}
+
+	if cfg.App.IsDemo() {
+		if err := initDemo(cfg); err != nil {
+			utils.Logger.Fatal("❌ Failed to prepare demo mode: ", err)
+		}
+	} else {
+		if err := utils.InitJWTKeys(cfg.JWT.KeysDir, cfg.JWT.ActiveKid, cfg.JWT.TokenTTL); err != nil {
+			utils.Logger.Fatal("❌ Invalid JWT key configuration: ", err)
+		}
+	}
+
+	storage, err := container.NewStorage(cfg)
+	if err != nil {
+		utils.Logger.Fatal("❌ Invalid storage configuration: ", err)
+	}
+
+	//? cancelled on SIGINT/SIGTERM, which also aborts DB connect retries during boot
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := utils.InitTracing(ctx, utils.TracingConfig{
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: system.Version,
+		Environment:    cfg.App.Env,
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.Endpoint,
+		Insecure:       cfg.Tracing.Insecure,
+		FilePath:       cfg.Tracing.FilePath,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		utils.Logger.Fatal("❌ Invalid tracing configuration: ", err)
+	}
+
+	var c *container.Container
+	var db, readDB *gorm.DB
+	if cfg.App.IsDemo() {
+		if c, err = newDemoContainer(cfg, storage); err != nil {
+			utils.Logger.Fatal("❌ Failed to seed demo data: ", err)
+		}
+	} else {
+		db, err = config.InitDB(ctx, cfg.Database)
+		if err != nil {
+			utils.Logger.Fatal("❌ Failed to connect to DB: ", err)
+		}
+		readDB, err = config.InitReadDB(ctx, cfg.Database, db)
+		if err != nil {
+			utils.Logger.Fatal("❌ Failed to connect to DB replica: ", err)
+		}
+		registerDBMetrics(db, cfg.Database.Name)
+		if readDB != db {
+			registerDBMetrics(readDB, cfg.Database.Name+"_replica")
+		}
+		c = container.New(cfg, db, readDB, storage)
+	}
+
+	go purgeIdempotencyKeys(ctx, c.Services.Idempotency, cfg.Idempotency.PurgeInterval)
+
+	r := router.SetupRouter(c)
+
+	srv := &http.Server{
+		Addr:              ":" + serverCfg.Port,
+		Handler:           r,
+		ReadTimeout:       serverCfg.ReadTimeout,
+		ReadHeaderTimeout: serverCfg.ReadHeaderTimeout,
+		WriteTimeout:      serverCfg.WriteTimeout,
+		IdleTimeout:       serverCfg.IdleTimeout,
+		MaxHeaderBytes:    serverCfg.MaxHeaderBytes,
+	}
 
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "4000"
+	serverErr := make(chan error, 1)
+	go func() {
+		utils.Logger.Infof("🚀 Server listening on %s (tls=%t)", srv.Addr, serverCfg.TLSEnabled())
+		if serverCfg.TLSEnabled() {
+			serverErr <- srv.ListenAndServeTLS(serverCfg.TLSCertFile, serverCfg.TLSKeyFile)
+		} else {
+			serverErr <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			utils.Logger.Error("❌ Server stopped: ", err)
+		}
+	case <-ctx.Done():
+		utils.Logger.Info("🛑 Shutdown signal received, draining requests")
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverCfg.ShutdownTimeout)
+	defer cancel()
+
+	//todo: Stop accepting requests and wait for in-flight ones
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		utils.Logger.Error("❌ Server shutdown: ", err)
+	}
+
+	//todo: Close DB pool
+	if db != nil {
+		if err := config.CloseDBs(db, readDB); err != nil {
+			utils.Logger.Error("❌ Closing DB: ", err)
+		}
+	}
+
+	//todo: Flush pending spans
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		utils.Logger.Error("❌ Flushing traces: ", err)
+	}
+
+	utils.Logger.Info("✅ Server stopped")
+}
+
+// purgeIdempotencyKeys deletes expired Idempotency-Keys every interval until
+// ctx is cancelled, so the table does not grow with keys nobody reuses.
+func purgeIdempotencyKeys(ctx context.Context, service idempotency.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := service.PurgeExpiredKeys(ctx, now)
+			if err != nil {
+				utils.Logger.Error("❌ Purging idempotency keys: ", err)
+				continue
+			}
+			if deleted > 0 {
+				utils.Logger.Infof("🧹 Purged %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
+
+func registerDBMetrics(db *gorm.DB, name string) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return
+	}
+	if err := utils.RegisterDBMetrics(sqlDB, name); err != nil {
+		utils.Logger.Warn("failed to register DB pool metrics: ", err)
 	}
-	r.Run(":" + port)
 }
@@ -0,0 +1,178 @@
+// Package container is the composition root: every repository and service is
+// built here once and shared by all route groups.
+package container
+
+import (
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/api_key"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/auth"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/idempotency"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/public"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/system"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Repositories holds one implementation per module. Any field can be
+// replaced, e.g. by a fake, before the set is passed to NewServices.
+type Repositories struct {
+	About               about.Repository
+	ApiKey              api_key.Repository
+	Auth                auth.Repository
+	Author              author.Repository
+	Blog                blog.Repository
+	BlogContentImage    blog_content_image.Repository
+	BlogTopic           blog_topic.Repository
+	Editorial           editorial.Repository
+	Experience          experience.Repository
+	Idempotency         idempotency.Repository
+	Project             project.Repository
+	ProjectContentImage project_content_image.Repository
+	ProjectTechnology   project_technology.Repository
+	Public              public.Repository
+	ReadingTime         reading_time.Repository
+	Statistic           statistic.Repository
+	System              system.Repository
+	Technology          technology.Repository
+	Testimonial         testimonial.Repository
+	Topic               topic.Repository
+	User                user.Repository
+}
+
+// Services holds the service every handler is built from.
+type Services struct {
+	About               about.Service
+	ApiKey              api_key.Service
+	Auth                auth.Service
+	Author              author.Service
+	Blog                blog.Service
+	BlogContentImage    blog_content_image.Service
+	BlogTopic           blog_topic.Service
+	Editorial           editorial.Service
+	Experience          experience.Service
+	Idempotency         idempotency.Service
+	Project             project.Service
+	ProjectContentImage project_content_image.Service
+	ProjectTechnology   project_technology.Service
+	Public              public.Service
+	ReadingTime         reading_time.Service
+	Statistic           statistic.Service
+	System              system.Service
+	Technology          technology.Service
+	Testimonial         testimonial.Service
+	Topic               topic.Service
+	User                user.Service
+}
+
+// Container is everything the router needs to mount the API.
+type Container struct {
+	Config   *config.Config
+	Repos    Repositories
+	Services Services
+	Storage  utils.Storage
+}
+
+// New wires the GORM repositories and their services. readDB serves the
+// public reads and may be db itself.
+func New(cfg *config.Config, db, readDB *gorm.DB, storage utils.Storage) *Container {
+	repos := NewRepositories(db, readDB)
+	return &Container{
+		Config:   cfg,
+		Repos:    repos,
+		Services: NewServices(repos, db, storage),
+		Storage:  storage,
+	}
+}
+
+// NewRepositories builds the GORM-backed repositories.
+func NewRepositories(db, readDB *gorm.DB) Repositories {
+	return Repositories{
+		About:               about.NewRepository(db),
+		ApiKey:              api_key.NewRepository(db),
+		Auth:                auth.NewRepository(db),
+		Author:              author.NewRepository(db),
+		Blog:                blog.NewRepository(db),
+		BlogContentImage:    blog_content_image.NewRepository(db),
+		BlogTopic:           blog_topic.NewRepository(db),
+		Editorial:           editorial.NewRepository(db),
+		Experience:          experience.NewRepository(db),
+		Idempotency:         idempotency.NewRepository(db),
+		Project:             project.NewRepository(db),
+		ProjectContentImage: project_content_image.NewRepository(db),
+		ProjectTechnology:   project_technology.NewRepository(db),
+		Public:              public.NewRepository(db, readDB),
+		ReadingTime:         reading_time.NewRepository(db),
+		Statistic:           statistic.NewRepository(db),
+		System:              system.NewRepository(db, readDB),
+		Technology:          technology.NewRepository(db),
+		Testimonial:         testimonial.NewRepository(db),
+		Topic:               topic.NewRepository(db),
+		User:                user.NewRepository(db),
+	}
+}
+
+// NewServices builds every service on top of repos. db is the handle the
+// blog and project services open their transactions on, storage keeps the
+// files the services upload.
+func NewServices(repos Repositories, db *gorm.DB, storage utils.Storage) Services {
+	var s Services
+
+	//* leaf services first, the composed ones depend on them
+	s.About = about.NewService(repos.About, storage)
+	s.ApiKey = api_key.NewService(repos.ApiKey)
+	s.Auth = auth.NewService(repos.Auth)
+	s.Author = author.NewService(repos.Author, storage)
+	s.BlogContentImage = blog_content_image.NewService(repos.BlogContentImage, storage)
+	s.BlogTopic = blog_topic.NewService(repos.BlogTopic)
+	s.Editorial = editorial.NewService(repos.Editorial)
+	s.Experience = experience.NewService(repos.Experience, storage)
+	s.Idempotency = idempotency.NewService(repos.Idempotency)
+	s.ProjectContentImage = project_content_image.NewService(repos.ProjectContentImage, storage)
+	s.ProjectTechnology = project_technology.NewService(repos.ProjectTechnology)
+	s.Public = public.NewService(repos.Public)
+	s.ReadingTime = reading_time.NewService(repos.ReadingTime)
+	s.Statistic = statistic.NewService(repos.Statistic)
+	s.System = system.NewService(repos.System, storage)
+	s.Technology = technology.NewService(repos.Technology, storage)
+	s.Testimonial = testimonial.NewService(repos.Testimonial)
+	s.Topic = topic.NewService(repos.Topic)
+
+	s.User = user.NewService(s.Author, repos.User)
+
+	s.Blog = blog.NewService(
+		s.Author,
+		s.Topic,
+		s.Statistic,
+		s.ReadingTime,
+		s.BlogTopic,
+		s.BlogContentImage,
+		s.Editorial,
+		s.User,
+		repos.Blog, db, storage)
+
+	s.Project = project.NewService(
+		s.ProjectTechnology,
+		s.ProjectContentImage,
+		s.Statistic,
+		s.Editorial,
+		s.User,
+		repos.Project, db, storage)
+
+	return s
+}
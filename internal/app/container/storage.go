@@ -0,0 +1,30 @@
+package container
+
+import (
+	"strings"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// NewStorage builds the file storage of cfg: the MinIO bucket, or in demo
+// mode a local directory whose files the router serves.
+func NewStorage(cfg *config.Config) (utils.Storage, error) {
+	if cfg.App.IsDemo() {
+		baseURL := strings.TrimSuffix(cfg.Storage.ViewEndpoint, "/")
+		if baseURL == "" {
+			baseURL = "http://localhost:" + cfg.Server.Port
+		}
+		return utils.NewLocalStorage(cfg.Storage.LocalDir, baseURL)
+	}
+
+	return utils.NewMinioStorage(utils.MinioConfig{
+		UploadEndpoint:  cfg.Storage.UploadEndpoint,
+		ViewEndpoint:    cfg.Storage.ViewEndpoint,
+		AccessKeyID:     cfg.Storage.AccessKeyID,
+		SecretAccessKey: cfg.Storage.SecretAccessKey,
+		UseSSL:          cfg.Storage.UseSSL,
+		Bucket:          cfg.Storage.Bucket,
+		ViewSSL:         cfg.App.IsProduction(),
+	})
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/memory"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// initDemo replaces the JWT keys of a normal boot: without provisioned key
+// files, tokens are signed with an in-memory key. Uploads go to the local
+// directory container.NewStorage picks for demo mode.
+func initDemo(cfg *config.Config) error {
+	if err := utils.InitJWTKeys(cfg.JWT.KeysDir, cfg.JWT.ActiveKid, cfg.JWT.TokenTTL); err != nil {
+		utils.Logger.Warn("⚠️ No usable JWT keys, signing with an ephemeral key: ", err)
+		if err := utils.InitEphemeralJWTKey(cfg.JWT.TokenTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newDemoContainer wires the services on an in-memory store filled with
+// sample content. Nothing is persisted across restarts.
+func newDemoContainer(cfg *config.Config, storage utils.Storage) (*container.Container, error) {
+	store, err := memory.NewSeededStore()
+	if err != nil {
+		return nil, err
+	}
+
+	repos := memory.NewRepositories(store)
+	utils.Logger.Warnf("🧪 Demo mode: in-memory data, uploads in %s, login %s / %s", cfg.Storage.LocalDir, memory.DemoEmail, memory.DemoPassword)
+	return &container.Container{
+		Config:   cfg,
+		Repos:    repos,
+		Services: container.NewServices(repos, nil, storage),
+		Storage:  storage,
+	}, nil
+}
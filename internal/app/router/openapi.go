@@ -0,0 +1,311 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/api_key"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/auth"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/openapi"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/public"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/system"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+const (
+	OpenAPIPath = "/openapi.json"
+	DocsPath    = "/docs"
+)
+
+// OpenAPI documents every route SetupRouter registers. A route added there
+// must be added here too, `go run ./cmd/openapi -check` fails otherwise.
+func OpenAPI(localStorage bool) *openapi.Document {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "Portfolio API",
+		Version:     "1.0.0",
+		Description: "Admin API under /api/v2, authenticated with a JWT or an API key, and the read-only site API under /api-public. The admin routes directly under /api, which take IDs in POST bodies, are deprecated.",
+	})
+
+	b.Add(
+		openapi.Route{Method: http.MethodGet, Path: "/metrics", Tag: "system", Summary: "Prometheus metrics", Public: true, ContentType: "text/plain"},
+		openapi.Route{Method: http.MethodGet, Path: "/healthz", Tag: "system", Summary: "Liveness probe", Public: true, Data: map[string]string{}},
+		openapi.Route{Method: http.MethodGet, Path: "/readyz", Tag: "system", Summary: "Readiness probe", Public: true, Data: system.ReadinessResponse{}, Errors: []int{http.StatusServiceUnavailable}},
+		openapi.Route{Method: http.MethodGet, Path: "/.well-known/jwks.json", Tag: "auth", Summary: "Keys that verify issued tokens", Public: true, Raw: struct {
+			Keys []utils.JWK `json:"keys"`
+		}{}},
+		openapi.Route{Method: http.MethodGet, Path: OpenAPIPath, Tag: "system", Summary: "This document", Public: true, ContentType: "application/json"},
+		openapi.Route{Method: http.MethodGet, Path: DocsPath, Tag: "system", Summary: "Interactive documentation", Public: true, ContentType: "text/html"},
+	)
+	if localStorage {
+		b.Add(openapi.Route{
+			Method: http.MethodGet, Path: "/" + utils.LocalStorageBucket + "/*filepath", Tag: "system", Public: true,
+			Summary: "Files uploaded in demo mode", ContentType: "application/octet-stream",
+		})
+	}
+
+	for _, prefix := range []string{"/api", "/api/v2"} {
+		b.Add(
+			openapi.Route{Method: http.MethodPost, Path: prefix + "/auth/register", Tag: "auth", Summary: "Register a user", Public: true, JSON: auth.RegisterUserRequest{}, Data: auth.RegisterResponse{}, Errors: []int{http.StatusConflict}, Deprecated: prefix == "/api", Idempotent: true},
+			openapi.Route{Method: http.MethodPost, Path: prefix + "/auth/login", Tag: "auth", Summary: "Log in for an access token", Public: true, JSON: auth.LoginUserRequest{}, Data: auth.LoginResponse{}, Errors: []int{http.StatusUnauthorized}, Deprecated: prefix == "/api"},
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/system/info", Tag: "system", Summary: "Build and runtime information, admins only", Data: system.SystemInfoResponse{}, Deprecated: prefix == "/api"},
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/api-keys", Tag: "api-keys", Summary: "List the caller's API keys", Data: api_key.ApiKeyResponse{}, List: true, Deprecated: prefix == "/api"},
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/api-keys/:id", Tag: "api-keys", Summary: "Get an API key", Data: api_key.ApiKeyResponse{}, Deprecated: prefix == "/api"},
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/users", Tag: "users", Summary: "List users", Query: withPagination("username", "email", "created_at"), Data: user.UserResponse{}, Paginated: true, Deprecated: prefix == "/api"},
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/users/:id", Tag: "users", Summary: "Get a user", Data: user.UserResponse{}, Deprecated: prefix == "/api"},
+		)
+	}
+
+	b.Add(deprecated(
+		openapi.Route{Method: http.MethodPost, Path: "/api/api-keys/store", Tag: "api-keys", Summary: "Create an API key, the key is only returned here", JSON: api_key.CreateApiKeyRequest{}, Data: api_key.ApiKeyCreatedResponse{}, Status: http.StatusCreated, Idempotent: true},
+		openapi.Route{Method: http.MethodPost, Path: "/api/api-keys/delete", Tag: "api-keys", Summary: "Revoke an API key", JSON: api_key.ApiKeyDeleteRequest{}},
+
+		openapi.Route{Method: http.MethodPost, Path: "/api/users/update", Tag: "users", Summary: "Update a user", JSON: user.UserUpdateRequest{}, Data: user.UserResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/users/delete", Tag: "users", Summary: "Delete a user", JSON: user.UserDeleteRequest{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/users/link-author", Tag: "users", Summary: "Link a user to an author profile", JSON: user.UserLinkAuthorRequest{}, Data: user.UserResponse{}},
+	)...)
+	b.Add(
+		openapi.Route{Method: http.MethodPost, Path: "/api/v2/api-keys", Tag: "api-keys", Summary: "Create an API key, the key is only returned here", JSON: api_key.CreateApiKeyRequest{}, Data: api_key.ApiKeyCreatedResponse{}, Status: http.StatusCreated, Location: true, Idempotent: true},
+		openapi.Route{Method: http.MethodDelete, Path: "/api/v2/api-keys/:id", Tag: "api-keys", Summary: "Revoke an API key"},
+
+		openapi.Route{Method: http.MethodPatch, Path: "/api/v2/users/:id", Tag: "users", Summary: "Update a user", JSON: user.UserUpdateRequest{}, Partial: true, Data: user.UserResponse{}},
+		openapi.Route{Method: http.MethodDelete, Path: "/api/v2/users/:id", Tag: "users", Summary: "Delete a user"},
+		openapi.Route{Method: http.MethodPut, Path: "/api/v2/users/:id/author", Tag: "users", Summary: "Link a user to an author profile, or unlink it with a null author_id", JSON: user.UserLinkAuthorRequest{}, Data: user.UserResponse{}},
+	)
+
+	for _, r := range []resource{
+		{
+			tag: "authors", path: "/authors", one: "author", many: "authors", item: author.AuthorResponse{}, query: withPagination("name", "created_at"),
+			create: author.CreateAuthorRequest{}, update: author.UpdateAuthorRequest{}, delete: author.AuthorDeleteRequest{}, multipart: true,
+		},
+		{
+			tag: "abouts", path: "/abouts", one: "about", many: "abouts", item: about.AboutResponse{},
+			create: about.CreateAboutRequest{}, update: about.UpdateAboutRequest{}, delete: about.AboutDeleteRequest{}, multipart: true, versioned: true,
+		},
+		{
+			tag: "technologies", path: "/technologies", one: "technology", many: "technologies", item: technology.TechnologyResponse{}, query: withPagination("name", "description_html", "is_major", "created_at"),
+			create: technology.CreateTechnologyRequest{}, update: technology.UpdateTechnologyRequest{}, delete: technology.TechnologyDeleteRequest{}, multipart: true, versioned: true,
+			deleted: technology.Technology{},
+		},
+		{
+			tag: "statistics", path: "/statistics", one: "statistic", many: "statistics", item: statistic.StatisticResponse{},
+			query:  withPagination("type", "min_likes", "max_likes", "min_views", "max_views", "created_at"),
+			create: statistic.CreateStatisticRequest{}, update: statistic.UpdateStatisticRequest{}, delete: statistic.StatisticDeleteRequest{},
+			deleted: statistic.Statistic{},
+		},
+		{
+			tag: "projects", path: "/project-content-images", one: "project content image", many: "project content images", item: project_content_image.ProjectContentImageResponse{},
+			create: project_content_image.CreateProjectContentImageRequest{}, update: project_content_image.UpdateProjectContentImageRequest{},
+			delete: project_content_image.ProjectContentImageDeleteRequest{}, multipart: true,
+			deleted: project_content_image.ProjectContentImageResponse{},
+		},
+		{
+			tag: "projects", path: "/project-technologies", one: "project technology", many: "project technologies", item: project_technology.ProjectTechnologyResponse{},
+			create: project_technology.CreateProjectTechnologyRequest{}, update: project_technology.UpdateProjectTechnologyRequest{},
+			delete: project_technology.ProjectTechnologyDeleteRequest{}, deleted: project_technology.ProjectTechnology{},
+		},
+		{
+			tag: "topics", path: "/topics", one: "topic", many: "topics", item: topic.TopicResponse{}, query: withPagination("name", "created_at"),
+			create: topic.CreateTopicRequest{}, update: topic.UpdateTopicRequest{}, delete: topic.TopicDeleteRequest{},
+			deleted: topic.Topic{},
+		},
+		{
+			tag: "reading-times", path: "/reading-times", one: "reading time", many: "reading times", item: reading_time.ReadingTimeResponse{},
+			query:  withPagination("min_minutes", "max_minutes", "min_estimates", "max_estimates", "created_at"),
+			create: reading_time.CreateReadingTimeRequest{}, update: reading_time.UpdateReadingTimeRequest{}, delete: reading_time.ReadingTimeDeleteRequest{},
+			deleted: reading_time.ReadingTime{},
+		},
+		{
+			tag: "blogs", path: "/blog-topics", one: "blog topic", many: "blog topics", item: blog_topic.BlogTopicResponse{},
+			create: blog_topic.CreateBlogTopicRequest{}, update: blog_topic.UpdateBlogTopicRequest{}, delete: blog_topic.BlogTopicDeleteRequest{},
+			deleted: blog_topic.BlogTopic{},
+		},
+		{
+			tag: "blogs", path: "/blog-content-images", one: "blog content image", many: "blog content images", item: blog_content_image.BlogContentImageResponse{},
+			create: blog_content_image.CreateBlogContentImageRequest{}, update: blog_content_image.UpdateBlogContentImageRequest{},
+			delete: blog_content_image.BlogContentImageDeleteRequest{}, multipart: true,
+			deleted: blog_content_image.BlogContentImageResponse{},
+		},
+		{
+			tag: "experiences", path: "/experiences", one: "experience", many: "experiences", item: experience.ExperienceResponse{},
+			query:  withPagination("position", "company_name", "work_type", "country", "city", "summary_html", "from_date", "to_date", "is_current", "created_at"),
+			create: experience.CreateExperienceRequest{}, update: experience.UpdateExperienceRequest{}, delete: experience.ExperienceDeleteRequest{}, multipart: true, versioned: true,
+			deleted: experience.Experience{},
+		},
+		{
+			tag: "testimonials", path: "/testimonials", one: "testimonial", many: "testimonials", item: testimonial.TestimonialResponse{},
+			query:  withPagination("name", "role", "working_at", "is_used", "created_at"),
+			create: testimonial.CreateTestimonialRequest{}, update: testimonial.UpdateTestimonialRequest{}, delete: testimonial.TestimonialDeleteRequest{},
+			deleted: testimonial.Testimonial{},
+		},
+		{
+			tag: "projects", path: "/projects", one: "project", many: "projects", item: project.ProjectRelationResponse{}, listItem: project.ProjectResponse{},
+			query:  withPagination("title", "slug", "status", "published_at", "created_at"),
+			create: project.CreateProjectRequest{}, created: project.ProjectResponse{},
+			update: project.UpdateProjectRequest{}, updated: project.ProjectUpdateResponse{},
+			delete: project.ProjectDeleteRequest{}, multipart: true, deleted: project.Project{}, versioned: true,
+		},
+		{
+			tag: "blogs", path: "/blogs", one: "blog", many: "blogs", item: blog.BlogRelationResponse{}, listItem: blog.BlogResponse{},
+			query:  withPagination("title", "slug", "status", "published_at", "created_at", "mine"),
+			create: blog.CreateBlogRequest{}, created: blog.BlogResponse{},
+			update: blog.UpdateBlogRequest{}, updated: blog.BlogUpdateResponse{},
+			delete: blog.BlogDeleteRequest{}, multipart: true, deleted: blog.Blog{}, versioned: true,
+		},
+	} {
+		b.Add(r.routes()...)
+	}
+
+	b.Add(deprecated(
+		openapi.Route{Method: http.MethodPost, Path: "/api/testimonials/change-status", Tag: "testimonials", Summary: "Show or hide a testimonial", JSON: testimonial.TestimonialChangeStatusRequest{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/testimonials/bulk-change-status", Tag: "testimonials", Summary: "Show or hide several testimonials", JSON: testimonial.TestimonialChangeMultiStatusRequest{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/topics/check-has-ids", Tag: "topics", Summary: "Report which topic ids exist", JSON: topic.TopicCheckIdsRequest{}, Data: topic.TopicHasCheckResponse{}, List: true},
+		openapi.Route{Method: http.MethodPost, Path: "/api/projects/update-statistic", Tag: "projects", Summary: "Set the likes and views of a project", JSON: project.ProjectStatisticUpdateRequest{}, Data: project.ProjectStatisticUpdateResponse{}},
+
+		openapi.Route{Method: http.MethodPost, Path: "/api/projects/change-status", Tag: "projects", Summary: "Move a project one step through the editorial workflow", JSON: project.ProjectChangeStatusRequest{}, Data: project.ProjectChangeStatusResponse{}, Errors: []int{http.StatusConflict}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/projects/assign-reviewer", Tag: "projects", Summary: "Assign the reviewer of a project", JSON: project.ProjectAssignReviewerRequest{}, Data: project.ProjectResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/projects/comment", Tag: "projects", Summary: "Comment on a project under review", JSON: project.ProjectCommentRequest{}, Idempotent: true},
+
+		openapi.Route{Method: http.MethodPost, Path: "/api/blogs/change-status", Tag: "blogs", Summary: "Move a blog one step through the editorial workflow", JSON: blog.BlogChangeStatusRequest{}, Data: blog.BlogChangeStatusResponse{}, Errors: []int{http.StatusConflict}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/blogs/assign-reviewer", Tag: "blogs", Summary: "Assign the reviewer of a blog", JSON: blog.BlogAssignReviewerRequest{}, Data: blog.BlogResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/blogs/comment", Tag: "blogs", Summary: "Comment on a blog under review", JSON: blog.BlogCommentRequest{}, Idempotent: true},
+	)...)
+	b.Add(
+		openapi.Route{Method: http.MethodPatch, Path: "/api/v2/testimonials", Tag: "testimonials", Summary: "Show or hide several testimonials", JSON: testimonial.TestimonialChangeMultiStatusRequest{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/v2/topics/check-has-ids", Tag: "topics", Summary: "Report which topic ids exist", JSON: topic.TopicCheckIdsRequest{}, Data: topic.TopicHasCheckResponse{}, List: true},
+		openapi.Route{Method: http.MethodPatch, Path: "/api/v2/projects/:id/statistic", Tag: "projects", Summary: "Set the likes or views of a project",
+			Description: "project_id and statistic_id are taken from the project, omitted counters keep their value.",
+			JSON:        project.ProjectStatisticUpdateRequest{}, Partial: true, Data: project.ProjectStatisticUpdateResponse{}},
+	)
+	for _, prefix := range []string{"/api", "/api/v2"} {
+		b.Add(
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/projects/:id/reviews", Tag: "projects", Summary: "Editorial history of a project", Data: editorial.EventResponse{}, List: true, Deprecated: prefix == "/api"},
+			openapi.Route{Method: http.MethodGet, Path: prefix + "/blogs/:id/reviews", Tag: "blogs", Summary: "Editorial history of a blog", Data: editorial.EventResponse{}, List: true, Deprecated: prefix == "/api"},
+		)
+	}
+	b.Add(
+		openapi.Route{Method: http.MethodPost, Path: "/api/v2/projects/:id/status", Tag: "projects", Summary: "Move a project one step through the editorial workflow", JSON: project.ProjectChangeStatusRequest{}, Data: project.ProjectChangeStatusResponse{}, Errors: []int{http.StatusConflict}},
+		openapi.Route{Method: http.MethodPut, Path: "/api/v2/projects/:id/reviewer", Tag: "projects", Summary: "Assign the reviewer of a project", JSON: project.ProjectAssignReviewerRequest{}, Data: project.ProjectResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/v2/projects/:id/comments", Tag: "projects", Summary: "Comment on a project under review", JSON: project.ProjectCommentRequest{}, Idempotent: true},
+
+		openapi.Route{Method: http.MethodPost, Path: "/api/v2/blogs/:id/status", Tag: "blogs", Summary: "Move a blog one step through the editorial workflow", JSON: blog.BlogChangeStatusRequest{}, Data: blog.BlogChangeStatusResponse{}, Errors: []int{http.StatusConflict}},
+		openapi.Route{Method: http.MethodPut, Path: "/api/v2/blogs/:id/reviewer", Tag: "blogs", Summary: "Assign the reviewer of a blog", JSON: blog.BlogAssignReviewerRequest{}, Data: blog.BlogResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api/v2/blogs/:id/comments", Tag: "blogs", Summary: "Comment on a blog under review", JSON: blog.BlogCommentRequest{}, Idempotent: true},
+	)
+
+	publicList := append(withPagination(), openapi.Param{Name: "search", Type: "string"},
+		openapi.Param{Name: "is_highlight", Type: "string", Enum: []string{"Y", "N"}})
+	b.Add(
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/profile", Tag: "public", Public: true, Summary: "Site owner profile", Data: public.ProfilePublicResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/blogs", Tag: "public", Public: true, Summary: "Published blogs",
+			Query: append(publicList, openapi.Param{Name: "topics", Type: "string", Description: "comma separated topic ids"}), Data: public.BlogPublicResponse{}, Paginated: true},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/blogs/:slug", Tag: "public", Public: true, Summary: "A published blog", Data: public.SingleBlogPublicResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/testimonials", Tag: "public", Public: true, Summary: "Shown testimonials", Data: public.TestimonialPublicResponse{}, List: true},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/topics", Tag: "public", Public: true, Summary: "Topics", Data: public.TopicPublicResponse{}, List: true},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/projects", Tag: "public", Public: true, Summary: "Published projects", Query: publicList, Data: public.ProjectPublicResponse{}, Paginated: true},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/projects/:slug", Tag: "public", Public: true, Summary: "A published project", Data: public.SingleProjectPublicResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/technologies", Tag: "public", Public: true, Summary: "Technologies", Data: public.TechnologyPublicResponse{}, List: true},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/authors", Tag: "public", Public: true, Summary: "Authors", Data: public.AuthorPublicResponse{}, List: true},
+		openapi.Route{Method: http.MethodGet, Path: "/api-public/experiences", Tag: "public", Public: true, Summary: "Work experiences", Data: public.ExperiencesPublicResponse{}, List: true},
+		openapi.Route{Method: http.MethodPost, Path: "/api-public/update-statistic-project", Tag: "public", Public: true, Summary: "Count a like or view of a project",
+			JSON: public.ProjectStatisticUpdatePublicRequest{}, Data: public.ProjectStatisticUpdatePubblicResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/api-public/update-statistic-blog", Tag: "public", Public: true, Summary: "Count a like or view of a blog",
+			JSON: public.BlogStatisticUpdatePublicRequest{}, Data: public.BlogStatisticUpdatePubblicResponse{}},
+	)
+
+	return b.Document()
+}
+
+// resource documents the routes most modules register: GET list, GET /:id,
+// /store, /update and /delete under /api, and GET, POST, PATCH /:id and
+// DELETE /:id under /api/v2.
+type resource struct {
+	tag, path string          // path of the collection below the version, e.g. /blogs
+	one, many string          // nouns for the summaries, e.g. blog and blogs
+	item      any             // data of GET /:id, and of the list, store and delete unless overridden
+	listItem  any             // item of the list when it differs from item
+	query     []openapi.Param // filters of a paginated list, nil for a plain one
+	multipart bool            // store and update take multipart/form-data
+	versioned bool            // updates send the version of the record
+	create    any
+	created   any
+	update    any
+	updated   any // data of update, empty when nil
+	delete    any
+	deleted   any // data of delete, empty when nil
+}
+
+func (r resource) routes() []openapi.Route {
+	listItem := r.listItem
+	if listItem == nil {
+		listItem = r.item
+	}
+	created := r.created
+	if created == nil {
+		created = r.item
+	}
+	body := func(route openapi.Route, v any) openapi.Route {
+		if r.multipart {
+			route.Form = v
+		} else {
+			route.JSON = v
+		}
+		return route
+	}
+	v1, v2 := "/api"+r.path, "/api/v2"+r.path
+
+	routes := deprecated(
+		openapi.Route{Method: http.MethodGet, Path: v1, Tag: r.tag, Summary: "List " + r.many, Query: r.query, Data: listItem, List: r.query == nil, Paginated: r.query != nil},
+		openapi.Route{Method: http.MethodGet, Path: v1 + "/:id", Tag: r.tag, Summary: "Get " + withArticle(r.one), Data: r.item, Versioned: r.versioned},
+		body(openapi.Route{Method: http.MethodPost, Path: v1 + "/store", Tag: r.tag, Summary: "Create " + withArticle(r.one), Data: created, Idempotent: true}, r.create),
+		body(openapi.Route{Method: http.MethodPost, Path: v1 + "/update", Tag: r.tag, Summary: "Update " + withArticle(r.one), Data: r.updated, Versioned: r.versioned}, r.update),
+		openapi.Route{Method: http.MethodPost, Path: v1 + "/delete", Tag: r.tag, Summary: "Delete " + withArticle(r.one), JSON: r.delete, Data: r.deleted},
+	)
+	return append(routes,
+		openapi.Route{Method: http.MethodGet, Path: v2, Tag: r.tag, Summary: "List " + r.many, Query: r.query, Data: listItem, List: r.query == nil, Paginated: r.query != nil},
+		openapi.Route{Method: http.MethodGet, Path: v2 + "/:id", Tag: r.tag, Summary: "Get " + withArticle(r.one), Data: r.item, Versioned: r.versioned},
+		body(openapi.Route{Method: http.MethodPost, Path: v2, Tag: r.tag, Summary: "Create " + withArticle(r.one), Data: created, Status: http.StatusCreated, Location: true, Idempotent: true}, r.create),
+		body(openapi.Route{Method: http.MethodPatch, Path: v2 + "/:id", Tag: r.tag, Summary: "Update " + withArticle(r.one) + ", omitted fields keep their value", Data: r.updated, Partial: true, Versioned: r.versioned}, r.update),
+		openapi.Route{Method: http.MethodDelete, Path: v2 + "/:id", Tag: r.tag, Summary: "Delete " + withArticle(r.one), Data: r.deleted},
+	)
+}
+
+// deprecated marks v1 routes, which answer with Deprecation and Link headers.
+func deprecated(routes ...openapi.Route) []openapi.Route {
+	for i := range routes {
+		routes[i].Deprecated = true
+	}
+	return routes
+}
+
+func withArticle(noun string) string {
+	if strings.ContainsRune("aeiou", rune(noun[0])) {
+		return "an " + noun
+	}
+	return "a " + noun
+}
+
+// withPagination returns the pagination parameters followed by string
+// filters.
+func withPagination(filters ...string) []openapi.Param {
+	params := append([]openapi.Param{}, openapi.Pagination...)
+	for _, name := range filters {
+		params = append(params, openapi.Param{Name: name, Type: "string"})
+	}
+	return params
+}
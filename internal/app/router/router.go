@@ -1,85 +1,203 @@
 package router
 
 import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/api_key"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/auth"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/idempotency"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/openapi"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/public"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/system"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
-	"gorm.io/gorm"
 )
 
-func SetupRouter(db *gorm.DB) *gin.Engine {
+func SetupRouter(c *container.Container) *gin.Engine {
+	cfg := c.Config
+	svc := c.Services
+
 	r := gin.New()
+	r.Use(utils.RequestIDMiddleware())
 	r.Use(utils.RecoveryWithLogger())
+	r.Use(utils.TracingMiddleware(cfg.Tracing.ServiceName))
 	r.Use(utils.LoggerMiddleware())
+	r.Use(utils.MetricsMiddleware())
+	r.Use(utils.TimeoutMiddleware(cfg.Server.RequestTimeout))
 
 	// Configure CORS options
 	corsConfig := cors.DefaultConfig()
-	corsConfig.AllowOrigins = []string{
-		"http://localhost:3000",
-		"http://43.134.162.211:3000",
-		"https://www.dimasroger.com",
-		"https://dimasroger.com",
-	}
-	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization"}
+	corsConfig.AllowOrigins = cfg.CORS.AllowOrigins
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "X-API-Key", utils.RequestIDHeader, "If-Match", idempotency.Header}
+	corsConfig.ExposeHeaders = []string{utils.RequestIDHeader, "Deprecation", "Link", "ETag", idempotency.ReplayedHeader}
 	corsConfig.AllowCredentials = true
 
 	// Apply CORS middleware
 	r.Use(cors.New(corsConfig))
 
 	r.NoRoute(func(c *gin.Context) {
-		c.JSON(404, gin.H{
-			"data":    nil,
-			"message": "route not found",
-		})
+		utils.Error(c, http.StatusNotFound, "route not found")
 	})
 
+	// Prometheus scrape endpoint
+	r.GET("/metrics", utils.MetricsHandler())
+
+	// Liveness and readiness probes
+	system.RegisterProbeRoutes(r, svc.System)
+
+	// Public keys for verifying tokens issued by this API
+	r.GET("/.well-known/jwks.json", utils.JWKSHandler)
+
+	// Files uploaded in demo mode, which stores them on disk instead of MinIO
+	localStorage, isLocal := c.Storage.(*utils.LocalStorage)
+	if isLocal {
+		r.Static("/"+utils.LocalStorageBucket, localStorage.Dir())
+	}
+
+	// API description and the docs UI rendering it
+	specHandler, err := openapi.Handler(OpenAPI(isLocal))
+	if err != nil {
+		panic(fmt.Sprintf("encode openapi document: %v", err))
+	}
+	r.GET(OpenAPIPath, specHandler)
+	r.GET(DocsPath, openapi.DocsHandler("Portfolio API", OpenAPIPath))
+
+	// v1 takes IDs in POST bodies and is kept for existing clients, v2 is
+	// RESTful; both share the same services
+	deprecated := utils.DeprecationMiddleware(v1DeprecatedSince, v2Successor)
+
+	// Retried create requests with the same Idempotency-Key get the first
+	// response instead of creating the record, and its uploads, twice
+	idempotent := idempotency.Middleware(svc.Idempotency, cfg.Idempotency, createsRecord)
+
+	// v2 updates of records without a version refuse If-Match instead of
+	// ignoring it
+	ifMatch := utils.IfMatchMiddleware(versionedRoute)
+
 	api := r.Group("/api")
 	{
-		auth.RegisterRoutes(api, db)
-
-		// Apply JWT middleware to other routes
-		api.Use(utils.JWTMiddleware()) // Protect all subsequent routes
-
-		user.RegisterRoutes(api, db)
-		author.RegisterRoutes(api, db)
-		about.RegisterRoutes(api, db)
-		technology.RegisterRoutes(api, db)
-		statistic.RegisterRoutes(api, db)
-		project_content_image.RegisterRoutes(api, db)
-		project_technology.RegisterRoutes(api, db)
-		project.RegisterRoutes(api, db)
-		topic.RegisterRoutes(api, db)
-		reading_time.RegisterRoutes(api, db)
-		blog.RegisterRoutes(api, db)
-		blog_topic.RegisterRoutes(api, db)
-		blog_content_image.RegisterRoutes(api, db)
-		experience.RegisterRoutes(api, db)
-		testimonial.RegisterRoutes(api, db)
+		auth.RegisterRoutes(api.Group("", deprecated, idempotent), svc.Auth)
+		auth.RegisterRoutes(api.Group("/v2", idempotent), svc.Auth)
+
+		// Apply JWT / API key middleware to other routes
+		api.Use(utils.AuthMiddleware(svc.ApiKey.Authenticate)) // Protect all subsequent routes
+
+		//* groups copy the middlewares of api, so they are created after api.Use
+		v1 := api.Group("", deprecated, idempotent)
+		{
+			api_key.RegisterRoutes(v1, svc.ApiKey)
+			user.RegisterRoutes(v1, svc.User)
+			author.RegisterRoutes(v1, svc.Author, cfg.Upload)
+			about.RegisterRoutes(v1, svc.About, cfg.Upload)
+			technology.RegisterRoutes(v1, svc.Technology, cfg.Upload)
+			statistic.RegisterRoutes(v1, svc.Statistic)
+			project_content_image.RegisterRoutes(v1, svc.ProjectContentImage, cfg.Upload)
+			project_technology.RegisterRoutes(v1, svc.ProjectTechnology)
+			project.RegisterRoutes(v1, svc.Project, svc.User, cfg.Upload)
+			topic.RegisterRoutes(v1, svc.Topic)
+			reading_time.RegisterRoutes(v1, svc.ReadingTime)
+			blog.RegisterRoutes(v1, svc.Blog, svc.User, cfg.Upload)
+			blog_topic.RegisterRoutes(v1, svc.BlogTopic)
+			blog_content_image.RegisterRoutes(v1, svc.BlogContentImage, cfg.Upload)
+			experience.RegisterRoutes(v1, svc.Experience, cfg.Upload)
+			testimonial.RegisterRoutes(v1, svc.Testimonial)
+			system.RegisterRoutes(v1, svc.System, svc.User)
+		}
+
+		v2 := api.Group("/v2", idempotent, ifMatch)
+		{
+			api_key.RegisterRoutesV2(v2, svc.ApiKey)
+			user.RegisterRoutesV2(v2, svc.User)
+			author.RegisterRoutesV2(v2, svc.Author, cfg.Upload)
+			about.RegisterRoutesV2(v2, svc.About, cfg.Upload)
+			technology.RegisterRoutesV2(v2, svc.Technology, cfg.Upload)
+			statistic.RegisterRoutesV2(v2, svc.Statistic)
+			project_content_image.RegisterRoutesV2(v2, svc.ProjectContentImage, cfg.Upload)
+			project_technology.RegisterRoutesV2(v2, svc.ProjectTechnology)
+			project.RegisterRoutesV2(v2, svc.Project, svc.User, cfg.Upload)
+			topic.RegisterRoutesV2(v2, svc.Topic)
+			reading_time.RegisterRoutesV2(v2, svc.ReadingTime)
+			blog.RegisterRoutesV2(v2, svc.Blog, svc.User, cfg.Upload)
+			blog_topic.RegisterRoutesV2(v2, svc.BlogTopic)
+			blog_content_image.RegisterRoutesV2(v2, svc.BlogContentImage, cfg.Upload)
+			experience.RegisterRoutesV2(v2, svc.Experience, cfg.Upload)
+			testimonial.RegisterRoutesV2(v2, svc.Testimonial)
+			system.RegisterRoutes(v2, svc.System, svc.User)
+		}
 	}
 
 	// Define the public API group
 	apiPublic := r.Group("/api-public")
 	{
-		public.RegisterRoutes(apiPublic, db)
+		public.RegisterRoutes(apiPublic, svc.Public)
 	}
 
 	return r
 }
+
+// v1DeprecatedSince is announced in the Deprecation header of every v1 route.
+var v1DeprecatedSince = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// v2Successor points a v1 route at the v2 collection replacing it, or at the
+// same path for auth and system, whose routes did not change.
+func v2Successor(route string) string {
+	rest, ok := strings.CutPrefix(route, "/api/")
+	if !ok {
+		return ""
+	}
+	resource, _, _ := strings.Cut(rest, "/")
+	if resource == "auth" || resource == "system" {
+		return "/api/v2/" + rest
+	}
+	return "/api/v2/" + resource
+}
+
+// createsRecord reports whether a route creates a record, and so honours an
+// Idempotency-Key: /store, /register and /comment under /api, and POST on a
+// collection or its comments under /api/v2.
+func createsRecord(method, route string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+	switch route[strings.LastIndex(route, "/")+1:] {
+	case "store", "register", "comment", "comments":
+		return true
+	}
+	rest, ok := strings.CutPrefix(route, "/api/v2/")
+	return ok && !strings.Contains(rest, "/")
+}
+
+// versionedResources are the v2 collections whose records carry a version.
+var versionedResources = []string{"abouts", "blogs", "experiences", "projects", "technologies"}
+
+// versionedRoute reports whether a route updates a versioned record, and so
+// honours If-Match: PATCH on an item of versionedResources.
+func versionedRoute(method, route string) bool {
+	if method != http.MethodPatch {
+		return false
+	}
+	resource, ok := strings.CutSuffix(strings.TrimPrefix(route, "/api/v2/"), "/:id")
+	return ok && slices.Contains(versionedResources, resource)
+}
@@ -0,0 +1,180 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/router"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/memory"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// server is the router on an in-memory store holding memory.Seed's content,
+// whose admin is user 1 and owns blog 1.
+type server struct {
+	t     *testing.T
+	r     *gin.Engine
+	repos container.Repositories
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	utils.Logger.SetOutput(io.Discard)
+	if err := utils.InitEphemeralJWTKey(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	store := memory.NewStore()
+	if err := memory.Seed(store); err != nil {
+		t.Fatal(err)
+	}
+	storage, err := utils.NewLocalStorage(t.TempDir(), "http://localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	repos := memory.NewRepositories(store)
+	c := &container.Container{Config: &cfg, Repos: repos, Services: container.NewServices(repos, nil, storage), Storage: storage}
+	return &server{t: t, r: router.SetupRouter(c), repos: repos}
+}
+
+// do sends a request with a JSON body, or a form when body is url.Values.
+func (s *server) do(method, path, token string, body any, header http.Header) *httptest.ResponseRecorder {
+	s.t.Helper()
+	var reader io.Reader
+	contentType := "application/json"
+	switch b := body.(type) {
+	case nil:
+	case url.Values:
+		reader = strings.NewReader(b.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		reader = strings.NewReader(string(raw))
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.r.ServeHTTP(w, req)
+	return w
+}
+
+func (s *server) login(email, password string) string {
+	s.t.Helper()
+	w := s.do(http.MethodPost, "/api/v2/auth/login", "", gin.H{"email": email, "password": password}, nil)
+	if w.Code != http.StatusOK {
+		s.t.Fatalf("login %s: %d %s", email, w.Code, w.Body)
+	}
+	var res struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		s.t.Fatal(err)
+	}
+	return res.Data.Token
+}
+
+// register creates an author account and returns its ID and token.
+func (s *server) register(username string) (int, string) {
+	s.t.Helper()
+	email := username + "@example.com"
+	w := s.do(http.MethodPost, "/api/v2/auth/register", "", gin.H{"username": username, "email": email, "password": "secret123"}, nil)
+	if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+		s.t.Fatalf("register %s: %d %s", username, w.Code, w.Body)
+	}
+	var res struct {
+		Data struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		s.t.Fatal(err)
+	}
+	return res.Data.ID, s.login(email, "secret123")
+}
+
+func expect(t *testing.T, w *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status %d, want %d: %s", w.Code, status, w.Body)
+	}
+}
+
+func TestUserOwnership(t *testing.T) {
+	s := newServer(t)
+	admin := s.login(memory.DemoEmail, memory.DemoPassword)
+	id, author := s.register("writer")
+	self := "/api/v2/users/" + strconv.Itoa(id)
+
+	expect(t, s.do(http.MethodPatch, "/api/v2/users/1", author, gin.H{"username": "taken"}, nil), http.StatusForbidden)
+	expect(t, s.do(http.MethodPatch, self, author, gin.H{"username": "renamed"}, nil), http.StatusOK)
+	expect(t, s.do(http.MethodDelete, "/api/v2/users/1", author, nil, nil), http.StatusForbidden)
+	expect(t, s.do(http.MethodDelete, "/api/v2/users/1", admin, nil, nil), http.StatusForbidden)
+	expect(t, s.do(http.MethodDelete, self, admin, nil, nil), http.StatusOK)
+}
+
+func TestBlogStatusTransitions(t *testing.T) {
+	s := newServer(t)
+	admin := s.login(memory.DemoEmail, memory.DemoPassword)
+	_, author := s.register("writer")
+
+	expect(t, s.do(http.MethodPost, "/api/v2/blogs/1/status", author, gin.H{"status": "Draft"}, nil), http.StatusForbidden)
+	expect(t, s.do(http.MethodPost, "/api/v2/blogs/1/status", admin, gin.H{"status": "Draft"}, nil), http.StatusOK)
+	expect(t, s.do(http.MethodPost, "/api/v2/blogs/1/status", admin, gin.H{"status": "Approved"}, nil), http.StatusConflict)
+
+	//* a transition checked against Published while the blog went back to Draft
+	stale := blog.BlogResponse{ID: 1, Status: string(editorial.StatusPublished)}
+	_, err := s.repos.Blog.ChangeStatusBlog(context.Background(), 1, string(editorial.StatusInReview), stale, nil)
+	if status := utils.StatusFromError(err, http.StatusInternalServerError); status != http.StatusConflict {
+		t.Fatalf("stale transition: status %d (%v), want 409", status, err)
+	}
+}
+
+func TestVersionConflicts(t *testing.T) {
+	s := newServer(t)
+	admin := s.login(memory.DemoEmail, memory.DemoPassword)
+
+	w := s.do(http.MethodGet, "/api/v2/abouts/1", admin, nil, nil)
+	expect(t, w, http.StatusOK)
+	etag := w.Header().Get("ETag")
+
+	w = s.do(http.MethodPatch, "/api/v2/abouts/1", admin, url.Values{"title": {"First"}}, http.Header{"If-Match": {etag}})
+	expect(t, w, http.StatusOK)
+	if w.Header().Get("ETag") == etag {
+		t.Fatalf("ETag %s did not change after an update", etag)
+	}
+
+	expect(t, s.do(http.MethodPatch, "/api/v2/abouts/1", admin, url.Values{"title": {"Stale"}}, http.Header{"If-Match": {etag}}), http.StatusPreconditionFailed)
+	expect(t, s.do(http.MethodPatch, "/api/v2/abouts/1", admin, url.Values{"title": {"Stale"}, "version": {"1"}}, nil), http.StatusConflict)
+	expect(t, s.do(http.MethodPatch, "/api/v2/abouts/1", admin, url.Values{"title": {"Any"}}, http.Header{"If-Match": {"*"}}), http.StatusOK)
+	expect(t, s.do(http.MethodPatch, "/api/v2/topics/1", admin, gin.H{"name": "Go"}, http.Header{"If-Match": {`"1"`}}), http.StatusPreconditionFailed)
+}
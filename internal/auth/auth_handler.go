@@ -11,13 +11,13 @@ func (h *handler) RegisterUser(c *gin.Context) {
 	// Validate the struct using validator
 	var req RegisterUserRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.RegisterUser(req)
+	data, err := h.service.RegisterUser(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -28,13 +28,13 @@ func (h *handler) LoginUser(c *gin.Context) {
 	// Validate the struct using validator
 	var req LoginUserRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.LoginUser(req)
+	data, err := h.service.LoginUser(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
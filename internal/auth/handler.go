@@ -2,16 +2,13 @@ package auth
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	auth := r.Group("/auth")
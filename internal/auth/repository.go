@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
@@ -10,9 +13,9 @@ import (
 )
 
 type Repository interface {
-	RegisterUser(user user.User) (RegisterResponse, error)
-	LoginUser(user LoginUserRequest) (LoginResponse, error)
-	CheckUniqueEmail(email string) (bool, error)
+	RegisterUser(ctx context.Context, user user.User) (RegisterResponse, error)
+	LoginUser(ctx context.Context, user LoginUserRequest) (LoginResponse, error)
+	CheckUniqueEmail(ctx context.Context, email string) (bool, error)
 }
 
 type repository struct {
@@ -23,15 +26,15 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) RegisterUser(payload user.User) (RegisterResponse, error) {
-	err := r.db.Create(&payload).Error
+func (r *repository) RegisterUser(ctx context.Context, payload user.User) (RegisterResponse, error) {
+	err := r.db.WithContext(ctx).Create(&payload).Error
 
 	if err != nil {
 		return RegisterResponse{}, err
 	}
 
 	var user user.User
-	err = r.db.Where("email = ?", payload.Email).First(&user).Error
+	err = r.db.WithContext(ctx).Where("email = ?", payload.Email).First(&user).Error
 
 	if err != nil {
 		return RegisterResponse{}, err
@@ -46,21 +49,23 @@ func (r *repository) RegisterUser(payload user.User) (RegisterResponse, error) {
 	return response, err
 }
 
-func (r *repository) LoginUser(payload LoginUserRequest) (LoginResponse, error) {
+func (r *repository) LoginUser(ctx context.Context, payload LoginUserRequest) (LoginResponse, error) {
 	var user user.User
-	if err := r.db.Where("email = ? AND deleted_at IS NULL", payload.Email).First(&user).Error; err != nil {
-		err = fmt.Errorf("email not found")
+	if err := r.db.WithContext(ctx).Where("email = ? AND deleted_at IS NULL", payload.Email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = utils.NewStatusError(http.StatusUnauthorized, "email not found")
+		}
 		return LoginResponse{}, err
 	}
 
 	// Compare password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
-		err = fmt.Errorf("invalid username or password")
+		err = utils.NewStatusError(http.StatusUnauthorized, "invalid username or password")
 		return LoginResponse{}, err
 	}
 
 	// Generate JWT
-	token, err := utils.GenerateJWT(user.Username)
+	token, err := utils.GenerateJWT(user.ID, user.Username)
 	if err != nil {
 		err = fmt.Errorf("error generating token")
 		return LoginResponse{}, err
@@ -76,9 +81,9 @@ func (r *repository) LoginUser(payload LoginUserRequest) (LoginResponse, error)
 	return response, nil
 }
 
-func (r *repository) CheckUniqueEmail(email string) (bool, error) {
+func (r *repository) CheckUniqueEmail(ctx context.Context, email string) (bool, error) {
 	var user user.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 
 	if err == gorm.ErrRecordNotFound {
 		return true, nil
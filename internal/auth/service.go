@@ -1,15 +1,18 @@
 package auth
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Service interface {
-	RegisterUser(req RegisterUserRequest) (RegisterResponse, error)
-	LoginUser(req LoginUserRequest) (LoginResponse, error)
+	RegisterUser(ctx context.Context, req RegisterUserRequest) (RegisterResponse, error)
+	LoginUser(ctx context.Context, req LoginUserRequest) (LoginResponse, error)
 }
 
 type service struct {
@@ -20,11 +23,11 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) RegisterUser(req RegisterUserRequest) (RegisterResponse, error) {
+func (s *service) RegisterUser(ctx context.Context, req RegisterUserRequest) (RegisterResponse, error) {
 	//todo: Check Unique Email
-	is_unique, _ := s.repo.CheckUniqueEmail(req.Email)
+	is_unique, _ := s.repo.CheckUniqueEmail(ctx, req.Email)
 	if !is_unique {
-		return RegisterResponse{}, fmt.Errorf("email already exist")
+		return RegisterResponse{}, utils.NewStatusError(http.StatusConflict, "email already exist")
 	}
 
 	// Hash the password
@@ -37,17 +40,19 @@ func (s *service) RegisterUser(req RegisterUserRequest) (RegisterResponse, error
 		Username: req.Username,
 		Email:    req.Email,
 		Password: string(hashPass),
+		Role:     user.RoleAuthor,
 	}
 
-	data, err := s.repo.RegisterUser(payload)
+	data, err := s.repo.RegisterUser(ctx, payload)
 	if err != nil {
 		return RegisterResponse{}, err
 	}
 	return data, nil
 }
 
-func (s *service) LoginUser(req LoginUserRequest) (LoginResponse, error) {
-	data, err := s.repo.LoginUser(req)
+func (s *service) LoginUser(ctx context.Context, req LoginUserRequest) (LoginResponse, error) {
+	data, err := s.repo.LoginUser(ctx, req)
+	utils.RecordLogin(err == nil)
 	if err != nil {
 		return LoginResponse{}, err
 	}
@@ -45,9 +45,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllAuthors(params)
+	data, total_records, err := h.service.GetAllAuthors(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, int(total_records))
@@ -59,9 +59,9 @@ func (h *handler) GetAuthorById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetAuthorById(id)
+	data, err := h.service.GetAuthorById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -71,14 +71,13 @@ func (h *handler) ValidateAvatar(c *gin.Context, validationCheck []string) (file
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	avatar_file, err := c.FormFile("avatar_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "avatar_file")
-		errors = utils.GenerateFieldErrorResponse("avatar_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("avatar_file", "{0} is required", "avatar_file")
 		// utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
 		return nil, errors, err
 	}
@@ -88,16 +87,15 @@ func (h *handler) ValidateAvatar(c *gin.Context, validationCheck []string) (file
 		errExt := utils.ValidateExtension(avatar_file.Filename, allowedExtensions)
 		if errExt != nil && slices.Contains(validationCheck, "extension") {
 			err = fmt.Errorf("validation Error")
-			// utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", errExt)
+			// utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", errExt)
 			return nil, errExt, err
 		}
 
 		// Step 3: Validate size
 		if avatar_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "avatar_file")
-			errors := utils.GenerateFieldErrorResponse("avatar_file", err_name)
-			// utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", errors)
+			errors := utils.GenerateFieldErrorResponse("avatar_file", "{0} exceeds max size", "avatar_file")
+			// utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", errors)
 			return nil, errors, err
 		}
 	}
@@ -121,24 +119,47 @@ func (h *handler) CreateAuthor(c *gin.Context) {
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.CreateAuthor(req)
+	data, err := h.service.CreateAuthor(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateAuthor(c *gin.Context) {
 	// Validate the struct using validator
 	id, _ := strconv.Atoi(c.PostForm("id"))
 
-	name := c.PostForm("name")
+	h.updateAuthor(c, UpdateAuthorRequest{ID: id})
+}
+
+// PatchAuthor updates the fields the form names and keeps the others.
+func (h *handler) PatchAuthor(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetAuthorById(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.updateAuthor(c, UpdateAuthorRequest{
+		ID:   id,
+		Name: current.Name,
+	})
+}
+
+// updateAuthor overlays the form values on req before saving it.
+func (h *handler) updateAuthor(c *gin.Context, req UpdateAuthorRequest) {
+	req.Name = utils.PostFormOr(c, "name", req.Name)
 
 	validationCheck := []string{"extension", "size"}
 	avatar_file, errors, err := h.ValidateAvatar(c, validationCheck)
@@ -146,21 +167,16 @@ func (h *handler) UpdateAuthor(c *gin.Context) {
 		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
 		return
 	}
-
-	req := UpdateAuthorRequest{
-		ID:         id,
-		Name:       name,
-		AvatarFile: avatar_file,
-	}
+	req.AvatarFile = avatar_file
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	err = h.service.UpdateAuthor(req)
+	err = h.service.UpdateAuthor(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -170,15 +186,25 @@ func (h *handler) UpdateAuthor(c *gin.Context) {
 func (h *handler) DeleteAuthor(c *gin.Context) {
 	var req AuthorDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteAuthor(c, req.ID)
+}
+
+func (h *handler) DeleteAuthorById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteAuthor(c, id)
+}
 
-	err := h.service.DeleteAuthor(id)
+func (h *handler) deleteAuthor(c *gin.Context, id int) {
+	err := h.service.DeleteAuthor(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", nil)
@@ -2,17 +2,16 @@ package author
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
 )
 
 type handler struct {
 	service Service
+	upload  config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
-	h := handler{service: service}
+func RegisterRoutes(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
 
 	author := r.Group("/authors")
 	{
@@ -23,3 +22,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		author.POST("/delete", h.DeleteAuthor)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
+
+	author := r.Group("/authors")
+	{
+		author.GET("", h.GetAll)
+		author.GET("/:id", h.GetAuthorById)
+		author.POST("", h.CreateAuthor)
+		author.PATCH("/:id", h.PatchAuthor)
+		author.DELETE("/:id", h.DeleteAuthorById)
+	}
+}
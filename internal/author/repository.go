@@ -1,19 +1,20 @@
 package author
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllAuthorParams) ([]Author, int, error)
-	FindById(id int) (Author, error)
-	CreateAuthor(p CreateAuthorDTO) (Author, error)
-	UpdateAuthor(p UpdateAuthorDTO) error
-	DeleteAuthor(id int) error
+	FindAll(ctx context.Context, params GetAllAuthorParams) ([]Author, int, error)
+	FindById(ctx context.Context, id int) (Author, error)
+	CreateAuthor(ctx context.Context, p CreateAuthorDTO) (Author, error)
+	UpdateAuthor(ctx context.Context, p UpdateAuthorDTO) error
+	DeleteAuthor(ctx context.Context, id int) error
 }
 
 type repository struct {
@@ -24,7 +25,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllAuthorParams) ([]Author, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllAuthorParams) ([]Author, int, error) {
 	var authors []Author
 	var totalCount int
 
@@ -41,29 +42,18 @@ func (r *repository) FindAll(params GetAllAuthorParams) ([]Author, int, error) {
 
 	//? field "name"
 	if params.Name != "" {
-		whereClauses = append(whereClauses, "(name LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Name+"%")
+		whereClauses = append(whereClauses, "(LOWER(name) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Name))
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -77,7 +67,7 @@ func (r *repository) FindAll(params GetAllAuthorParams) ([]Author, int, error) {
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -109,7 +99,7 @@ func (r *repository) FindAll(params GetAllAuthorParams) ([]Author, int, error) {
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&authors).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&authors).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -118,9 +108,9 @@ func (r *repository) FindAll(params GetAllAuthorParams) ([]Author, int, error) {
 	return authors, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (Author, error) {
+func (r *repository) FindById(ctx context.Context, id int) (Author, error) {
 	var data Author
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	if err == gorm.ErrRecordNotFound {
 		errStr := fmt.Errorf("author with id %d not found", id)
 		return Author{}, errStr
@@ -128,28 +118,28 @@ func (r *repository) FindById(id int) (Author, error) {
 	return data, err
 }
 
-func (r *repository) CreateAuthor(p CreateAuthorDTO) (Author, error) {
+func (r *repository) CreateAuthor(ctx context.Context, p CreateAuthorDTO) (Author, error) {
 	about := Author{
 		Name:           p.Name,
 		AvatarUrl:      p.AvatarUrl,
 		AvatarFileName: p.AvatarFileName}
-	err := r.db.Create(&about).Error
+	err := r.db.WithContext(ctx).Create(&about).Error
 	return about, err
 }
 
-func (r *repository) UpdateAuthor(p UpdateAuthorDTO) error {
+func (r *repository) UpdateAuthor(ctx context.Context, p UpdateAuthorDTO) error {
 	author := Author{
 		ID:             p.ID,
 		Name:           p.Name,
 		AvatarUrl:      p.AvatarUrl,
 		AvatarFileName: p.AvatarFileName}
-	err := r.db.Updates(&author).Error
+	err := r.db.WithContext(ctx).Updates(&author).Error
 	return err
 }
 
-func (r *repository) DeleteAuthor(id int) error {
+func (r *repository) DeleteAuthor(ctx context.Context, id int) error {
 	// Hard Delete
-	if err := r.db.Unscoped().Where("id = ?", id).Delete(&Author{}).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&Author{}).Error; err != nil {
 		return err
 	}
 
@@ -7,23 +7,24 @@ import (
 )
 
 type Service interface {
-	GetAllAuthors(params GetAllAuthorParams) ([]AuthorResponse, int, error)
-	GetAuthorById(id int) (AuthorResponse, error)
-	CreateAuthor(p CreateAuthorRequest) (AuthorResponse, error)
-	UpdateAuthor(p UpdateAuthorRequest) error
-	DeleteAuthor(id int) error
+	GetAllAuthors(ctx context.Context, params GetAllAuthorParams) ([]AuthorResponse, int, error)
+	GetAuthorById(ctx context.Context, id int) (AuthorResponse, error)
+	CreateAuthor(ctx context.Context, p CreateAuthorRequest) (AuthorResponse, error)
+	UpdateAuthor(ctx context.Context, p UpdateAuthorRequest) error
+	DeleteAuthor(ctx context.Context, id int) error
 }
 
 type service struct {
-	repo Repository
+	repo    Repository
+	storage utils.Storage
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
 }
 
-func (s *service) GetAllAuthors(params GetAllAuthorParams) ([]AuthorResponse, int, error) {
-	authors, total, err := s.repo.FindAll(params)
+func (s *service) GetAllAuthors(ctx context.Context, params GetAllAuthorParams) ([]AuthorResponse, int, error) {
+	authors, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -35,16 +36,16 @@ func (s *service) GetAllAuthors(params GetAllAuthorParams) ([]AuthorResponse, in
 	return result, total, nil
 }
 
-func (s *service) GetAuthorById(id int) (AuthorResponse, error) {
-	author, err := s.repo.FindById(id)
+func (s *service) GetAuthorById(ctx context.Context, id int) (AuthorResponse, error) {
+	author, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return AuthorResponse{}, err
 	}
 	return ToAuthorResponse(author), nil
 }
 
-func (s *service) CreateAuthor(p CreateAuthorRequest) (AuthorResponse, error) {
-	avatarRes, err := utils.HandlUploadFile(p.AvatarFile, "author")
+func (s *service) CreateAuthor(ctx context.Context, p CreateAuthorRequest) (AuthorResponse, error) {
+	avatarRes, err := s.storage.Upload(ctx, p.AvatarFile, "author")
 	if err != nil {
 		return AuthorResponse{}, err
 	}
@@ -55,17 +56,17 @@ func (s *service) CreateAuthor(p CreateAuthorRequest) (AuthorResponse, error) {
 		AvatarFileName: avatarRes.FileName,
 	}
 
-	author, err := s.repo.CreateAuthor(payload)
+	author, err := s.repo.CreateAuthor(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), avatarRes.FileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), avatarRes.FileName)
 		return AuthorResponse{}, err
 	}
 	return ToAuthorResponse(author), nil
 }
 
-func (s *service) UpdateAuthor(p UpdateAuthorRequest) error {
+func (s *service) UpdateAuthor(ctx context.Context, p UpdateAuthorRequest) error {
 	//todo: Get Author
-	author, err := s.repo.FindById(p.ID)
+	author, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
@@ -81,7 +82,7 @@ func (s *service) UpdateAuthor(p UpdateAuthorRequest) error {
 
 	//todo: Upload File
 	if p.AvatarFile != nil {
-		logoRes, err := utils.HandlUploadFile(p.AvatarFile, "author")
+		logoRes, err := s.storage.Upload(ctx, p.AvatarFile, "author")
 		if err != nil {
 			return err
 		}
@@ -100,33 +101,33 @@ func (s *service) UpdateAuthor(p UpdateAuthorRequest) error {
 		AvatarFileName: newFileName,
 	}
 
-	err = s.repo.UpdateAuthor(payload)
+	err = s.repo.UpdateAuthor(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), newFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), newFileName)
 		return err
 	}
 
 	//todo: Delete Old Image
 	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), oldFileName)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteAuthor(id int) error {
-	author, err := s.repo.FindById(id)
+func (s *service) DeleteAuthor(ctx context.Context, id int) error {
+	author, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.DeleteAuthor(id)
+	err = s.repo.DeleteAuthor(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	//todo: Delete Old Image
-	_ = utils.DeleteFromMinio(context.Background(), author.AvatarFileName)
+	_ = s.storage.Delete(context.WithoutCancel(ctx), author.AvatarFileName)
 
 	return nil
 }
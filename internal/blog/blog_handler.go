@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 )
 
@@ -21,9 +22,11 @@ func (h *handler) GetAll(c *gin.Context) {
 	order := c.DefaultQuery("order", "id")
 	//? Filters
 	title := c.DefaultQuery("title", "")
+	slug := c.DefaultQuery("slug", "")
 	status := c.DefaultQuery("status", "")
 	published_at := c.DefaultQuery("published_at", "")
 	created_at := c.DefaultQuery("created_at", "")
+	mine := c.DefaultQuery("mine", "") // Y or N
 
 	var createdAtRange []string
 	if created_at != "" {
@@ -41,18 +44,32 @@ func (h *handler) GetAll(c *gin.Context) {
 		Sort:        sort,
 		Order:       order,
 		Title:       title,
+		Slug:        slug,
 		Status:      status,
 		PublishedAt: publishedAtRange,
 		CreatedAt:   createdAtRange,
 	}
 
+	//? only blogs written by the caller's author profile
+	if mine == "Y" {
+		actor, ok := user.AuthActor(c, h.userService)
+		if !ok {
+			return
+		}
+		if actor.AuthorID == nil {
+			utils.PaginatedSuccess(c, "success get all data", nil, page, limit, 0)
+			return
+		}
+		params.AuthorID = *actor.AuthorID
+	}
+
 	// Validate the params using the binding tags
 	if err := c.ShouldBindQuery(&params); err != nil {
 		utils.Error(c, http.StatusBadRequest, "Invalid query parameters")
 		return
 	}
 
-	data, total_record, err := h.service.GetAllBlogs(params)
+	data, total_record, err := h.service.GetAllBlogs(c.Request.Context(), params)
 	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "failed to get data")
 		return
@@ -66,11 +83,12 @@ func (h *handler) GetBlogByIdWithRelations(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetBlogByIdWithRelations(id)
+	data, err := h.service.GetBlogByIdWithRelations(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success get data", data)
 }
 
@@ -78,14 +96,13 @@ func (h *handler) ValidateBanner(c *gin.Context, validationCheck []string) (file
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	banner_file, err := c.FormFile("banner_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "banner_file")
-		errors = utils.GenerateFieldErrorResponse("banner_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("banner_file", "{0} is required", "banner_file")
 		return nil, errors, err
 	}
 
@@ -100,8 +117,7 @@ func (h *handler) ValidateBanner(c *gin.Context, validationCheck []string) (file
 		// Step 3: Validate size
 		if banner_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "banner_file")
-			errors := utils.GenerateFieldErrorResponse("banner_file", err_name)
+			errors := utils.GenerateFieldErrorResponse("banner_file", "{0} exceeds max size", "banner_file")
 			return nil, errors, err
 		}
 	}
@@ -112,15 +128,18 @@ func (h *handler) ValidateBanner(c *gin.Context, validationCheck []string) (file
 func (h *handler) CreateBlog(c *gin.Context) {
 	title := c.PostForm("title")
 	description := c.PostForm("description")
-	is_published := c.PostForm("is_published") // Y or N
 	summary := c.PostForm("summary")
-	author_id := c.PostForm("author_id")
+	author_id := c.PostForm("author_id") // optional, defaults to the caller's author
 	slug := c.PostForm("slug")
 
-	author_id_int, err := strconv.Atoi(author_id)
-	if err != nil {
-		utils.Error(c, http.StatusBadRequest, "invalid author_id")
-		return
+	author_id_int := 0
+	if author_id != "" {
+		var err error
+		author_id_int, err = strconv.Atoi(author_id)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, "invalid author_id")
+			return
+		}
 	}
 
 	var topic_ids []int
@@ -157,23 +176,27 @@ func (h *handler) CreateBlog(c *gin.Context) {
 		DescriptionHTML: description,
 		BannerFile:      image_file,
 		Summary:         summary,
-		IsPublished:     is_published,
 		ContentImages:   content_images,
 		Slug:            slug,
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
+		return
+	}
+
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
 		return
 	}
 
-	data, err := h.service.CreateBlog(req)
+	data, err := h.service.CreateBlog(c.Request.Context(), actor, req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success create data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateBlog(c *gin.Context) {
@@ -183,31 +206,86 @@ func (h *handler) UpdateBlog(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	// Validate the struct using validator
-	author_id, err := strconv.Atoi(c.PostForm("author_id"))
+
+	h.updateBlog(c, UpdateBlogRequest{ID: id})
+}
+
+// PatchBlog updates the fields the form names and keeps the others.
+func (h *handler) PatchBlog(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetBlogByIdWithRelations(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, "invalid Author ID")
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	is_published := c.PostForm("is_published") // Y or N
-	summary := c.PostForm("summary")
-	slug := c.PostForm("slug")
-	is_highlight := c.PostForm("is_highlight") // Y or N
+	topic_ids := make([]UpdateBlogTopicDTO, 0, len(current.Topics))
+	for _, topic := range current.Topics {
+		topic_ids = append(topic_ids, UpdateBlogTopicDTO{TopicID: topic.TopicID})
+	}
+	content_images := make([]string, 0, len(current.ContentImages))
+	for _, image := range current.ContentImages {
+		content_images = append(content_images, image.BlogContentImageUrl)
+	}
 
-	var topic_ids []UpdateBlogTopicDTO
-	if err := json.Unmarshal([]byte(c.PostForm("topic_ids")), &topic_ids); err != nil {
-		utils.Error(c, http.StatusBadRequest, "Invalid topic_ids format")
-		return
+	// author_id is left at 0 so the current author is kept
+	h.updateBlog(c, UpdateBlogRequest{
+		ID:              id,
+		Title:           current.Title,
+		DescriptionHTML: current.DescriptionHTML,
+		Summary:         current.Summary,
+		TopicIds:        topic_ids,
+		ContentImages:   content_images,
+		Slug:            current.Slug,
+		IsHighlight:     utils.BoolToYN(current.IsHighlight),
+		Version:         current.Version,
+	})
+}
+
+// updateBlog overlays the form values on req before saving it, provided the
+// blog is still at the version the client sent.
+func (h *handler) updateBlog(c *gin.Context, req UpdateBlogRequest) {
+	// author_id is optional, the current author is kept when omitted
+	if author_id_param := c.PostForm("author_id"); author_id_param != "" {
+		author_id, err := strconv.Atoi(author_id_param)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, "invalid Author ID")
+			return
+		}
+		req.AuthorID = author_id
 	}
 
-	var content_images []string
-	if err := json.Unmarshal([]byte(c.PostForm("content_images")), &content_images); err != nil {
-		utils.Error(c, http.StatusBadRequest, "Invalid content_images format")
+	req.Title = utils.PostFormOr(c, "title", req.Title)
+	req.DescriptionHTML = utils.PostFormOr(c, "description", req.DescriptionHTML)
+	req.Summary = utils.PostFormOr(c, "summary", req.Summary)
+	req.Slug = utils.PostFormOr(c, "slug", req.Slug)
+	req.IsHighlight = utils.PostFormOr(c, "is_highlight", req.IsHighlight) // Y or N
+	version, ok := utils.RequestVersion(c, req.Version)
+	if !ok {
 		return
 	}
+	req.Version = version
+
+	if value, ok := c.GetPostForm("topic_ids"); ok {
+		var topic_ids []UpdateBlogTopicDTO
+		if err := json.Unmarshal([]byte(value), &topic_ids); err != nil {
+			utils.Error(c, http.StatusBadRequest, "Invalid topic_ids format")
+			return
+		}
+		req.TopicIds = topic_ids
+	}
+
+	if value, ok := c.GetPostForm("content_images"); ok {
+		var content_images []string
+		if err := json.Unmarshal([]byte(value), &content_images); err != nil {
+			utils.Error(c, http.StatusBadRequest, "Invalid content_images format")
+			return
+		}
+		req.ContentImages = content_images
+	}
 
 	validationCheck := []string{"extension", "size"}
 	banner_file, errors, err := h.ValidateBanner(c, validationCheck)
@@ -215,48 +293,60 @@ func (h *handler) UpdateBlog(c *gin.Context) {
 		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
 		return
 	}
+	req.BannerFile = banner_file
 
-	// Validate the struct using validator
-	req := UpdateBlogRequest{
-		ID:              id,
-		Title:           title,
-		DescriptionHTML: description,
-		BannerFile:      banner_file,
-		Summary:         summary,
-		IsPublished:     is_published,
-		TopicIds:        topic_ids,
-		ContentImages:   content_images,
-		AuthorID:        author_id,
-		Slug:            slug,
-		IsHighlight:     is_highlight,
+	if verr := utils.ValidateRequest(&req); verr != nil {
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
+		return
 	}
 
-	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
 		return
 	}
 
-	data, err := h.service.UpdateBlog(req)
+	data, err := h.service.UpdateBlog(c.Request.Context(), actor, req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success updated data", data)
 }
 
 func (h *handler) DeleteBlog(c *gin.Context) {
 	var req BlogDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteBlog(c, req.ID)
+}
 
-	data, err := h.service.DeleteBlog(id)
+func (h *handler) DeleteBlogById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteBlog(c, id)
+}
+
+func (h *handler) deleteBlog(c *gin.Context, id int) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.DeleteBlog(c.Request.Context(), actor, id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, "failed to deleted data")
+		status := utils.StatusFromError(err, http.StatusInternalServerError)
+		if status == http.StatusForbidden || status == http.StatusNotFound {
+			utils.ErrorFrom(c, status, err)
+			return
+		}
+		utils.Error(c, status, "failed to deleted data")
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -265,14 +355,134 @@ func (h *handler) DeleteBlog(c *gin.Context) {
 func (h *handler) ChangeStatusBlog(c *gin.Context) {
 	var req BlogChangeStatusRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.ChangeStatusBlog(req)
+	h.changeStatusBlog(c, req)
+}
+
+// ChangeStatusBlogById is ChangeStatusBlog with the ID taken from the path.
+func (h *handler) ChangeStatusBlogById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := BlogChangeStatusRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.changeStatusBlog(c, req)
+}
+
+func (h *handler) changeStatusBlog(c *gin.Context, req BlogChangeStatusRequest) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.ChangeStatusBlog(c.Request.Context(), actor, req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success change status", data)
 }
+
+func (h *handler) AssignReviewerBlog(c *gin.Context) {
+	var req BlogAssignReviewerRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.assignReviewerBlog(c, req)
+}
+
+// AssignReviewerBlogById is AssignReviewerBlog with the ID taken from the path.
+func (h *handler) AssignReviewerBlogById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := BlogAssignReviewerRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.assignReviewerBlog(c, req)
+}
+
+func (h *handler) assignReviewerBlog(c *gin.Context, req BlogAssignReviewerRequest) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.AssignReviewerBlog(c.Request.Context(), actor, req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success assign reviewer", data)
+}
+
+func (h *handler) CommentBlog(c *gin.Context) {
+	var req BlogCommentRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.commentBlog(c, req)
+}
+
+// CommentBlogById is CommentBlog with the ID taken from the path.
+func (h *handler) CommentBlogById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := BlogCommentRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.commentBlog(c, req)
+}
+
+func (h *handler) commentBlog(c *gin.Context, req BlogCommentRequest) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	err := h.service.CommentBlog(c.Request.Context(), actor, req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success add comment", nil)
+}
+
+func (h *handler) GetBlogReviews(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, "invalid ID")
+		return
+	}
+
+	data, err := h.service.GetBlogReviews(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success get data", data)
+}
@@ -6,15 +6,14 @@ import (
 )
 
 type CreateBlogRequest struct {
-	TopicIds        []int
-	ContentImages   []string
-	AuthorID        int    `validate:"required"`
-	Title           string `validate:"required"`
-	DescriptionHTML string `validate:"required"`
-	BannerFile      *multipart.FileHeader
-	Summary         string `validate:"required"`
-	IsPublished     string `validate:"required,oneof=Y N"`
-	Slug            string `validate:"required"`
+	TopicIds        []int                 `form:"topic_ids"`
+	ContentImages   []string              `form:"content_images"`
+	AuthorID        int                   `form:"author_id"`
+	Title           string                `form:"title" validate:"required"`
+	DescriptionHTML string                `form:"description" validate:"required"`
+	BannerFile      *multipart.FileHeader `form:"banner_file"`
+	Summary         string                `form:"summary" validate:"required"`
+	Slug            string                `form:"slug" validate:"required"`
 }
 
 type CreateBlogDTO struct {
@@ -46,6 +45,8 @@ type BlogResponse struct {
 	Status          string  `json:"status"`
 	Slug            string  `json:"slug"`
 	IsHighlight     bool    `json:"is_highlight"`
+	ReviewerID      *int    `json:"reviewer_id"`
+	Version         int     `json:"version"`
 	PublishedAt     *string `json:"published_at"`
 	CreatedAt       string  `json:"created_at"`
 }
@@ -60,6 +61,8 @@ type RawBlogRelationResponse struct {
 	Status                      string     `json:"status"`
 	Slug                        string     `json:"slug"`
 	IsHighlight                 bool       `json:"is_highlight"`
+	ReviewerID                  *int       `json:"reviewer_id"`
+	Version                     int        `json:"version"`
 	PublishedAt                 *time.Time `json:"published_at"`
 	CreatedAt                   time.Time  `json:"created_at"`
 	AuthorID                    int        `json:"author_id"`
@@ -114,21 +117,22 @@ type BlogContentImageDTO struct {
 }
 
 type UpdateBlogRequest struct {
-	ID              int                  `validate:"required"`
-	TopicIds        []UpdateBlogTopicDTO `validate:"required,dive"`
-	ContentImages   []string             `validate:"required,dive"`
-	AuthorID        int                  `validate:"required"`
-	Title           string               `validate:"required"`
-	DescriptionHTML string               `validate:"required"`
-	BannerFile      *multipart.FileHeader
-	Summary         string `validate:"required"`
-	IsPublished     string `validate:"required,oneof=Y N"`
-	Slug            string `validate:"required"`
-	IsHighlight     string `validate:"required,oneof=Y N"`
+	ID              int                   `form:"id" validate:"required"`
+	TopicIds        []UpdateBlogTopicDTO  `form:"topic_ids" validate:"required,dive"`
+	ContentImages   []string              `form:"content_images" validate:"required,dive"`
+	AuthorID        int                   `form:"author_id"`
+	Title           string                `form:"title" validate:"required"`
+	DescriptionHTML string                `form:"description" validate:"required"`
+	BannerFile      *multipart.FileHeader `form:"banner_file"`
+	Summary         string                `form:"summary" validate:"required"`
+	Slug            string                `form:"slug" validate:"required"`
+	IsHighlight     string                `form:"is_highlight" validate:"required,oneof=Y N"`
+	Version         int                   `form:"version" validate:"required"`
 }
 
 type UpdateBlogDTO struct {
 	ID              int
+	Version         int
 	TopicIds        []UpdateBlogTopicDTO
 	AuthorID        int
 	StatisticID     int
@@ -141,7 +145,6 @@ type UpdateBlogDTO struct {
 	Status          string
 	Slug            string
 	IsHighlight     string
-	PublishedAt     *time.Time
 }
 
 type UpdateBlogTopicDTO struct {
@@ -158,6 +161,8 @@ type BlogRelationResponse struct {
 	Status          string                `json:"status"`
 	Slug            string                `json:"slug"`
 	IsHighlight     bool                  `json:"is_highlight"`
+	ReviewerID      *int                  `json:"reviewer_id"`
+	Version         int                   `json:"version"`
 	PublishedAt     *string               `json:"published_at"`
 	CreatedAt       string                `json:"created_at"`
 	Author          *BlogAuthorDTO        `json:"author"`
@@ -180,6 +185,7 @@ type BlogUpdateResponse struct {
 	StatisticID     int    `json:"statistic_id"`
 	ReadingTimeID   int    `json:"reading_time_id"`
 	AuthorID        int    `json:"author_id"`
+	Version         int    `json:"version"`
 }
 
 type BlogDeleteRequest struct {
@@ -192,7 +198,9 @@ type GetAllBlogParams struct {
 	Sort        string
 	Order       string
 	Title       string
+	Slug        string
 	Status      string
+	AuthorID    int
 	PublishedAt []string
 	CreatedAt   []string
 }
@@ -216,6 +224,8 @@ func ToBlogResponse(p Blog) BlogResponse {
 		Status:          p.Status,
 		Slug:            p.Slug,
 		IsHighlight:     p.IsHighlight,
+		ReviewerID:      p.ReviewerID,
+		Version:         p.Version,
 		PublishedAt:     publishedAtPointer,
 		CreatedAt:       p.CreatedAt.Format("2006-01-02"),
 	}
@@ -235,12 +245,24 @@ func ToBlogUpdateResponse(p Blog) BlogUpdateResponse {
 		StatisticID:     p.StatisticID,
 		ReadingTimeID:   p.ReadingTimeID,
 		AuthorID:        p.AuthorID,
+		Version:         p.Version,
 	}
 }
 
 type BlogChangeStatusRequest struct {
-	ID     int    `json:"id" binding:"required"`
-	Status string `json:"status" binding:"required,oneof=Published Unpublished"`
+	ID      int     `json:"id" binding:"required"`
+	Status  string  `json:"status" binding:"required,oneof=Draft InReview Approved Published"`
+	Comment *string `json:"comment"`
+}
+
+type BlogAssignReviewerRequest struct {
+	ID         int  `json:"id" binding:"required"`
+	ReviewerID *int `json:"reviewer_id"`
+}
+
+type BlogCommentRequest struct {
+	ID      int    `json:"id" binding:"required"`
+	Comment string `json:"comment" binding:"required"`
 }
 
 type BlogChangeStatusResponse struct {
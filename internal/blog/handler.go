@@ -2,62 +2,47 @@ package blog
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 )
 
 type handler struct {
-	service Service
+	service     Service
+	userService user.Service
+	upload      config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	//* Create author repo & service
-	authorRepo := author.NewRepository(db)
-	authorService := author.NewService(authorRepo)
-
-	//* Create topic repo & service
-	topicRepo := topic.NewRepository(db)
-	topicService := topic.NewService(topicRepo)
-
-	//* Create statistic repo & service
-	statisticRepo := statistic.NewRepository(db)
-	statisticService := statistic.NewService(statisticRepo)
-
-	//* Create readingTime repo & service
-	readingTimeRepo := reading_time.NewRepository(db)
-	readingTimeService := reading_time.NewService(readingTimeRepo)
-
-	//* Create blogTopic repo & service
-	blogTopicRepo := blog_topic.NewRepository(db)
-	blogTopicService := blog_topic.NewService(blogTopicRepo)
-
-	//* Create blogContentImage repo & service
-	blogContentImageRepo := blog_content_image.NewRepository(db)
-	blogContentImageService := blog_content_image.NewService(blogContentImageRepo)
-
-	blogRepo := NewRepository(db)
-	blogService := NewService(
-		authorService,
-		topicService,
-		statisticService,
-		readingTimeService,
-		blogTopicService,
-		blogContentImageService,
-		blogRepo, db)
-	h := handler{service: blogService}
+func RegisterRoutes(r *gin.RouterGroup, service Service, userService user.Service, upload config.UploadConfig) {
+	h := handler{service: service, userService: userService, upload: upload}
 
 	blog := r.Group("/blogs")
 	{
 		blog.GET("", h.GetAll)
 		blog.GET("/:id", h.GetBlogByIdWithRelations)
+		blog.GET("/:id/reviews", h.GetBlogReviews)
 		blog.POST("/store", h.CreateBlog)
 		blog.POST("/update", h.UpdateBlog)
 		blog.POST("/delete", h.DeleteBlog)
 		blog.POST("/change-status", h.ChangeStatusBlog)
+		blog.POST("/assign-reviewer", h.AssignReviewerBlog)
+		blog.POST("/comment", h.CommentBlog)
+	}
+}
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, userService user.Service, upload config.UploadConfig) {
+	h := handler{service: service, userService: userService, upload: upload}
+
+	blog := r.Group("/blogs")
+	{
+		blog.GET("", h.GetAll)
+		blog.GET("/:id", h.GetBlogByIdWithRelations)
+		blog.POST("", h.CreateBlog)
+		blog.PATCH("/:id", h.PatchBlog)
+		blog.DELETE("/:id", h.DeleteBlogById)
+		blog.GET("/:id/reviews", h.GetBlogReviews)
+		blog.POST("/:id/status", h.ChangeStatusBlogById)
+		blog.PUT("/:id/reviewer", h.AssignReviewerBlogById)
+		blog.POST("/:id/comments", h.CommentBlogById)
 	}
 }
@@ -11,6 +11,7 @@ type Blog struct {
 	StatisticID     int    `json:"statistic_id"`
 	ReadingTimeID   int    `json:"reading_time_id"`
 	AuthorID        int    `json:"author_id"`
+	ReviewerID      *int   `json:"reviewer_id"`
 	Title           string `json:"title"`
 	DescriptionHTML string `json:"description_html"`
 	BannerUrl       string `json:"banner_url"`
@@ -19,6 +20,7 @@ type Blog struct {
 	Status          string `json:"status"`
 	Slug            string `json:"slug"`
 	IsHighlight     bool   `json:"is_highlight"`
+	Version         int    `json:"version" gorm:"not null;default:1"`
 	PublishedAt     *time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
@@ -1,22 +1,26 @@
 package blog
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllBlogParams) ([]Blog, int, error)
-	FindByIdWithRelations(id int) ([]RawBlogRelationResponse, error)
-	FindById(id int) (BlogResponse, error)
-	CreateBlog(p CreateBlogDTO, tx *gorm.DB) (Blog, error)
-	UpdateBlog(p UpdateBlogDTO, tx *gorm.DB) (Blog, error)
-	DeleteBlog(id int) (Blog, error)
-	ChangeStatusBlog(id int, status string, blog BlogResponse) (BlogChangeStatusResponse, error)
-	CheckUniqueSlug(slug string) (bool, error)
+	FindAll(ctx context.Context, params GetAllBlogParams) ([]Blog, int, error)
+	FindByIdWithRelations(ctx context.Context, id int) ([]RawBlogRelationResponse, error)
+	FindById(ctx context.Context, id int) (BlogResponse, error)
+	CreateBlog(ctx context.Context, p CreateBlogDTO, tx *gorm.DB) (Blog, error)
+	UpdateBlog(ctx context.Context, p UpdateBlogDTO, tx *gorm.DB) (Blog, error)
+	DeleteBlog(ctx context.Context, id int) (Blog, error)
+	ChangeStatusBlog(ctx context.Context, id int, status string, blog BlogResponse, tx *gorm.DB) (BlogChangeStatusResponse, error)
+	AssignReviewer(ctx context.Context, id int, reviewerID *int, tx *gorm.DB) error
+	CheckUniqueSlug(ctx context.Context, slug string) (bool, error)
 }
 
 type repository struct {
@@ -27,7 +31,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllBlogParams) ([]Blog, int, error) {
 	var blog []Blog
 	var totalCount int
 
@@ -44,8 +48,14 @@ func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
 
 	//? field "title"
 	if params.Title != "" {
-		whereClauses = append(whereClauses, "(title LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Title+"%")
+		whereClauses = append(whereClauses, "(LOWER(title) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Title))
+	}
+
+	//? field "slug"
+	if params.Slug != "" {
+		whereClauses = append(whereClauses, "(slug = ?)")
+		queryArgs = append(queryArgs, params.Slug)
 	}
 
 	//? field "status"
@@ -54,47 +64,30 @@ func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
 		queryArgs = append(queryArgs, params.Status)
 	}
 
+	//? field "author_id"
+	if params.AuthorID != 0 {
+		whereClauses = append(whereClauses, "(author_id = ?)")
+		queryArgs = append(queryArgs, params.AuthorID)
+	}
+
 	//? field "published_at"
-	if len(params.PublishedAt) == 1 {
-		// If only one date is provided, use equality
-		whereClauses = append(whereClauses, "(published_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.PublishedAt[0]+"%")
-	} else if len(params.PublishedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.PublishedAt[0])
-		if err != nil {
-			return nil, 0, err
-		}
-		endDate, err := time.Parse("2006-01-02", params.PublishedAt[1])
+	if len(params.PublishedAt) > 0 {
+		clause, args, err := utils.DateFilter("published_at", params.PublishedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(from_date BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
-		if err != nil {
-			return nil, 0, err
-		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -108,7 +101,7 @@ func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -133,6 +126,8 @@ func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
 			status,
 			slug,
 			is_highlight,
+			reviewer_id,
+			version,
 			published_at,
 			created_at
 		FROM blogs
@@ -153,7 +148,7 @@ func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&blog).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&blog).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -162,18 +157,18 @@ func (r *repository) FindAll(params GetAllBlogParams) ([]Blog, int, error) {
 	return blog, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (BlogResponse, error) {
+func (r *repository) FindById(ctx context.Context, id int) (BlogResponse, error) {
 	var data BlogResponse
-	err := r.db.Table("blogs").Where("id = ?", id).Scan(&data).Error
+	err := r.db.WithContext(ctx).Table("blogs").Where("id = ?", id).Scan(&data).Error
 	if data.ID == 0 {
 		return BlogResponse{}, gorm.ErrRecordNotFound
 	}
 	return data, err
 }
 
-func (r *repository) FindByIdWithRelations(id int) ([]RawBlogRelationResponse, error) {
+func (r *repository) FindByIdWithRelations(ctx context.Context, id int) ([]RawBlogRelationResponse, error) {
 	var data []RawBlogRelationResponse
-	err := r.db.Raw(`
+	err := r.db.WithContext(ctx).Raw(`
 		SELECT 
 			b.id, 
 			b.title,
@@ -185,6 +180,8 @@ func (r *repository) FindByIdWithRelations(id int) ([]RawBlogRelationResponse, e
 			b.status,
 			b.slug,
 			b.is_highlight,
+			b.reviewer_id,
+			b.version,
 			b.created_at,
 			a.id as author_id,
 			a.name as author_name,
@@ -226,12 +223,12 @@ func (r *repository) FindByIdWithRelations(id int) ([]RawBlogRelationResponse, e
 	return data, err
 }
 
-func (r *repository) CreateBlog(p CreateBlogDTO, tx *gorm.DB) (Blog, error) {
+func (r *repository) CreateBlog(ctx context.Context, p CreateBlogDTO, tx *gorm.DB) (Blog, error) {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	data := Blog{
@@ -247,18 +244,19 @@ func (r *repository) CreateBlog(p CreateBlogDTO, tx *gorm.DB) (Blog, error) {
 		Slug:            p.Slug,
 		PublishedAt:     p.PublishedAt,
 		IsHighlight:     false,
+		Version:         1,
 	}
 
 	err := db.Table("blogs").Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateBlog(p UpdateBlogDTO, tx *gorm.DB) (Blog, error) {
+func (r *repository) UpdateBlog(ctx context.Context, p UpdateBlogDTO, tx *gorm.DB) (Blog, error) {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	updateMap := map[string]interface{}{
@@ -270,17 +268,16 @@ func (r *repository) UpdateBlog(p UpdateBlogDTO, tx *gorm.DB) (Blog, error) {
 		"banner_url":       p.BannerUrl,
 		"banner_file_name": p.BannerFileName,
 		"summary":          p.Summary,
-		"status":           p.Status,
 		"slug":             p.Slug,
 		"is_highlight":     p.IsHighlight == "Y",
-		"published_at":     p.PublishedAt,
 		"updated_at":       time.Now(),
 	}
 
-	err := db.Table("blogs").Where("id = ?", p.ID).Updates(updateMap).Error
+	err := utils.UpdateVersioned(db, "blogs", p.ID, p.Version, updateMap)
 
 	data := Blog{
 		ID:              p.ID,
+		Version:         p.Version + 1,
 		StatisticID:     p.StatisticID,
 		ReadingTimeID:   p.ReadingTimeID,
 		AuthorID:        p.AuthorID,
@@ -292,22 +289,21 @@ func (r *repository) UpdateBlog(p UpdateBlogDTO, tx *gorm.DB) (Blog, error) {
 		Status:          p.Status,
 		Slug:            p.Slug,
 		IsHighlight:     p.IsHighlight == "Y",
-		PublishedAt:     p.PublishedAt,
 		UpdatedAt:       time.Now(),
 	}
 	return data, err
 }
 
-func (r *repository) DeleteBlog(id int) (Blog, error) {
+func (r *repository) DeleteBlog(ctx context.Context, id int) (Blog, error) {
 	var data Blog
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Blog{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return Blog{}, err
 	}
 
@@ -315,22 +311,33 @@ func (r *repository) DeleteBlog(id int) (Blog, error) {
 	return data, nil
 }
 
-func (r *repository) ChangeStatusBlog(id int, status string, blog BlogResponse) (BlogChangeStatusResponse, error) {
+func (r *repository) ChangeStatusBlog(ctx context.Context, id int, status string, blog BlogResponse, tx *gorm.DB) (BlogChangeStatusResponse, error) {
+	var db *gorm.DB
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	} else {
+		db = r.db.WithContext(ctx)
+	}
+
 	now := time.Now()
 	var updateMap = make(map[string]interface{})
 	updateMap["status"] = status
-	if status == "Published" {
+	if status == string(editorial.StatusPublished) {
 		updateMap["published_at"] = now
 	}
-	err := r.db.Model(&Blog{}).Where("id = ?", id).Updates(updateMap).Error
-
-	if err != nil {
-		return BlogChangeStatusResponse{}, err
+	//? only update from the status the transition was checked against, so a
+	//? concurrent transition cannot be overwritten
+	result := db.Model(&Blog{}).Where("id = ? AND status = ?", id, blog.Status).Updates(updateMap)
+	if result.Error != nil {
+		return BlogChangeStatusResponse{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return BlogChangeStatusResponse{}, editorial.ErrStatusChanged(blog.Status)
 	}
 
 	// Return the updated data
 	var publishedAtStringPtr *string
-	if status == "Published" {
+	if status == string(editorial.StatusPublished) {
 		publishedAtString := now.Format("2006-01-02 15:04:05")
 		publishedAtStringPtr = &publishedAtString
 	}
@@ -344,10 +351,10 @@ func (r *repository) ChangeStatusBlog(id int, status string, blog BlogResponse)
 	return updatedData, nil
 }
 
-func (r *repository) CheckUniqueSlug(slug string) (bool, error) {
+func (r *repository) CheckUniqueSlug(ctx context.Context, slug string) (bool, error) {
 	var data BlogResponse
 
-	err := r.db.Table("blogs").Where("slug = ?", slug).Scan(&data).Error
+	err := r.db.WithContext(ctx).Table("blogs").Where("slug = ?", slug).Scan(&data).Error
 
 	if err != nil {
 		return false, err // handle DB or syntax error
@@ -359,3 +366,14 @@ func (r *repository) CheckUniqueSlug(slug string) (bool, error) {
 
 	return false, nil
 }
+
+func (r *repository) AssignReviewer(ctx context.Context, id int, reviewerID *int, tx *gorm.DB) error {
+	var db *gorm.DB
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	} else {
+		db = r.db.WithContext(ctx)
+	}
+
+	return db.Model(&Blog{}).Where("id = ?", id).Update("reviewer_id", reviewerID).Error
+}
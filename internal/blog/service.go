@@ -3,26 +3,32 @@ package blog
 import (
 	"context"
 	"fmt"
-	"time"
+	"net/http"
 
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAllBlogs(params GetAllBlogParams) ([]BlogResponse, int, error)
-	GetBlogByIdWithRelations(id int) (BlogRelationResponse, error)
-	GetBlogById(id int) (BlogResponse, error)
-	CreateBlog(p CreateBlogRequest) (BlogResponse, error)
-	UpdateBlog(p UpdateBlogRequest) (BlogUpdateResponse, error)
-	DeleteBlog(id int) (Blog, error)
-	ChangeStatusBlog(req BlogChangeStatusRequest) (BlogChangeStatusResponse, error)
+	GetAllBlogs(ctx context.Context, params GetAllBlogParams) ([]BlogResponse, int, error)
+	GetBlogByIdWithRelations(ctx context.Context, id int) (BlogRelationResponse, error)
+	GetBlogById(ctx context.Context, id int) (BlogResponse, error)
+	CreateBlog(ctx context.Context, actor user.Actor, p CreateBlogRequest) (BlogResponse, error)
+	UpdateBlog(ctx context.Context, actor user.Actor, p UpdateBlogRequest) (BlogUpdateResponse, error)
+	DeleteBlog(ctx context.Context, actor user.Actor, id int) (Blog, error)
+	ChangeStatusBlog(ctx context.Context, actor user.Actor, req BlogChangeStatusRequest) (BlogChangeStatusResponse, error)
+	AssignReviewerBlog(ctx context.Context, actor user.Actor, req BlogAssignReviewerRequest) (BlogResponse, error)
+	CommentBlog(ctx context.Context, actor user.Actor, req BlogCommentRequest) error
+	GetBlogReviews(ctx context.Context, id int) ([]editorial.EventResponse, error)
+	RecomputeReadingTime(ctx context.Context, id int) (reading_time.ReadingTimeResponse, error)
 }
 
 type service struct {
@@ -32,8 +38,11 @@ type service struct {
 	readingTimeService      reading_time.Service
 	blogTopicService        blog_topic.Service
 	blogContentImageService blog_content_image.Service
+	editorialService        editorial.Service
+	userService             user.Service
 	blogRepo                Repository
 	db                      *gorm.DB
+	storage                 utils.Storage
 }
 
 func NewService(
@@ -43,8 +52,11 @@ func NewService(
 	readingTimeSvc reading_time.Service,
 	blogTopicSvc blog_topic.Service,
 	blogContentImageSvc blog_content_image.Service,
+	editorialSvc editorial.Service,
+	userSvc user.Service,
 	r Repository,
-	db *gorm.DB) Service {
+	db *gorm.DB,
+	storage utils.Storage) Service {
 	return &service{
 		authorService:           authorSvc,
 		topicService:            topicSvc,
@@ -52,13 +64,16 @@ func NewService(
 		readingTimeService:      readingTimeSvc,
 		blogTopicService:        blogTopicSvc,
 		blogContentImageService: blogContentImageSvc,
+		editorialService:        editorialSvc,
+		userService:             userSvc,
 		blogRepo:                r,
 		db:                      db,
+		storage:                 storage,
 	}
 }
 
-func (s *service) GetAllBlogs(params GetAllBlogParams) ([]BlogResponse, int, error) {
-	datas, total, err := s.blogRepo.FindAll(params)
+func (s *service) GetAllBlogs(ctx context.Context, params GetAllBlogParams) ([]BlogResponse, int, error) {
+	datas, total, err := s.blogRepo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -70,16 +85,16 @@ func (s *service) GetAllBlogs(params GetAllBlogParams) ([]BlogResponse, int, err
 	return result, total, nil
 }
 
-func (s *service) GetBlogById(id int) (BlogResponse, error) {
-	data, err := s.blogRepo.FindById(id)
+func (s *service) GetBlogById(ctx context.Context, id int) (BlogResponse, error) {
+	data, err := s.blogRepo.FindById(ctx, id)
 	if err != nil {
 		return BlogResponse{}, err
 	}
 	return data, nil
 }
 
-func (s *service) GetBlogByIdWithRelations(id int) (BlogRelationResponse, error) {
-	data, err := s.blogRepo.FindByIdWithRelations(id)
+func (s *service) GetBlogByIdWithRelations(ctx context.Context, id int) (BlogRelationResponse, error) {
+	data, err := s.blogRepo.FindByIdWithRelations(ctx, id)
 
 	if err != nil {
 		return BlogRelationResponse{}, err
@@ -140,6 +155,8 @@ func (s *service) GetBlogByIdWithRelations(id int) (BlogRelationResponse, error)
 				Status:          row.Status,
 				Slug:            row.Slug,
 				IsHighlight:     row.IsHighlight,
+				ReviewerID:      row.ReviewerID,
+				Version:         row.Version,
 				PublishedAt:     publishedAtPointer,
 				CreatedAt:       row.CreatedAt.Format("2006-01-02 15:04:05"),
 				Author:          blogAuthor,
@@ -190,156 +207,175 @@ func (s *service) GetBlogByIdWithRelations(id int) (BlogRelationResponse, error)
 	return result, nil
 }
 
-func (s *service) CreateBlog(p CreateBlogRequest) (BlogResponse, error) {
+// resolveAuthorID defaults the author to the actor's linked profile and keeps
+// non-admins from writing blogs under another author.
+func (s *service) resolveAuthorID(actor user.Actor, requestedID int) (int, error) {
+	if requestedID == 0 {
+		if actor.AuthorID == nil {
+			return 0, utils.NewError("author_id is required, your account is not linked to an author")
+		}
+		return *actor.AuthorID, nil
+	}
+
+	if !actor.IsAdmin() && !actor.OwnsAuthor(requestedID) {
+		return 0, utils.ErrForbidden
+	}
+
+	return requestedID, nil
+}
+
+// checkOwnership allows admins everything and everyone else only blogs they author.
+func (s *service) checkOwnership(actor user.Actor, blog BlogResponse) error {
+	if actor.IsAdmin() || actor.OwnsAuthor(blog.AuthorID) {
+		return nil
+	}
+	return utils.ErrForbidden
+}
+
+func (s *service) CreateBlog(ctx context.Context, actor user.Actor, p CreateBlogRequest) (BlogResponse, error) {
+	//todo: Resolve Author Id
+	authorID, err := s.resolveAuthorID(actor, p.AuthorID)
+	if err != nil {
+		return BlogResponse{}, err
+	}
+	p.AuthorID = authorID
+
 	//todo: Check Author Id
 	//ex: author_id = 1
-	_, err := s.authorService.GetAuthorById(p.AuthorID)
+	_, err = s.authorService.GetAuthorById(ctx, p.AuthorID)
 
 	if err != nil {
-		err = fmt.Errorf("author_id %d not found", p.AuthorID)
+		err = utils.NewError("author_id {0} not found", p.AuthorID)
 		return BlogResponse{}, err
 	}
 
 	//todo: Check Is Unique Slug
 	slugVal := utils.StringToSlug(p.Slug)
-	is_unique_slug, err := s.blogRepo.CheckUniqueSlug(slugVal)
+	is_unique_slug, err := s.blogRepo.CheckUniqueSlug(ctx, slugVal)
 	if err != nil {
 		return BlogResponse{}, err
 	}
 	if !is_unique_slug {
-		err = fmt.Errorf("slug %s already exists", slugVal)
+		err = utils.NewError("slug {0} already exists", slugVal)
 		return BlogResponse{}, err
 	}
 
 	//todo: Check Topic Ids
 	//ex: topic_ids = [1, 2, 3]
 	topic_ids := p.TopicIds
-	_, err = s.topicService.CheckTopicIds(topic_ids)
+	_, err = s.topicService.CheckTopicIds(ctx, topic_ids)
 	if err != nil {
 		return BlogResponse{}, err
 	}
 
 	//todo: Check Content Images
-	err = s.blogContentImageService.CountUnlinkedImages(p.ContentImages)
-	if err != nil {
-		return BlogResponse{}, err
-	}
-
-	tx := s.db.Begin()
-
-	//todo: Create Statistic
-	zero := 0
-	pStatistic := statistic.CreateStatisticRequest{
-		Likes: &zero,
-		Views: &zero,
-		Type:  "Blog",
-	}
-	dataStatistic, err := s.statisticService.CreateStatisticWithTx(pStatistic, tx)
-
-	if err != nil {
-		tx.Rollback()
-		return BlogResponse{}, err
-	}
-
-	//todo: Create Reading Time
-	readingTimeStats := utils.ExtractHTMLtoStatistics(p.DescriptionHTML)
-	pReadingTime := reading_time.CreateReadingTimeRequest{
-		Minutes:          readingTimeStats.Minutes,
-		TextLength:       readingTimeStats.TextLength,
-		EstimatedSeconds: readingTimeStats.EstimatedSeconds,
-		WordCount:        readingTimeStats.WordCount,
-		Type:             "Blog",
-	}
-
-	dataReadingTime, err := s.readingTimeService.CreateReadingTime(pReadingTime, tx)
-
+	err = s.blogContentImageService.CountUnlinkedImages(ctx, p.ContentImages)
 	if err != nil {
-		tx.Rollback()
 		return BlogResponse{}, err
 	}
 
-	//todo: Upload Banner
-	bannerRes, err := utils.HandlUploadFile(p.BannerFile, "blog")
-	if err != nil {
-		tx.Rollback()
-		return BlogResponse{}, err
-	}
-
-	uploadedImageFilName := bannerRes.FileName
+	var data Blog
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		//todo: Create Statistic
+		zero := 0
+		pStatistic := statistic.CreateStatisticRequest{
+			Likes: &zero,
+			Views: &zero,
+			Type:  "Blog",
+		}
+		dataStatistic, err := s.statisticService.CreateStatisticWithTx(ctx, pStatistic, tx.DB)
+		if err != nil {
+			return err
+		}
 
-	//todo: Create Blog
+		//todo: Create Reading Time
+		readingTimeStats := utils.ExtractHTMLtoStatistics(p.DescriptionHTML)
+		pReadingTime := reading_time.CreateReadingTimeRequest{
+			Minutes:          readingTimeStats.Minutes,
+			TextLength:       readingTimeStats.TextLength,
+			EstimatedSeconds: readingTimeStats.EstimatedSeconds,
+			WordCount:        readingTimeStats.WordCount,
+			Type:             "Blog",
+		}
 
-	var publishedAt *time.Time
-	var status string
-	if p.IsPublished == "Y" {
-		now := time.Now()
-		publishedAt = &now
-		status = "PUBLISHED"
-	} else if p.IsPublished == "N" {
-		status = "UNPUBLISHED"
-	}
+		dataReadingTime, err := s.readingTimeService.CreateReadingTime(ctx, pReadingTime, tx.DB)
+		if err != nil {
+			return err
+		}
 
-	payload := CreateBlogDTO{
-		AuthorID:        p.AuthorID,
-		StatisticID:     dataStatistic.ID,
-		ReadingTimeID:   dataReadingTime.ID,
-		TopicIds:        p.TopicIds,
-		Title:           p.Title,
-		DescriptionHTML: p.DescriptionHTML,
-		BannerUrl:       bannerRes.FileURL,
-		BannerFileName:  bannerRes.FileName,
-		Summary:         p.Summary,
-		Status:          status,
-		Slug:            slugVal,
-		PublishedAt:     publishedAt,
-	}
+		//todo: Upload Banner
+		bannerRes, err := s.storage.Upload(ctx, p.BannerFile, "blog")
+		if err != nil {
+			return err
+		}
 
-	data, err := s.blogRepo.CreateBlog(payload, tx)
-	if err != nil {
-		tx.Rollback()
-		//? Delete banner image
-		if uploadedImageFilName != "" {
-			_ = utils.DeleteFromMinio(context.Background(), uploadedImageFilName)
+		//? Delete banner image when the blog is not saved
+		tx.AfterRollback(func(ctx context.Context) error {
+			return s.storage.Delete(ctx, bannerRes.FileName)
+		})
+
+		//todo: Create Blog, every blog starts as a draft
+		payload := CreateBlogDTO{
+			AuthorID:        p.AuthorID,
+			StatisticID:     dataStatistic.ID,
+			ReadingTimeID:   dataReadingTime.ID,
+			TopicIds:        p.TopicIds,
+			Title:           p.Title,
+			DescriptionHTML: p.DescriptionHTML,
+			BannerUrl:       bannerRes.FileURL,
+			BannerFileName:  bannerRes.FileName,
+			Summary:         p.Summary,
+			Status:          string(editorial.StatusDraft),
+			Slug:            slugVal,
 		}
-		return BlogResponse{}, err
-	}
 
-	//todo: Create Blog Topic
-	err = s.blogTopicService.BulkCreateBlogTopic(topic_ids, data.ID, tx)
-	if err != nil {
-		tx.Rollback()
-		//? Delete banner image
-		if uploadedImageFilName != "" {
-			_ = utils.DeleteFromMinio(context.Background(), uploadedImageFilName)
+		data, err = s.blogRepo.CreateBlog(ctx, payload, tx.DB)
+		if err != nil {
+			return err
 		}
-		return BlogResponse{}, err
-	}
 
-	//todo: Update Blog Content Images
-	err = s.blogContentImageService.MarkImagesUsedByBlog(p.ContentImages, data.ID, tx)
-	if err != nil {
-		tx.Rollback()
-		//? Delete banner image
-		if uploadedImageFilName != "" {
-			_ = utils.DeleteFromMinio(context.Background(), uploadedImageFilName)
+		//todo: Create Blog Topic
+		err = s.blogTopicService.BulkCreateBlogTopic(ctx, topic_ids, data.ID, tx.DB)
+		if err != nil {
+			return err
 		}
-		return BlogResponse{}, err
-	}
 
-	if err := tx.Commit().Error; err != nil {
-		err = fmt.Errorf("error commit transaction")
+		//todo: Update Blog Content Images
+		return s.blogContentImageService.MarkImagesUsedByBlog(ctx, p.ContentImages, data.ID, tx.DB)
+	})
+	if err != nil {
 		return BlogResponse{}, err
 	}
 
 	return ToBlogResponse(data), nil
 }
 
-func (s *service) UpdateBlog(p UpdateBlogRequest) (BlogUpdateResponse, error) {
-	blog, err := s.GetBlogById(p.ID)
+func (s *service) UpdateBlog(ctx context.Context, actor user.Actor, p UpdateBlogRequest) (BlogUpdateResponse, error) {
+	blog, err := s.GetBlogById(ctx, p.ID)
 	if err != nil {
 		return BlogUpdateResponse{}, err
 	}
 
+	//todo: Check Ownership
+	if err := s.checkOwnership(actor, blog); err != nil {
+		return BlogUpdateResponse{}, err
+	}
+
+	//? fail before uploading anything, the update checks the version again
+	if err := utils.CheckVersion(p.Version, blog.Version); err != nil {
+		return BlogUpdateResponse{}, err
+	}
+
+	//? keep the current author when none is sent
+	if p.AuthorID == 0 {
+		p.AuthorID = blog.AuthorID
+	} else if p.AuthorID != blog.AuthorID {
+		p.AuthorID, err = s.resolveAuthorID(actor, p.AuthorID)
+		if err != nil {
+			return BlogUpdateResponse{}, err
+		}
+	}
+
 	//* set oldFileName
 	oldFileName := ""
 	if blog.BannerFileName != "" {
@@ -352,7 +388,7 @@ func (s *service) UpdateBlog(p UpdateBlogRequest) (BlogUpdateResponse, error) {
 	for _, item := range p.TopicIds {
 		topic_ids = append(topic_ids, item.TopicID)
 	}
-	_, err = s.topicService.CheckTopicIds(topic_ids)
+	_, err = s.topicService.CheckTopicIds(ctx, topic_ids)
 	if err != nil {
 		return BlogUpdateResponse{}, err
 	}
@@ -361,172 +397,300 @@ func (s *service) UpdateBlog(p UpdateBlogRequest) (BlogUpdateResponse, error) {
 	slugVal := utils.StringToSlug(p.Slug)
 
 	if blog.Slug != slugVal {
-		is_unique_slug, err := s.blogRepo.CheckUniqueSlug(slugVal)
+		is_unique_slug, err := s.blogRepo.CheckUniqueSlug(ctx, slugVal)
 		if err != nil {
 			return BlogUpdateResponse{}, err
 		}
 		if !is_unique_slug {
-			err = fmt.Errorf("slug %s already exists", slugVal)
+			err = utils.NewError("slug {0} already exists", slugVal)
 			return BlogUpdateResponse{}, err
 		}
 	}
 
 	//todo: Check Author Id
-	_, err = s.authorService.GetAuthorById(p.AuthorID)
+	_, err = s.authorService.GetAuthorById(ctx, p.AuthorID)
 	if err != nil {
 		return BlogUpdateResponse{}, err
 	}
 
-	//! todo: Begin Transaction
+	var dataUpdated Blog
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		// todo: Update Blog Topics
+		err := s.blogTopicService.BatchUpdateBlogTopic(ctx, topic_ids, p.ID, tx.DB)
+		if err != nil {
+			return err
+		}
+
+		// todo: Sync Blog Images
+		oldImageBlogs, err := s.blogContentImageService.SyncBlogImages(ctx, p.ContentImages, p.ID, tx.DB)
+		if err != nil {
+			return err
+		}
+
+		if p.DescriptionHTML != blog.DescriptionHTML {
+			//todo: Extract Reading Time
+			readingTimeStats := utils.ExtractHTMLtoStatistics(p.DescriptionHTML)
+			pReadingTime := reading_time.UpdateReadingTimeRequest{
+				ID:               blog.ReadingTimeID,
+				Minutes:          readingTimeStats.Minutes,
+				TextLength:       readingTimeStats.TextLength,
+				EstimatedSeconds: readingTimeStats.EstimatedSeconds,
+				WordCount:        readingTimeStats.WordCount,
+				Type:             "Blog",
+			}
 
-	tx := s.db.Begin()
+			//todo: Update Reading Time
+			err := s.readingTimeService.UpdateReadingTime(ctx, pReadingTime, tx.DB)
+			if err != nil {
+				return err
+			}
+		}
 
-	// todo: Update Blog Topics
-	err = s.blogTopicService.BatchUpdateBlogTopic(topic_ids, p.ID, tx)
+		//todo: Handle Upload Banner
+		newFileURL := blog.BannerUrl // keep existing if not updated
+		newFileName := blog.BannerFileName
+
+		if p.BannerFile != nil {
+			imageRes, err := s.storage.Upload(ctx, p.BannerFile, "blog")
+			if err != nil {
+				return err
+			}
+
+			newFileURL = imageRes.FileURL
+			newFileName = imageRes.FileName
+
+			//? the new banner is dropped on failure, the replaced one only once the update is committed
+			tx.AfterRollback(func(ctx context.Context) error {
+				return s.storage.Delete(ctx, newFileName)
+			})
+			if oldFileName != "" {
+				tx.AfterCommit(func(ctx context.Context) error {
+					return s.storage.Delete(ctx, oldFileName)
+				})
+			}
+		}
+
+		//? status only changes through ChangeStatusBlog
+		payload := UpdateBlogDTO{
+			ID:              p.ID,
+			Version:         p.Version,
+			TopicIds:        p.TopicIds,
+			AuthorID:        p.AuthorID,
+			StatisticID:     blog.StatisticID,
+			ReadingTimeID:   blog.ReadingTimeID,
+			Title:           p.Title,
+			DescriptionHTML: p.DescriptionHTML,
+			BannerUrl:       newFileURL,
+			BannerFileName:  newFileName,
+			Summary:         p.Summary,
+			Status:          blog.Status,
+			Slug:            slugVal,
+			IsHighlight:     p.IsHighlight,
+		}
+
+		//todo: Update Blog
+		dataUpdated, err = s.blogRepo.UpdateBlog(ctx, payload, tx.DB)
+		if err != nil {
+			return err
+		}
+
+		//todo: Delete Old Blog Images
+		if len(oldImageBlogs) > 0 {
+			slice_image_urls := []string{}
+			for _, item := range oldImageBlogs {
+				slice_image_urls = append(slice_image_urls, item.ImageUrl)
+			}
+
+			return s.blogContentImageService.BulkDeleteHardByImageUrls(ctx, slice_image_urls, tx)
+		}
+		return nil
+	})
 	if err != nil {
-		tx.Rollback()
 		return BlogUpdateResponse{}, err
 	}
 
-	// todo: Sync Blog Images
-	oldImageBlogs, err := s.blogContentImageService.SyncBlogImages(p.ContentImages, p.ID, tx)
+	return ToBlogUpdateResponse(dataUpdated), nil
+}
 
+func (s *service) DeleteBlog(ctx context.Context, actor user.Actor, id int) (Blog, error) {
+	blog, err := s.GetBlogById(ctx, id)
 	if err != nil {
-		tx.Rollback()
-		return BlogUpdateResponse{}, err
+		return Blog{}, err
 	}
 
-	isUpdateReadingTime := false
-	if p.DescriptionHTML != blog.DescriptionHTML {
-		isUpdateReadingTime = true
+	//todo: Check Ownership
+	if err := s.checkOwnership(actor, blog); err != nil {
+		return Blog{}, err
 	}
 
-	if isUpdateReadingTime {
-		//todo: Extract Reading Time
-		readingTimeStats := utils.ExtractHTMLtoStatistics(p.DescriptionHTML)
-		pReadingTime := reading_time.UpdateReadingTimeRequest{
-			ID:               blog.ReadingTimeID,
-			Minutes:          readingTimeStats.Minutes,
-			TextLength:       readingTimeStats.TextLength,
-			EstimatedSeconds: readingTimeStats.EstimatedSeconds,
-			WordCount:        readingTimeStats.WordCount,
-			Type:             "Blog",
-		}
+	data, err := s.blogRepo.DeleteBlog(ctx, id)
+	if err != nil {
+		return Blog{}, err
+	}
+	return data, nil
+}
 
-		//todo: Update Reading Time
-		err := s.readingTimeService.UpdateReadingTime(pReadingTime, tx)
+// participant describes the actor's part in the editorial workflow of a blog.
+func (s *service) participant(actor user.Actor, blog BlogResponse) editorial.Participant {
+	return editorial.Participant{
+		IsAdmin:    actor.IsAdmin(),
+		IsOwner:    actor.OwnsAuthor(blog.AuthorID),
+		IsReviewer: blog.ReviewerID != nil && *blog.ReviewerID == actor.UserID,
+	}
+}
 
-		if err != nil {
-			tx.Rollback()
-			return BlogUpdateResponse{}, err
-		}
+func (s *service) ChangeStatusBlog(ctx context.Context, actor user.Actor, req BlogChangeStatusRequest) (BlogChangeStatusResponse, error) {
+	blog, err := s.GetBlogById(ctx, req.ID)
+	if err != nil {
+		return BlogChangeStatusResponse{}, err
 	}
 
-	//todo: Handle Upload Banner
-	var newFileURL string
-	var newFileName string
+	//todo: Validate Transition
+	from := editorial.ParseStatus(blog.Status)
+	to := editorial.Status(req.Status)
+	err = s.editorialService.CheckTransition(ctx, from, to, s.participant(actor, blog))
+	if err != nil {
+		return BlogChangeStatusResponse{}, err
+	}
 
-	if p.BannerFile != nil {
-		imageRes, err := utils.HandlUploadFile(p.BannerFile, "blog")
+	var data BlogChangeStatusResponse
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		data, err = s.blogRepo.ChangeStatusBlog(ctx, req.ID, string(to), blog, tx.DB)
 		if err != nil {
-			return BlogUpdateResponse{}, err
+			return err
 		}
 
-		newFileURL = imageRes.FileURL
-		newFileName = imageRes.FileName
-	} else {
-		newFileURL = blog.BannerUrl // keep existing if not updated
-		newFileName = blog.BannerFileName
-	}
-
-	var publishedAt *time.Time
-	var status string
-	var oldIsPublished string
-	if blog.Status == "Published" {
-		oldIsPublished = "Y"
-	} else {
-		oldIsPublished = "N"
-	}
-
-	if oldIsPublished != p.IsPublished {
-		if p.IsPublished == "Y" {
-			now := time.Now()
-			publishedAt = &now
-			status = "Published"
-		} else if p.IsPublished == "N" {
-			status = "Unpublished"
-		}
-	} else {
-		status = blog.Status
-	}
-
-	payload := UpdateBlogDTO{
-		ID:              p.ID,
-		TopicIds:        p.TopicIds,
-		AuthorID:        p.AuthorID,
-		StatisticID:     blog.StatisticID,
-		ReadingTimeID:   blog.ReadingTimeID,
-		Title:           p.Title,
-		DescriptionHTML: p.DescriptionHTML,
-		BannerUrl:       newFileURL,
-		BannerFileName:  newFileName,
-		Summary:         p.Summary,
-		Status:          status,
-		Slug:            slugVal,
-		IsHighlight:     p.IsHighlight,
-		PublishedAt:     publishedAt,
-	}
-
-	//todo: Update Blog
-	dataUpdated, err := s.blogRepo.UpdateBlog(payload, tx)
+		//todo: Record Transition
+		_, err = s.editorialService.RecordEvent(ctx, editorial.RecordEventDTO{
+			ContentType: editorial.ContentTypeBlog,
+			ContentID:   req.ID,
+			UserID:      actor.UserID,
+			Action:      editorial.ActionTransition,
+			FromStatus:  from,
+			ToStatus:    to,
+			Comment:     req.Comment,
+		}, tx.DB)
+		return err
+	})
 	if err != nil {
-		tx.Rollback()
-		if oldFileName != newFileName {
-			_ = utils.DeleteFromMinio(context.Background(), oldFileName)
-		}
-		return BlogUpdateResponse{}, err
+		return BlogChangeStatusResponse{}, err
+	}
+
+	return data, nil
+}
+
+func (s *service) AssignReviewerBlog(ctx context.Context, actor user.Actor, req BlogAssignReviewerRequest) (BlogResponse, error) {
+	blog, err := s.GetBlogById(ctx, req.ID)
+	if err != nil {
+		return BlogResponse{}, err
+	}
+
+	//todo: Check Ownership
+	if err := s.checkOwnership(actor, blog); err != nil {
+		return BlogResponse{}, err
 	}
 
-	//todo: Delete Old Blog Images
-	if len(oldImageBlogs) > 0 {
-		slice_image_urls := []string{}
-		for _, item := range oldImageBlogs {
-			slice_image_urls = append(slice_image_urls, item.ImageUrl)
+	//todo: Check Reviewer
+	if req.ReviewerID != nil {
+		reviewer, err := s.userService.GetActor(ctx, *req.ReviewerID)
+		if err != nil {
+			return BlogResponse{}, err
 		}
+		if !reviewer.CanReview() {
+			return BlogResponse{}, utils.NewStatusError(http.StatusUnprocessableEntity, "user {0} cannot review, role must be admin or editor", *req.ReviewerID)
+		}
+		//? nobody approves their own blog
+		if reviewer.OwnsAuthor(blog.AuthorID) {
+			return BlogResponse{}, utils.NewStatusError(http.StatusUnprocessableEntity, "user {0} owns this content and cannot review it", *req.ReviewerID)
+		}
+	}
 
-		err = s.blogContentImageService.BulkDeleteHardByImageUrls(slice_image_urls, tx)
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		err := s.blogRepo.AssignReviewer(ctx, req.ID, req.ReviewerID, tx.DB)
 		if err != nil {
-			tx.Rollback()
-			return BlogUpdateResponse{}, err
+			return err
 		}
+
+		status := editorial.ParseStatus(blog.Status)
+		_, err = s.editorialService.RecordEvent(ctx, editorial.RecordEventDTO{
+			ContentType: editorial.ContentTypeBlog,
+			ContentID:   req.ID,
+			UserID:      actor.UserID,
+			Action:      editorial.ActionAssignReviewer,
+			FromStatus:  status,
+			ToStatus:    status,
+			ReviewerID:  req.ReviewerID,
+		}, tx.DB)
+		return err
+	})
+	if err != nil {
+		return BlogResponse{}, err
 	}
 
-	//todo: Commit Transaction
-	if err := tx.Commit().Error; err != nil {
-		err = fmt.Errorf("error commit transaction")
-		return BlogUpdateResponse{}, err
+	blog.ReviewerID = req.ReviewerID
+	return blog, nil
+}
+
+func (s *service) CommentBlog(ctx context.Context, actor user.Actor, req BlogCommentRequest) error {
+	blog, err := s.GetBlogById(ctx, req.ID)
+	if err != nil {
+		return err
 	}
 
-	return ToBlogUpdateResponse(dataUpdated), nil
+	who := s.participant(actor, blog)
+	if !who.IsAdmin && !who.IsOwner && !who.IsReviewer {
+		return utils.ErrForbidden
+	}
+
+	status := editorial.ParseStatus(blog.Status)
+	_, err = s.editorialService.RecordEvent(ctx, editorial.RecordEventDTO{
+		ContentType: editorial.ContentTypeBlog,
+		ContentID:   req.ID,
+		UserID:      actor.UserID,
+		Action:      editorial.ActionComment,
+		FromStatus:  status,
+		ToStatus:    status,
+		Comment:     &req.Comment,
+	}, nil)
+	return err
 }
 
-func (s *service) DeleteBlog(id int) (Blog, error) {
-	data, err := s.blogRepo.DeleteBlog(id)
+func (s *service) GetBlogReviews(ctx context.Context, id int) ([]editorial.EventResponse, error) {
+	_, err := s.GetBlogById(ctx, id)
 	if err != nil {
-		return Blog{}, err
+		return nil, err
 	}
-	return data, nil
+
+	return s.editorialService.GetHistory(ctx, editorial.ContentTypeBlog, id)
 }
 
-func (s *service) ChangeStatusBlog(req BlogChangeStatusRequest) (BlogChangeStatusResponse, error) {
-	blog, err := s.GetBlogById(req.ID)
+// RecomputeReadingTime derives the blog's reading time from its current
+// description again, e.g. after the estimate itself changed.
+func (s *service) RecomputeReadingTime(ctx context.Context, id int) (reading_time.ReadingTimeResponse, error) {
+	blog, err := s.GetBlogById(ctx, id)
 	if err != nil {
-		return BlogChangeStatusResponse{}, err
+		return reading_time.ReadingTimeResponse{}, err
+	}
+	if blog.ReadingTimeID == 0 {
+		return reading_time.ReadingTimeResponse{}, fmt.Errorf("blog %d has no reading time", id)
 	}
 
-	data, err := s.blogRepo.ChangeStatusBlog(req.ID, req.Status, blog)
+	//todo: Extract Reading Time
+	readingTimeStats := utils.ExtractHTMLtoStatistics(blog.DescriptionHTML)
+	pReadingTime := reading_time.UpdateReadingTimeRequest{
+		ID:               blog.ReadingTimeID,
+		Minutes:          readingTimeStats.Minutes,
+		TextLength:       readingTimeStats.TextLength,
+		EstimatedSeconds: readingTimeStats.EstimatedSeconds,
+		WordCount:        readingTimeStats.WordCount,
+		Type:             "Blog",
+	}
+
+	//todo: Update Reading Time
+	err = s.readingTimeService.UpdateReadingTime(ctx, pReadingTime, nil)
 	if err != nil {
-		return BlogChangeStatusResponse{}, err
+		return reading_time.ReadingTimeResponse{}, err
 	}
-	return data, nil
+
+	return s.readingTimeService.GetReadingTimeById(ctx, blog.ReadingTimeID)
 }
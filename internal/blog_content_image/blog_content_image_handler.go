@@ -12,9 +12,9 @@ import (
 )
 
 func (h *handler) GetAll(c *gin.Context) {
-	datas, err := h.service.GetAllBlogContentImages()
+	datas, err := h.service.GetAllBlogContentImages(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", datas)
@@ -26,9 +26,9 @@ func (h *handler) GetBlogContentImageById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetBlogContentImageById(id)
+	data, err := h.service.GetBlogContentImageById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -38,14 +38,13 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	image_file, err := c.FormFile("image_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "image_file")
-		errors = utils.GenerateFieldErrorResponse("image_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("image_file", "{0} is required", "image_file")
 		return nil, errors, err
 	}
 
@@ -60,8 +59,7 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 		// Step 3: Validate size
 		if image_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "image_file")
-			errors := utils.GenerateFieldErrorResponse("image_file", err_name)
+			errors := utils.GenerateFieldErrorResponse("image_file", "{0} exceeds max size", "image_file")
 			return nil, errors, err
 		}
 	}
@@ -82,17 +80,17 @@ func (h *handler) CreateBlogContentImage(c *gin.Context) {
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.CreateBlogContentImage(req)
+	data, err := h.service.CreateBlogContentImage(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateBlogContentImage(c *gin.Context) {
@@ -102,25 +100,55 @@ func (h *handler) UpdateBlogContentImage(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "id type is wrong")
 		return
 	}
-	blog_id, err := strconv.Atoi(c.PostForm("blog_id"))
+
+	h.updateBlogContentImage(c, UpdateBlogContentImageRequest{ID: id})
+}
+
+// PatchBlogContentImage updates the fields the form names and keeps the others.
+func (h *handler) PatchBlogContentImage(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetBlogContentImageById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, "blog_id type is wrong")
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	req := UpdateBlogContentImageRequest{
+	h.updateBlogContentImage(c, UpdateBlogContentImageRequest{
 		ID:     id,
-		BlogID: &blog_id,
+		BlogID: current.BlogID,
+	})
+}
+
+// updateBlogContentImage overlays the form values on req before saving it.
+func (h *handler) updateBlogContentImage(c *gin.Context, req UpdateBlogContentImageRequest) {
+	if value, ok := c.GetPostForm("blog_id"); ok {
+		blog_id, err := strconv.Atoi(value)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, "blog_id type is wrong")
+			return
+		}
+		req.BlogID = &blog_id
 	}
 
+	validationCheck := []string{"extension", "size"}
+	image_file, errors, err := h.ValidateImage(c, validationCheck)
+	if err != nil {
+		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		return
+	}
+	req.ImageFile = image_file
+
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	err = h.service.UpdateBlogContentImage(req)
+	err = h.service.UpdateBlogContentImage(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -130,15 +158,25 @@ func (h *handler) UpdateBlogContentImage(c *gin.Context) {
 func (h *handler) DeleteBlogContentImage(c *gin.Context) {
 	var req BlogContentImageDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteBlogContentImage(c, req.ID)
+}
+
+func (h *handler) DeleteBlogContentImageById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteBlogContentImage(c, id)
+}
 
-	data, err := h.service.DeleteBlogContentImage(id)
+func (h *handler) deleteBlogContentImage(c *gin.Context, id int) {
+	data, err := h.service.DeleteBlogContentImage(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
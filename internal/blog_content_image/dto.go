@@ -9,7 +9,7 @@ type CreateBlogContentImageRequest struct {
 type UpdateBlogContentImageRequest struct {
 	ID        int                   `json:"id" validate:"required"`
 	BlogID    *int                  `json:"blog_id" validate:"required"`
-	ImageFile *multipart.FileHeader `json:"image_file" validate:"required"`
+	ImageFile *multipart.FileHeader `json:"image_file"`
 }
 
 type CreateBlogContentImageDTO struct {
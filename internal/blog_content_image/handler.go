@@ -2,17 +2,16 @@ package blog_content_image
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
 )
 
 type handler struct {
 	service Service
+	upload  config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
-	h := handler{service: service}
+func RegisterRoutes(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
 
 	blog_content_image := r.Group("/blog-content-images")
 	{
@@ -23,3 +22,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		blog_content_image.POST("/delete", h.DeleteBlogContentImage)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
+
+	blog_content_image := r.Group("/blog-content-images")
+	{
+		blog_content_image.GET("", h.GetAll)
+		blog_content_image.GET("/:id", h.GetBlogContentImageById)
+		blog_content_image.POST("", h.CreateBlogContentImage)
+		blog_content_image.PATCH("/:id", h.PatchBlogContentImage)
+		blog_content_image.DELETE("/:id", h.DeleteBlogContentImageById)
+	}
+}
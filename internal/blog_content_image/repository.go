@@ -1,22 +1,26 @@
 package blog_content_image
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll() ([]BlogContentImage, error)
-	FindById(id int) (BlogContentImage, error)
-	CreateBlogContentImage(p CreateBlogContentImageDTO) (BlogContentImage, error)
-	UpdateBlogContentImage(p UpdateBlogContentImageDTO) error
-	DeleteBlogContentImage(id int) (BlogContentImage, error)
-	CountUnlinkedImages(image_urls []string) (total int, err error)
-	MarkImagesUsedByBlog(p BlogContentImageBulkUpdateDTO, tx *gorm.DB) error
-	CountImagesLinkedToBlog(image_urls []string, blog_id int) (total int, err error)
-	FindImageExist(image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error)
-	FindImageNotExist(image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error)
-	BatchUpdateImagesById(ids []int, blog_id int, tx *gorm.DB) error
-	BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error
+	FindAll(ctx context.Context) ([]BlogContentImage, error)
+	FindById(ctx context.Context, id int) (BlogContentImage, error)
+	FindUnlinked(ctx context.Context, before time.Time) ([]BlogContentImage, error)
+	CreateBlogContentImage(ctx context.Context, p CreateBlogContentImageDTO) (BlogContentImage, error)
+	UpdateBlogContentImage(ctx context.Context, p UpdateBlogContentImageDTO) error
+	DeleteBlogContentImage(ctx context.Context, id int) (BlogContentImage, error)
+	CountUnlinkedImages(ctx context.Context, image_urls []string) (total int, err error)
+	MarkImagesUsedByBlog(ctx context.Context, p BlogContentImageBulkUpdateDTO, tx *gorm.DB) error
+	CountImagesLinkedToBlog(ctx context.Context, image_urls []string, blog_id int) (total int, err error)
+	FindImageExist(ctx context.Context, image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error)
+	FindImageNotExist(ctx context.Context, image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error)
+	BatchUpdateImagesById(ctx context.Context, ids []int, blog_id int, tx *gorm.DB) error
+	BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *gorm.DB) error
 }
 
 type repository struct {
@@ -27,64 +31,70 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll() ([]BlogContentImage, error) {
+func (r *repository) FindAll(ctx context.Context) ([]BlogContentImage, error) {
+	var datas []BlogContentImage
+	err := r.db.WithContext(ctx).Find(&datas).Error
+	return datas, err
+}
+
+func (r *repository) FindUnlinked(ctx context.Context, before time.Time) ([]BlogContentImage, error) {
 	var datas []BlogContentImage
-	err := r.db.Find(&datas).Error
+	err := r.db.WithContext(ctx).Where("blog_id IS NULL AND created_at < ?", before).Find(&datas).Error
 	return datas, err
 }
 
-func (r *repository) FindById(id int) (BlogContentImage, error) {
+func (r *repository) FindById(ctx context.Context, id int) (BlogContentImage, error) {
 	var data BlogContentImage
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) FindImageExist(image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error) {
+func (r *repository) FindImageExist(ctx context.Context, image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error) {
 	var data []BlogContentImageExistingResponse
-	err := r.db.Table("blog_content_images").
+	err := r.db.WithContext(ctx).Table("blog_content_images").
 		Where("image_url IN ? AND (blog_id = ? OR blog_id IS NULL)", image_urls, blog_id).
 		Select("id, blog_id, image_url").
 		Find(&data).Error
 	return data, err
 }
 
-func (r *repository) FindImageNotExist(image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error) {
+func (r *repository) FindImageNotExist(ctx context.Context, image_urls []string, blog_id int) ([]BlogContentImageExistingResponse, error) {
 	var data []BlogContentImageExistingResponse
-	err := r.db.Table("blog_content_images").
+	err := r.db.WithContext(ctx).Table("blog_content_images").
 		Where("blog_id = ? AND image_url NOT IN ?", blog_id, image_urls).
 		Select("id, blog_id, image_url").
 		Find(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateBlogContentImage(p CreateBlogContentImageDTO) (BlogContentImage, error) {
+func (r *repository) CreateBlogContentImage(ctx context.Context, p CreateBlogContentImageDTO) (BlogContentImage, error) {
 	data := BlogContentImage{
 		ImageUrl:      p.ImageUrl,
 		ImageFileName: p.ImageFileName}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateBlogContentImage(p UpdateBlogContentImageDTO) error {
+func (r *repository) UpdateBlogContentImage(ctx context.Context, p UpdateBlogContentImageDTO) error {
 	data := BlogContentImage{
 		ID:            p.ID,
 		BlogID:        p.BlogID,
 		ImageUrl:      p.ImageUrl,
 		ImageFileName: p.ImageFileName}
-	err := r.db.Updates(&data).Error
+	err := r.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
 
-func (r *repository) DeleteBlogContentImage(id int) (BlogContentImage, error) {
+func (r *repository) DeleteBlogContentImage(ctx context.Context, id int) (BlogContentImage, error) {
 	var data BlogContentImage
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return BlogContentImage{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return BlogContentImage{}, err
 	}
 
@@ -92,8 +102,8 @@ func (r *repository) DeleteBlogContentImage(id int) (BlogContentImage, error) {
 	return data, nil
 }
 
-func (r *repository) CountUnlinkedImages(image_urls []string) (total int, err error) {
-	err = r.db.Raw(`
+func (r *repository) CountUnlinkedImages(ctx context.Context, image_urls []string) (total int, err error) {
+	err = r.db.WithContext(ctx).Raw(`
 		SELECT COUNT(*) FROM blog_content_images 
 		WHERE image_url IN ? AND
 		blog_id IS NULL AND
@@ -102,12 +112,12 @@ func (r *repository) CountUnlinkedImages(image_urls []string) (total int, err er
 	return total, err
 }
 
-func (r *repository) MarkImagesUsedByBlog(p BlogContentImageBulkUpdateDTO, tx *gorm.DB) error {
+func (r *repository) MarkImagesUsedByBlog(ctx context.Context, p BlogContentImageBulkUpdateDTO, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	// UPDATE to TABLE PROJECT CONTENT IMAGES
@@ -124,8 +134,8 @@ func (r *repository) MarkImagesUsedByBlog(p BlogContentImageBulkUpdateDTO, tx *g
 	return nil
 }
 
-func (r *repository) CountImagesLinkedToBlog(image_urls []string, blog_id int) (total int, err error) {
-	err = r.db.Raw(`
+func (r *repository) CountImagesLinkedToBlog(ctx context.Context, image_urls []string, blog_id int) (total int, err error) {
+	err = r.db.WithContext(ctx).Raw(`
 		SELECT COUNT(*) FROM blog_content_images 
 		WHERE image_url IN ? AND
 		blog_id = ? AND
@@ -134,12 +144,12 @@ func (r *repository) CountImagesLinkedToBlog(image_urls []string, blog_id int) (
 	return total, err
 }
 
-func (r *repository) BatchUpdateImagesById(ids []int, blog_id int, tx *gorm.DB) error {
+func (r *repository) BatchUpdateImagesById(ctx context.Context, ids []int, blog_id int, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	err := db.Table("blog_content_images").
@@ -148,12 +158,12 @@ func (r *repository) BatchUpdateImagesById(ids []int, blog_id int, tx *gorm.DB)
 	return err
 }
 
-func (r *repository) BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error {
+func (r *repository) BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	// Create a raw SQL query to delete records with IDs in the slice
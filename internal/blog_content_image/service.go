@@ -2,36 +2,36 @@ package blog_content_image
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
+	"time"
 
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAllBlogContentImages() ([]BlogContentImageResponse, error)
-	GetBlogContentImageById(id int) (BlogContentImageResponse, error)
-	CreateBlogContentImage(p CreateBlogContentImageRequest) (BlogContentImageResponse, error)
-	UpdateBlogContentImage(p UpdateBlogContentImageRequest) error
-	DeleteBlogContentImage(id int) (BlogContentImageResponse, error)
-	CountUnlinkedImages(image_urls []string) error
-	MarkImagesUsedByBlog(image_urls []string, blog_id int, tx *gorm.DB) error
-	SyncBlogImages(image_urls []string, blog_id int, tx *gorm.DB) (imageNotExist []BlogContentImageExistingResponse, err error)
-	BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error
+	GetAllBlogContentImages(ctx context.Context) ([]BlogContentImageResponse, error)
+	GetBlogContentImageById(ctx context.Context, id int) (BlogContentImageResponse, error)
+	GetUnlinkedImages(ctx context.Context, before time.Time) ([]BlogContentImageResponse, error)
+	CreateBlogContentImage(ctx context.Context, p CreateBlogContentImageRequest) (BlogContentImageResponse, error)
+	UpdateBlogContentImage(ctx context.Context, p UpdateBlogContentImageRequest) error
+	DeleteBlogContentImage(ctx context.Context, id int) (BlogContentImageResponse, error)
+	CountUnlinkedImages(ctx context.Context, image_urls []string) error
+	MarkImagesUsedByBlog(ctx context.Context, image_urls []string, blog_id int, tx *gorm.DB) error
+	SyncBlogImages(ctx context.Context, image_urls []string, blog_id int, tx *gorm.DB) (imageNotExist []BlogContentImageExistingResponse, err error)
+	BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *utils.Tx) error
 }
 
 type service struct {
-	repo Repository
+	repo    Repository
+	storage utils.Storage
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
 }
 
-func (s *service) GetAllBlogContentImages() ([]BlogContentImageResponse, error) {
-	datas, err := s.repo.FindAll()
+func (s *service) GetAllBlogContentImages(ctx context.Context) ([]BlogContentImageResponse, error) {
+	datas, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -43,16 +43,31 @@ func (s *service) GetAllBlogContentImages() ([]BlogContentImageResponse, error)
 	return result, nil
 }
 
-func (s *service) GetBlogContentImageById(id int) (BlogContentImageResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetBlogContentImageById(ctx context.Context, id int) (BlogContentImageResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return BlogContentImageResponse{}, err
 	}
 	return ToBlogContentImageResponse(data), nil
 }
 
-func (s *service) CreateBlogContentImage(p CreateBlogContentImageRequest) (BlogContentImageResponse, error) {
-	imageRes, err := utils.HandlUploadFile(p.ImageFile, "blog")
+// GetUnlinkedImages returns the images uploaded before the given time that
+// never got attached to a blog, e.g. because the editor was abandoned.
+func (s *service) GetUnlinkedImages(ctx context.Context, before time.Time) ([]BlogContentImageResponse, error) {
+	datas, err := s.repo.FindUnlinked(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []BlogContentImageResponse
+	for _, p := range datas {
+		result = append(result, ToBlogContentImageResponse(p))
+	}
+	return result, nil
+}
+
+func (s *service) CreateBlogContentImage(ctx context.Context, p CreateBlogContentImageRequest) (BlogContentImageResponse, error) {
+	imageRes, err := s.storage.Upload(ctx, p.ImageFile, "blog")
 	if err != nil {
 		return BlogContentImageResponse{}, err
 	}
@@ -63,17 +78,17 @@ func (s *service) CreateBlogContentImage(p CreateBlogContentImageRequest) (BlogC
 		ImageFileName: imageRes.FileName,
 	}
 
-	data, err := s.repo.CreateBlogContentImage(payload)
+	data, err := s.repo.CreateBlogContentImage(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), imageRes.FileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), imageRes.FileName)
 		return BlogContentImageResponse{}, err
 	}
 	return ToBlogContentImageResponse(data), nil
 }
 
-func (s *service) UpdateBlogContentImage(p UpdateBlogContentImageRequest) error {
+func (s *service) UpdateBlogContentImage(ctx context.Context, p UpdateBlogContentImageRequest) error {
 	//todo: Get Data
-	blogImage, err := s.repo.FindById(p.ID)
+	blogImage, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
@@ -89,7 +104,7 @@ func (s *service) UpdateBlogContentImage(p UpdateBlogContentImageRequest) error
 
 	//todo: Upload File
 	if p.ImageFile != nil {
-		imageRes, err := utils.HandlUploadFile(p.ImageFile, "blog")
+		imageRes, err := s.storage.Upload(ctx, p.ImageFile, "blog")
 		if err != nil {
 			return err
 		}
@@ -108,50 +123,50 @@ func (s *service) UpdateBlogContentImage(p UpdateBlogContentImageRequest) error
 		ImageFileName: newFileName,
 	}
 
-	err = s.repo.UpdateBlogContentImage(payload)
+	err = s.repo.UpdateBlogContentImage(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), newFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), newFileName)
 		return err
 	}
 
 	//todo: Delete Old Image
 	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), oldFileName)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteBlogContentImage(id int) (BlogContentImageResponse, error) {
-	data, err := s.repo.DeleteBlogContentImage(id)
+func (s *service) DeleteBlogContentImage(ctx context.Context, id int) (BlogContentImageResponse, error) {
+	data, err := s.repo.DeleteBlogContentImage(ctx, id)
 	if err != nil {
 		return BlogContentImageResponse{}, err
 	}
 
-	_ = utils.DeleteFromMinio(context.Background(), data.ImageFileName)
+	_ = s.storage.Delete(context.WithoutCancel(ctx), data.ImageFileName)
 
 	return ToBlogContentImageResponse(data), nil
 }
 
-func (s *service) CountUnlinkedImages(image_urls []string) error {
-	total, err := s.repo.CountUnlinkedImages(image_urls)
+func (s *service) CountUnlinkedImages(ctx context.Context, image_urls []string) error {
+	total, err := s.repo.CountUnlinkedImages(ctx, image_urls)
 	if err != nil {
 		return err
 	}
 
 	if total != len(image_urls) {
-		err := fmt.Errorf("some blog_content_images not found in database")
+		err := utils.NewError("some blog_content_images not found in database")
 		return err
 	}
 	return nil
 }
 
-func (s *service) MarkImagesUsedByBlog(image_urls []string, blog_id int, tx *gorm.DB) error {
+func (s *service) MarkImagesUsedByBlog(ctx context.Context, image_urls []string, blog_id int, tx *gorm.DB) error {
 	payload := BlogContentImageBulkUpdateDTO{
 		ImageUrls: image_urls,
 		BlogID:    blog_id,
 	}
-	err := s.repo.MarkImagesUsedByBlog(payload, tx)
+	err := s.repo.MarkImagesUsedByBlog(ctx, payload, tx)
 	if err != nil {
 		return err
 	}
@@ -159,7 +174,7 @@ func (s *service) MarkImagesUsedByBlog(image_urls []string, blog_id int, tx *gor
 	return nil
 }
 
-func (s *service) SyncBlogImages(
+func (s *service) SyncBlogImages(ctx context.Context,
 	image_urls []string,
 	blog_id int,
 	tx *gorm.DB) (
@@ -167,7 +182,7 @@ func (s *service) SyncBlogImages(
 	err error,
 ) {
 	// 1. Cek apakah ada image baru di konten yang belum ada di database
-	imageExist, err := s.repo.FindImageExist(image_urls, blog_id)
+	imageExist, err := s.repo.FindImageExist(ctx, image_urls, blog_id)
 	if err != nil {
 		return imageNotExist, err
 	}
@@ -187,13 +202,13 @@ func (s *service) SyncBlogImages(
 	}
 
 	if len(imageIDsToUpdate) > 0 {
-		if err := s.repo.BatchUpdateImagesById(imageIDsToUpdate, blog_id, tx); err != nil {
+		if err := s.repo.BatchUpdateImagesById(ctx, imageIDsToUpdate, blog_id, tx); err != nil {
 			return imageNotExist, err
 		}
 	}
 
 	// 4. Hapus image lama yang tidak ada di konten lagi
-	imageNotExist, err = s.repo.FindImageNotExist(image_urls, blog_id)
+	imageNotExist, err = s.repo.FindImageNotExist(ctx, image_urls, blog_id)
 	if err != nil {
 		return imageNotExist, err
 	}
@@ -201,20 +216,24 @@ func (s *service) SyncBlogImages(
 	return imageNotExist, nil
 }
 
-func (s *service) BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error {
-	err := s.repo.BulkDeleteHardByImageUrls(image_urls, tx)
+func (s *service) BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *utils.Tx) error {
+	err := s.repo.BulkDeleteHardByImageUrls(ctx, image_urls, tx.DB)
 	if err != nil {
 		return err
 	}
 
-	bucketName := os.Getenv("MINIO_BUCKET")
-	images_key, _ := utils.MinioParseURLToImageKey(image_urls, bucketName)
-	batchSize := 3
+	//? files go only once the rows are gone for good
+	tx.AfterCommit(func(ctx context.Context) error {
+		bucketName := s.storage.Bucket()
+		images_key, _ := utils.MinioParseURLToImageKey(image_urls, bucketName)
+		batchSize := 3
 
-	err = utils.DeleteBulkImagesInBatches(bucketName, images_key, batchSize)
-	if err != nil {
-		log.Fatalf("Failed to delete images: %v", err)
-	}
+		err := s.storage.DeleteBatch(ctx, images_key, batchSize)
+		if err != nil {
+			utils.LoggerFrom(ctx).WithField("images", images_key).Error("failed to delete images: ", err)
+		}
+		return nil
+	})
 
 	return nil
 }
@@ -9,9 +9,9 @@ import (
 )
 
 func (h *handler) GetAll(c *gin.Context) {
-	data, err := h.service.GetAllBlogTopics()
+	data, err := h.service.GetAllBlogTopics(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
@@ -23,9 +23,9 @@ func (h *handler) GetBlogTopicById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetBlogTopicById(id)
+	data, err := h.service.GetBlogTopicById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -35,29 +35,54 @@ func (h *handler) CreateBlogTopic(c *gin.Context) {
 	// Validate the struct using validator
 	var req CreateBlogTopicRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CreateBlogTopic(req)
+	data, err := h.service.CreateBlogTopic(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateBlogTopic(c *gin.Context) {
 	var req UpdateBlogTopicRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	err := h.service.UpdateBlogTopic(req)
+	h.updateBlogTopic(c, req)
+}
+
+// PatchBlogTopic updates the fields the body names and keeps the others.
+func (h *handler) PatchBlogTopic(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetBlogTopicById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UpdateBlogTopicRequest{ID: id, BlogID: current.BlogID, TopicID: current.TopicID}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.updateBlogTopic(c, req)
+}
+
+func (h *handler) updateBlogTopic(c *gin.Context, req UpdateBlogTopicRequest) {
+	err := h.service.UpdateBlogTopic(c.Request.Context(), req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -67,15 +92,25 @@ func (h *handler) UpdateBlogTopic(c *gin.Context) {
 func (h *handler) DeleteBlogTopic(c *gin.Context) {
 	var req BlogTopicDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteBlogTopic(c, req.ID)
+}
+
+func (h *handler) DeleteBlogTopicById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteBlogTopic(c, id)
+}
 
-	data, err := h.service.DeleteBlogTopic(id)
+func (h *handler) deleteBlogTopic(c *gin.Context, id int) {
+	data, err := h.service.DeleteBlogTopic(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -2,16 +2,13 @@ package blog_topic
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	blog_topic := r.Group("/blog-topics")
@@ -23,3 +20,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		blog_topic.POST("/delete", h.DeleteBlogTopic)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	blog_topic := r.Group("/blog-topics")
+	{
+		blog_topic.GET("", h.GetAll)
+		blog_topic.GET("/:id", h.GetBlogTopicById)
+		blog_topic.POST("", h.CreateBlogTopic)
+		blog_topic.PATCH("/:id", h.PatchBlogTopic)
+		blog_topic.DELETE("/:id", h.DeleteBlogTopicById)
+	}
+}
@@ -1,18 +1,19 @@
 package blog_topic
 
 import (
+	"context"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll() ([]BlogTopic, error)
-	FindById(id int) (BlogTopic, error)
-	CreateBlogTopic(p CreateBlogTopicRequest) (BlogTopic, error)
-	BulkCreateBlogTopic(topic_ids []int, blog_id int, tx *gorm.DB) error
-	UpdateBlogTopic(p UpdateBlogTopicRequest) error
-	DeleteBlogTopic(id int) (BlogTopic, error)
-	BulkDeleteHard(topic_ids []int, tx *gorm.DB) error
-	FindExistingBlogTopics(blog_id int) ([]BlogTopicExistingResponse, error)
+	FindAll(ctx context.Context) ([]BlogTopic, error)
+	FindById(ctx context.Context, id int) (BlogTopic, error)
+	CreateBlogTopic(ctx context.Context, p CreateBlogTopicRequest) (BlogTopic, error)
+	BulkCreateBlogTopic(ctx context.Context, topic_ids []int, blog_id int, tx *gorm.DB) error
+	UpdateBlogTopic(ctx context.Context, p UpdateBlogTopicRequest) error
+	DeleteBlogTopic(ctx context.Context, id int) (BlogTopic, error)
+	BulkDeleteHard(ctx context.Context, topic_ids []int, tx *gorm.DB) error
+	FindExistingBlogTopics(ctx context.Context, blog_id int) ([]BlogTopicExistingResponse, error)
 }
 
 type repository struct {
@@ -23,41 +24,41 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll() ([]BlogTopic, error) {
+func (r *repository) FindAll(ctx context.Context) ([]BlogTopic, error) {
 	var datas []BlogTopic
-	err := r.db.Find(&datas).Error
+	err := r.db.WithContext(ctx).Find(&datas).Error
 	return datas, err
 }
 
-func (r *repository) FindById(id int) (BlogTopic, error) {
+func (r *repository) FindById(ctx context.Context, id int) (BlogTopic, error) {
 	var data BlogTopic
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) FindExistingBlogTopics(blog_id int) ([]BlogTopicExistingResponse, error) {
+func (r *repository) FindExistingBlogTopics(ctx context.Context, blog_id int) ([]BlogTopicExistingResponse, error) {
 	var data []BlogTopicExistingResponse
-	err := r.db.Table("blog_topics").
+	err := r.db.WithContext(ctx).Table("blog_topics").
 		Where("blog_id = ?", blog_id).
 		Select("id, blog_id, topic_id").
 		Find(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateBlogTopic(p CreateBlogTopicRequest) (BlogTopic, error) {
+func (r *repository) CreateBlogTopic(ctx context.Context, p CreateBlogTopicRequest) (BlogTopic, error) {
 	data := BlogTopic{
 		BlogID:  p.BlogID,
 		TopicID: p.TopicID}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) BulkCreateBlogTopic(topic_ids []int, blog_id int, tx *gorm.DB) error {
+func (r *repository) BulkCreateBlogTopic(ctx context.Context, topic_ids []int, blog_id int, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	var blog_topics []BlogTopic
@@ -76,25 +77,25 @@ func (r *repository) BulkCreateBlogTopic(topic_ids []int, blog_id int, tx *gorm.
 	return nil
 }
 
-func (r *repository) UpdateBlogTopic(p UpdateBlogTopicRequest) error {
+func (r *repository) UpdateBlogTopic(ctx context.Context, p UpdateBlogTopicRequest) error {
 	data := BlogTopic{
 		ID:      p.ID,
 		BlogID:  p.BlogID,
 		TopicID: p.TopicID}
-	err := r.db.Updates(&data).Error
+	err := r.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
 
-func (r *repository) DeleteBlogTopic(id int) (BlogTopic, error) {
+func (r *repository) DeleteBlogTopic(ctx context.Context, id int) (BlogTopic, error) {
 	var data BlogTopic
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return BlogTopic{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return BlogTopic{}, err
 	}
 
@@ -102,12 +103,12 @@ func (r *repository) DeleteBlogTopic(id int) (BlogTopic, error) {
 	return data, nil
 }
 
-func (r *repository) BulkDeleteHard(topic_ids []int, tx *gorm.DB) error {
+func (r *repository) BulkDeleteHard(ctx context.Context, topic_ids []int, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	// Create a raw SQL query to delete records with IDs in the slice
@@ -1,19 +1,20 @@
 package blog_topic
 
 import (
+	"context"
 	"slices"
 
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAllBlogTopics() ([]BlogTopicResponse, error)
-	GetBlogTopicById(id int) (BlogTopicResponse, error)
-	CreateBlogTopic(p CreateBlogTopicRequest) (BlogTopicResponse, error)
-	BulkCreateBlogTopic(topic_ids []int, project_id int, tx *gorm.DB) error
-	UpdateBlogTopic(p UpdateBlogTopicRequest) error
-	DeleteBlogTopic(id int) (BlogTopic, error)
-	BatchUpdateBlogTopic(topic_ids []int, blog_id int, tx *gorm.DB) error
+	GetAllBlogTopics(ctx context.Context) ([]BlogTopicResponse, error)
+	GetBlogTopicById(ctx context.Context, id int) (BlogTopicResponse, error)
+	CreateBlogTopic(ctx context.Context, p CreateBlogTopicRequest) (BlogTopicResponse, error)
+	BulkCreateBlogTopic(ctx context.Context, topic_ids []int, project_id int, tx *gorm.DB) error
+	UpdateBlogTopic(ctx context.Context, p UpdateBlogTopicRequest) error
+	DeleteBlogTopic(ctx context.Context, id int) (BlogTopic, error)
+	BatchUpdateBlogTopic(ctx context.Context, topic_ids []int, blog_id int, tx *gorm.DB) error
 }
 
 type service struct {
@@ -24,8 +25,8 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) GetAllBlogTopics() ([]BlogTopicResponse, error) {
-	datas, err := s.repo.FindAll()
+func (s *service) GetAllBlogTopics(ctx context.Context) ([]BlogTopicResponse, error) {
+	datas, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -37,37 +38,37 @@ func (s *service) GetAllBlogTopics() ([]BlogTopicResponse, error) {
 	return result, nil
 }
 
-func (s *service) GetBlogTopicById(id int) (BlogTopicResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetBlogTopicById(ctx context.Context, id int) (BlogTopicResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return BlogTopicResponse{}, err
 	}
 	return ToBlogTopicResponse(data), nil
 }
 
-func (s *service) CreateBlogTopic(p CreateBlogTopicRequest) (BlogTopicResponse, error) {
-	data, err := s.repo.CreateBlogTopic(p)
+func (s *service) CreateBlogTopic(ctx context.Context, p CreateBlogTopicRequest) (BlogTopicResponse, error) {
+	data, err := s.repo.CreateBlogTopic(ctx, p)
 	if err != nil {
 		return BlogTopicResponse{}, err
 	}
 	return ToBlogTopicResponse(data), nil
 }
 
-func (s *service) BulkCreateBlogTopic(topic_ids []int, blog_id int, tx *gorm.DB) error {
-	err := s.repo.BulkCreateBlogTopic(topic_ids, blog_id, tx)
+func (s *service) BulkCreateBlogTopic(ctx context.Context, topic_ids []int, blog_id int, tx *gorm.DB) error {
+	err := s.repo.BulkCreateBlogTopic(ctx, topic_ids, blog_id, tx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *service) UpdateBlogTopic(p UpdateBlogTopicRequest) error {
-	_, err := s.repo.FindById(p.ID)
+func (s *service) UpdateBlogTopic(ctx context.Context, p UpdateBlogTopicRequest) error {
+	_, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.UpdateBlogTopic(p)
+	err = s.repo.UpdateBlogTopic(ctx, p)
 	if err != nil {
 		return err
 	}
@@ -75,24 +76,24 @@ func (s *service) UpdateBlogTopic(p UpdateBlogTopicRequest) error {
 	return nil
 }
 
-func (s *service) DeleteBlogTopic(id int) (BlogTopic, error) {
-	data, err := s.repo.DeleteBlogTopic(id)
+func (s *service) DeleteBlogTopic(ctx context.Context, id int) (BlogTopic, error) {
+	data, err := s.repo.DeleteBlogTopic(ctx, id)
 	if err != nil {
 		return BlogTopic{}, err
 	}
 	return data, nil
 }
 
-func (s *service) BulkDeleteHard(topic_ids []int, tx *gorm.DB) error {
-	err := s.repo.BulkDeleteHard(topic_ids, tx)
+func (s *service) BulkDeleteHard(ctx context.Context, topic_ids []int, tx *gorm.DB) error {
+	err := s.repo.BulkDeleteHard(ctx, topic_ids, tx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *service) BatchUpdateBlogTopic(topic_ids []int, blog_id int, tx *gorm.DB) error {
-	existingBlogTopics, err := s.repo.FindExistingBlogTopics(blog_id)
+func (s *service) BatchUpdateBlogTopic(ctx context.Context, topic_ids []int, blog_id int, tx *gorm.DB) error {
+	existingBlogTopics, err := s.repo.FindExistingBlogTopics(ctx, blog_id)
 	if err != nil {
 		return err
 	}
@@ -110,12 +111,12 @@ func (s *service) BatchUpdateBlogTopic(topic_ids []int, blog_id int, tx *gorm.DB
 		return nil
 	}
 
-	err = s.repo.BulkDeleteHard(existing_topic_ids, tx)
+	err = s.repo.BulkDeleteHard(ctx, existing_topic_ids, tx)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.BulkCreateBlogTopic(topic_ids, blog_id, tx)
+	err = s.repo.BulkCreateBlogTopic(ctx, topic_ids, blog_id, tx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,50 @@
+package editorial
+
+// Participant describes how the acting user relates to the content.
+type Participant struct {
+	IsAdmin    bool
+	IsOwner    bool
+	IsReviewer bool
+}
+
+type RecordEventDTO struct {
+	ContentType string
+	ContentID   int
+	UserID      int
+	Action      string
+	FromStatus  Status
+	ToStatus    Status
+	ReviewerID  *int
+	Comment     *string
+}
+
+type EventResponse struct {
+	ID         int     `json:"id"`
+	UserID     int     `json:"user_id"`
+	Username   string  `json:"username"`
+	Action     string  `json:"action"`
+	FromStatus string  `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	ReviewerID *int    `json:"reviewer_id"`
+	Comment    *string `json:"comment"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type RawEventResponse struct {
+	Event
+	Username string
+}
+
+func ToEventResponse(p RawEventResponse) EventResponse {
+	return EventResponse{
+		ID:         p.ID,
+		UserID:     p.UserID,
+		Username:   p.Username,
+		Action:     p.Action,
+		FromStatus: p.FromStatus,
+		ToStatus:   p.ToStatus,
+		ReviewerID: p.ReviewerID,
+		Comment:    p.Comment,
+		CreatedAt:  p.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,45 @@
+package editorial
+
+import (
+	"time"
+)
+
+// Status is the editorial state of a blog or project.
+type Status string
+
+const (
+	StatusDraft     Status = "Draft"
+	StatusInReview  Status = "InReview"
+	StatusApproved  Status = "Approved"
+	StatusPublished Status = "Published"
+)
+
+const (
+	ContentTypeBlog    = "Blog"
+	ContentTypeProject = "Project"
+)
+
+const (
+	ActionTransition     = "transition"
+	ActionComment        = "comment"
+	ActionAssignReviewer = "assign_reviewer"
+)
+
+// Event is one entry of the review history: a status transition, a review
+// comment or a reviewer assignment.
+type Event struct {
+	ID          int     `json:"id" gorm:"primaryKey"`
+	ContentType string  `json:"content_type"`
+	ContentID   int     `json:"content_id"`
+	UserID      int     `json:"user_id"`
+	Action      string  `json:"action"`
+	FromStatus  string  `json:"from_status"`
+	ToStatus    string  `json:"to_status"`
+	ReviewerID  *int    `json:"reviewer_id"`
+	Comment     *string `json:"comment"`
+	CreatedAt   time.Time
+}
+
+func (Event) TableName() string {
+	return "editorial_events"
+}
@@ -0,0 +1,57 @@
+package editorial
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateEvent(ctx context.Context, p RecordEventDTO, tx *gorm.DB) (Event, error)
+	FindByContent(ctx context.Context, contentType string, contentID int) ([]RawEventResponse, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateEvent(ctx context.Context, p RecordEventDTO, tx *gorm.DB) (Event, error) {
+	var db *gorm.DB
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	} else {
+		db = r.db.WithContext(ctx)
+	}
+
+	data := Event{
+		ContentType: p.ContentType,
+		ContentID:   p.ContentID,
+		UserID:      p.UserID,
+		Action:      p.Action,
+		FromStatus:  string(p.FromStatus),
+		ToStatus:    string(p.ToStatus),
+		ReviewerID:  p.ReviewerID,
+		Comment:     p.Comment,
+	}
+	err := db.Create(&data).Error
+	return data, err
+}
+
+func (r *repository) FindByContent(ctx context.Context, contentType string, contentID int) ([]RawEventResponse, error) {
+	var datas []RawEventResponse
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			e.*,
+			u.username
+		FROM editorial_events e
+		LEFT JOIN users u ON u.id = e.user_id
+		WHERE
+			e.content_type = ? AND
+			e.content_id = ?
+		ORDER BY e.created_at ASC, e.id ASC
+	`, contentType, contentID).Scan(&datas).Error
+	return datas, err
+}
@@ -0,0 +1,131 @@
+package editorial
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// rule says who, besides an admin, may perform a transition.
+type rule struct {
+	owner    bool
+	reviewer bool
+}
+
+// transitions is the editorial state machine:
+// Draft -> InReview -> Approved -> Published, with a way back to Draft from every state.
+var transitions = map[Status]map[Status]rule{
+	StatusDraft: {
+		StatusInReview: {owner: true},
+	},
+	StatusInReview: {
+		StatusApproved: {reviewer: true},
+		StatusDraft:    {owner: true, reviewer: true},
+	},
+	StatusApproved: {
+		StatusPublished: {owner: true},
+		StatusDraft:     {owner: true, reviewer: true},
+	},
+	StatusPublished: {
+		StatusDraft: {owner: true},
+	},
+}
+
+// Path returns the statuses content passes through, in order, to get from one
+// status to another along the state machine. It is empty when from equals to
+// and nil when to cannot be reached.
+func Path(from, to Status) []Status {
+	prev := map[Status]Status{from: from}
+	queue := []Status{from}
+	for len(queue) > 0 && prev[to] == "" {
+		current := queue[0]
+		queue = queue[1:]
+		for next := range transitions[current] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	if _, ok := prev[to]; !ok {
+		return nil
+	}
+
+	path := []Status{}
+	for step := to; step != from; step = prev[step] {
+		path = append([]Status{step}, path...)
+	}
+	return path
+}
+
+// ParseStatus normalizes a stored status. Rows written before the workflow
+// existed use "PUBLISHED"/"Published" or "UNPUBLISHED"/"Unpublished".
+func ParseStatus(value string) Status {
+	switch strings.ToLower(strings.ReplaceAll(value, " ", "")) {
+	case "published":
+		return StatusPublished
+	case "approved":
+		return StatusApproved
+	case "inreview":
+		return StatusInReview
+	default:
+		return StatusDraft
+	}
+}
+
+type Service interface {
+	CheckTransition(ctx context.Context, from Status, to Status, who Participant) error
+	RecordEvent(ctx context.Context, p RecordEventDTO, tx *gorm.DB) (Event, error)
+	GetHistory(ctx context.Context, contentType string, contentID int) ([]EventResponse, error)
+}
+
+// ErrStatusChanged is returned by a status update when the content is no
+// longer in the status the transition was checked against.
+func ErrStatusChanged(from string) error {
+	return utils.NewStatusError(http.StatusConflict, "the status is no longer {0}, reload and try again", from)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{repo: r}
+}
+
+func (s *service) CheckTransition(ctx context.Context, from Status, to Status, who Participant) error {
+	allowed, ok := transitions[from][to]
+	if !ok {
+		return utils.NewStatusError(http.StatusConflict, "cannot change status from {0} to {1}", from, to)
+	}
+
+	if who.IsAdmin || (allowed.owner && who.IsOwner) || (allowed.reviewer && who.IsReviewer) {
+		return nil
+	}
+
+	return utils.ErrForbidden
+}
+
+func (s *service) RecordEvent(ctx context.Context, p RecordEventDTO, tx *gorm.DB) (Event, error) {
+	if p.Comment != nil && strings.TrimSpace(*p.Comment) == "" {
+		p.Comment = nil
+	}
+	return s.repo.CreateEvent(ctx, p, tx)
+}
+
+func (s *service) GetHistory(ctx context.Context, contentType string, contentID int) ([]EventResponse, error) {
+	datas, err := s.repo.FindByContent(ctx, contentType, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review history: %w", err)
+	}
+
+	var result []EventResponse
+	for _, p := range datas {
+		result = append(result, ToEventResponse(p))
+	}
+	return result, nil
+}
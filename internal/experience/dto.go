@@ -34,6 +34,7 @@ type UpdateExperienceRequest struct {
 	CompImageFile  *multipart.FileHeader `json:"comp_image_file"`
 	CompWebsiteUrl string                `json:"comp_website_url" validate:"required"`
 	IsCurrent      string                `json:"is_current" validate:"required,oneof=Y N"`
+	Version        int                   `json:"version" validate:"required"`
 }
 
 type CreateExperienceDTO struct {
@@ -53,6 +54,7 @@ type CreateExperienceDTO struct {
 
 type UpdateExperienceDTO struct {
 	ID                int
+	Version           int
 	Position          string
 	CompanyName       string
 	WorkType          string
@@ -81,6 +83,7 @@ type ExperienceResponse struct {
 	CompImageFileName string  `json:"comp_image_file_name"`
 	CompWebsiteUrl    string  `json:"comp_website_url"`
 	IsCurrent         string  `json:"is_current"`
+	Version           int     `json:"version"`
 	CreatedAt         string  `json:"created_at"`
 }
 
@@ -126,6 +129,7 @@ func ToExperienceResponse(p Experience) ExperienceResponse {
 		CompImageUrl:      p.CompImageUrl,
 		CompImageFileName: p.CompImageFileName,
 		IsCurrent:         utils.BoolToYN(p.IsCurrent),
+		Version:           p.Version,
 		CreatedAt:         p.CreatedAt.Format("2006-01-02"),
 	}
 }
@@ -69,9 +69,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllExperiences(params)
+	data, total_records, err := h.service.GetAllExperiences(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -85,11 +85,12 @@ func (h *handler) GetExperienceById(c *gin.Context) {
 		return
 	}
 
-	data, err := h.service.GetExperienceById(id)
+	data, err := h.service.GetExperienceById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success get data", data)
 }
 
@@ -97,14 +98,13 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	comp_image_file, err := c.FormFile("comp_image_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "comp_image_file")
-		errors = utils.GenerateFieldErrorResponse("comp_image_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("comp_image_file", "{0} is required", "comp_image_file")
 		return nil, errors, err
 	}
 
@@ -119,8 +119,7 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 		// Step 3: Validate size
 		if comp_image_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "comp_image_file")
-			errors := utils.GenerateFieldErrorResponse("comp_image_file", err_name)
+			errors := utils.GenerateFieldErrorResponse("comp_image_file", "{0} exceeds max size", "comp_image_file")
 			return nil, errors, err
 		}
 	}
@@ -162,82 +161,122 @@ func (h *handler) CreateExperience(c *gin.Context) {
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.CreateExperience(req)
+	data, err := h.service.CreateExperience(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateExperience(c *gin.Context) {
 	// Validate the struct using validator
 	id, _ := strconv.Atoi(c.PostForm("id"))
 
-	position := c.PostForm("position")
-	company_name := c.PostForm("company_name")
-	work_type := c.PostForm("work_type")
-	country := c.PostForm("country")
-	city := c.PostForm("city")
-	summary_html := c.PostForm("summary_html")
-	from_date := c.PostForm("from_date")
-	to_date := c.PostForm("to_date")
-	comp_website_url := c.PostForm("comp_website_url")
-	is_current := c.PostForm("is_current")
+	h.updateExperience(c, UpdateExperienceRequest{ID: id, City: new(string), ToDate: new(string)})
+}
 
-	validationCheck := []string{"extension", "size"}
-	comp_image_file, errors, err := h.ValidateImage(c, validationCheck)
+// PatchExperience updates the fields the form names and keeps the others.
+func (h *handler) PatchExperience(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetExperienceById(c.Request.Context(), id)
 	if err != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	req := UpdateExperienceRequest{
+	h.updateExperience(c, UpdateExperienceRequest{
 		ID:             id,
-		Position:       position,
-		CompanyName:    company_name,
-		WorkType:       work_type,
-		Country:        country,
-		City:           &city,
-		SummaryHTML:    summary_html,
-		FromDate:       from_date,
-		ToDate:         &to_date,
-		CompImageFile:  comp_image_file,
-		CompWebsiteUrl: comp_website_url,
-		IsCurrent:      is_current,
+		Position:       current.Position,
+		CompanyName:    current.CompanyName,
+		WorkType:       current.WorkType,
+		Country:        current.Country,
+		City:           current.City,
+		SummaryHTML:    current.SummaryHTML,
+		FromDate:       current.FromDate,
+		ToDate:         current.ToDate,
+		CompWebsiteUrl: current.CompWebsiteUrl,
+		IsCurrent:      current.IsCurrent,
+		Version:        current.Version,
+	})
+}
+
+// updateExperience overlays the form values on req before saving it, provided
+// the experience is still at the version the client sent.
+func (h *handler) updateExperience(c *gin.Context, req UpdateExperienceRequest) {
+	req.Position = utils.PostFormOr(c, "position", req.Position)
+	req.CompanyName = utils.PostFormOr(c, "company_name", req.CompanyName)
+	req.WorkType = utils.PostFormOr(c, "work_type", req.WorkType)
+	req.Country = utils.PostFormOr(c, "country", req.Country)
+	if city, ok := c.GetPostForm("city"); ok {
+		req.City = &city
+	}
+	req.SummaryHTML = utils.PostFormOr(c, "summary_html", req.SummaryHTML)
+	req.FromDate = utils.PostFormOr(c, "from_date", req.FromDate)
+	if to_date, ok := c.GetPostForm("to_date"); ok {
+		req.ToDate = &to_date
+	}
+	req.CompWebsiteUrl = utils.PostFormOr(c, "comp_website_url", req.CompWebsiteUrl)
+	req.IsCurrent = utils.PostFormOr(c, "is_current", req.IsCurrent)
+	version, ok := utils.RequestVersion(c, req.Version)
+	if !ok {
+		return
+	}
+	req.Version = version
+
+	validationCheck := []string{"extension", "size"}
+	comp_image_file, errors, err := h.ValidateImage(c, validationCheck)
+	if err != nil {
+		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		return
 	}
+	req.CompImageFile = comp_image_file
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	err = h.service.UpdateExperience(req)
+	err = h.service.UpdateExperience(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	utils.SetETag(c, req.Version+1)
 	utils.Success(c, "success updated data", nil)
 }
 
 func (h *handler) DeleteExperience(c *gin.Context) {
 	var req ExperienceDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteExperience(c, req.ID)
+}
+
+func (h *handler) DeleteExperienceById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteExperience(c, id)
+}
 
-	data, err := h.service.DeleteExperience(id)
+func (h *handler) deleteExperience(c *gin.Context, id int) {
+	data, err := h.service.DeleteExperience(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
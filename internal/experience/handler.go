@@ -2,17 +2,16 @@ package experience
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
 )
 
 type handler struct {
 	service Service
+	upload  config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
-	h := handler{service: service}
+func RegisterRoutes(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
 
 	experience := r.Group("/experiences")
 	{
@@ -23,3 +22,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		experience.POST("/delete", h.DeleteExperience)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
+
+	experience := r.Group("/experiences")
+	{
+		experience.GET("", h.GetAll)
+		experience.GET("/:id", h.GetExperienceById)
+		experience.POST("", h.CreateExperience)
+		experience.PATCH("/:id", h.PatchExperience)
+		experience.DELETE("/:id", h.DeleteExperienceById)
+	}
+}
@@ -20,6 +20,7 @@ type Experience struct {
 	CompImageFileName string     `json:"comp_image_file_name"`
 	CompWebsiteUrl    string     `json:"comp_website_url"`
 	IsCurrent         bool       `json:"is_current"`
+	Version           int        `json:"version" gorm:"not null;default:1"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         gorm.DeletedAt `gorm:"index"`
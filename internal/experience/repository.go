@@ -1,19 +1,21 @@
 package experience
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllExperienceParams) ([]Experience, int, error)
-	FindById(id int) (Experience, error)
-	CreateExperience(p CreateExperienceDTO) (Experience, error)
-	UpdateExperience(p UpdateExperienceDTO) error
-	DeleteExperience(id int) (Experience, error)
+	FindAll(ctx context.Context, params GetAllExperienceParams) ([]Experience, int, error)
+	FindById(ctx context.Context, id int) (Experience, error)
+	CreateExperience(ctx context.Context, p CreateExperienceDTO) (Experience, error)
+	UpdateExperience(ctx context.Context, p UpdateExperienceDTO) error
+	DeleteExperience(ctx context.Context, id int) (Experience, error)
 }
 
 type repository struct {
@@ -24,7 +26,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllExperienceParams) ([]Experience, int, error) {
 	var experience []Experience
 	var totalCount int
 
@@ -41,44 +43,44 @@ func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int,
 
 	//? field "position"
 	if params.Position != "" {
-		whereClauses = append(whereClauses, "(position LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Position+"%")
+		whereClauses = append(whereClauses, "(LOWER(position) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Position))
 	}
 
 	//? field "company_name"
 	if params.CompanyName != "" {
-		whereClauses = append(whereClauses, "(company_name LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CompanyName+"%")
+		whereClauses = append(whereClauses, "(LOWER(company_name) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.CompanyName))
 	}
 
 	//? field "work_type"
 	if params.WorkType != "" {
-		whereClauses = append(whereClauses, "(work_type LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.WorkType+"%")
+		whereClauses = append(whereClauses, "(LOWER(work_type) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.WorkType))
 	}
 
 	//? field "company_name"
 	if params.CompanyName != "" {
-		whereClauses = append(whereClauses, "(company_name LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CompanyName+"%")
+		whereClauses = append(whereClauses, "(LOWER(company_name) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.CompanyName))
 	}
 
 	//? field "country"
 	if params.Country != "" {
-		whereClauses = append(whereClauses, "(country LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Country+"%")
+		whereClauses = append(whereClauses, "(LOWER(country) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Country))
 	}
 
 	//? field "city"
 	if params.City != "" {
-		whereClauses = append(whereClauses, "(city LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.City+"%")
+		whereClauses = append(whereClauses, "(LOWER(city) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.City))
 	}
 
 	//? field "summary_html"
 	if params.SummaryHTML != "" {
-		whereClauses = append(whereClauses, "(summary_html LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.SummaryHTML+"%")
+		whereClauses = append(whereClauses, "(LOWER(summary_html) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.SummaryHTML))
 	}
 
 	//? field "is_current"
@@ -89,68 +91,33 @@ func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int,
 	}
 
 	//? field "from_date"
-	if len(params.FromDate) == 1 {
-		// If only one date is provided, use equality
-		whereClauses = append(whereClauses, "(from_date LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.FromDate[0]+"%")
-	} else if len(params.FromDate) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.FromDate[0])
+	if len(params.FromDate) > 0 {
+		clause, args, err := utils.DateFilter("from_date", params.FromDate)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.FromDate[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(from_date BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? field "to_date"
-	if len(params.ToDate) == 1 {
-		// If only one date is provided, use equality
-		whereClauses = append(whereClauses, "(to_date LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.ToDate[0]+"%")
-	} else if len(params.ToDate) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.ToDate[0])
+	if len(params.ToDate) > 0 {
+		clause, args, err := utils.DateFilter("to_date", params.ToDate)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.ToDate[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(to_date BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -164,7 +131,7 @@ func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int,
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -186,6 +153,7 @@ func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int,
 			comp_image_file_name,
 			comp_website_url,
 			is_current,
+			version,
 			created_at
 		FROM experiences
 	`
@@ -205,7 +173,7 @@ func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int,
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&experience).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&experience).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -214,16 +182,16 @@ func (r *repository) FindAll(params GetAllExperienceParams) ([]Experience, int,
 	return experience, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (Experience, error) {
+func (r *repository) FindById(ctx context.Context, id int) (Experience, error) {
 	var data Experience
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	if err == gorm.ErrRecordNotFound {
 		return Experience{}, gorm.ErrRecordNotFound
 	}
 	return data, err
 }
 
-func (r *repository) CreateExperience(p CreateExperienceDTO) (Experience, error) {
+func (r *repository) CreateExperience(ctx context.Context, p CreateExperienceDTO) (Experience, error) {
 	data := Experience{
 		Position:          p.Position,
 		CompanyName:       p.CompanyName,
@@ -236,12 +204,14 @@ func (r *repository) CreateExperience(p CreateExperienceDTO) (Experience, error)
 		CompImageUrl:      p.CompImageUrl,
 		CompImageFileName: p.CompImageFileName,
 		CompWebsiteUrl:    p.CompWebsiteUrl,
-		IsCurrent:         p.IsCurrent}
-	err := r.db.Create(&data).Error
+		IsCurrent:         p.IsCurrent,
+		Version:           1,
+	}
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateExperience(p UpdateExperienceDTO) error {
+func (r *repository) UpdateExperience(ctx context.Context, p UpdateExperienceDTO) error {
 	// Create a map with only the fields that are non-zero
 	updateMap := map[string]interface{}{
 		"position":             p.Position,
@@ -259,24 +229,19 @@ func (r *repository) UpdateExperience(p UpdateExperienceDTO) error {
 		"updated_at":           time.Now(),
 	}
 
-	err := r.db.Table("experiences").Where("id = ?", p.ID).Updates(updateMap).Error
-	if err != nil {
-		return err
-	}
-
-	return err
+	return utils.UpdateVersioned(r.db.WithContext(ctx), "experiences", p.ID, p.Version, updateMap)
 }
 
-func (r *repository) DeleteExperience(id int) (Experience, error) {
+func (r *repository) DeleteExperience(ctx context.Context, id int) (Experience, error) {
 	var data Experience
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Experience{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return Experience{}, err
 	}
 
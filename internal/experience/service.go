@@ -8,23 +8,24 @@ import (
 )
 
 type Service interface {
-	GetAllExperiences(params GetAllExperienceParams) ([]ExperienceResponse, int, error)
-	GetExperienceById(id int) (ExperienceResponse, error)
-	CreateExperience(p CreateExperienceRequest) (ExperienceResponse, error)
-	UpdateExperience(p UpdateExperienceRequest) error
-	DeleteExperience(id int) (Experience, error)
+	GetAllExperiences(ctx context.Context, params GetAllExperienceParams) ([]ExperienceResponse, int, error)
+	GetExperienceById(ctx context.Context, id int) (ExperienceResponse, error)
+	CreateExperience(ctx context.Context, p CreateExperienceRequest) (ExperienceResponse, error)
+	UpdateExperience(ctx context.Context, p UpdateExperienceRequest) error
+	DeleteExperience(ctx context.Context, id int) (Experience, error)
 }
 
 type service struct {
-	repo Repository
+	repo    Repository
+	storage utils.Storage
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
 }
 
-func (s *service) GetAllExperiences(params GetAllExperienceParams) ([]ExperienceResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllExperiences(ctx context.Context, params GetAllExperienceParams) ([]ExperienceResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -36,16 +37,16 @@ func (s *service) GetAllExperiences(params GetAllExperienceParams) ([]Experience
 	return result, total, nil
 }
 
-func (s *service) GetExperienceById(id int) (ExperienceResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetExperienceById(ctx context.Context, id int) (ExperienceResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return ExperienceResponse{}, err
 	}
 	return ToExperienceResponse(data), nil
 }
 
-func (s *service) CreateExperience(p CreateExperienceRequest) (ExperienceResponse, error) {
-	imageFile, err := utils.HandlUploadFile(p.CompImageFile, "experience")
+func (s *service) CreateExperience(ctx context.Context, p CreateExperienceRequest) (ExperienceResponse, error) {
+	imageFile, err := s.storage.Upload(ctx, p.CompImageFile, "experience")
 	if err != nil {
 		return ExperienceResponse{}, err
 	}
@@ -74,19 +75,22 @@ func (s *service) CreateExperience(p CreateExperienceRequest) (ExperienceRespons
 		IsCurrent:         p.IsCurrent == "Y",
 	}
 
-	data, err := s.repo.CreateExperience(payload)
+	data, err := s.repo.CreateExperience(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), imageFile.FileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), imageFile.FileName)
 		return ExperienceResponse{}, err
 	}
 	return ToExperienceResponse(data), nil
 }
 
-func (s *service) UpdateExperience(p UpdateExperienceRequest) error {
-	experience, err := s.repo.FindById(p.ID)
+func (s *service) UpdateExperience(ctx context.Context, p UpdateExperienceRequest) error {
+	experience, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
+	if err := utils.CheckVersion(p.Version, experience.Version); err != nil {
+		return err
+	}
 
 	//todo: set oldFileName
 	oldFileName := ""
@@ -99,7 +103,7 @@ func (s *service) UpdateExperience(p UpdateExperienceRequest) error {
 	var newFileName string
 
 	if p.CompImageFile != nil {
-		imageRes, err := utils.HandlUploadFile(p.CompImageFile, "project")
+		imageRes, err := s.storage.Upload(ctx, p.CompImageFile, "project")
 		if err != nil {
 			return err
 		}
@@ -123,6 +127,7 @@ func (s *service) UpdateExperience(p UpdateExperienceRequest) error {
 	}
 	payload := UpdateExperienceDTO{
 		ID:                p.ID,
+		Version:           p.Version,
 		Position:          p.Position,
 		CompanyName:       p.CompanyName,
 		WorkType:          p.WorkType,
@@ -138,22 +143,24 @@ func (s *service) UpdateExperience(p UpdateExperienceRequest) error {
 	}
 
 	//todo: Update Experience
-	err = s.repo.UpdateExperience(payload)
+	err = s.repo.UpdateExperience(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), newFileName)
+		if p.CompImageFile != nil {
+			_ = s.storage.Delete(context.WithoutCancel(ctx), newFileName)
+		}
 		return err
 	}
 
 	//todo: Delete Old Image
 	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), oldFileName)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteExperience(id int) (Experience, error) {
-	data, err := s.repo.DeleteExperience(id)
+func (s *service) DeleteExperience(ctx context.Context, id int) (Experience, error) {
+	data, err := s.repo.DeleteExperience(ctx, id)
 	if err != nil {
 		return Experience{}, err
 	}
@@ -0,0 +1,27 @@
+package idempotency
+
+import "time"
+
+// BeginKeyRequest is a create request sent with an Idempotency-Key.
+type BeginKeyRequest struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	TTL         time.Duration
+	LockTimeout time.Duration
+}
+
+type CreateKeyDTO struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	ExpiresAt   time.Time
+}
+
+type CompleteKeyDTO struct {
+	ID                  int
+	ResponseStatus      int
+	ResponseContentType string
+	ResponseHeaders     string
+	ResponseBody        string
+}
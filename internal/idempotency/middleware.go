@@ -0,0 +1,231 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+const (
+	// Header carries the key a client picks for a create request and sends
+	// again when it retries it.
+	Header = "Idempotency-Key"
+	// ReplayedHeader marks a response replayed from the first request.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	//? the same limit gin parses multipart forms with
+	multipartMemory = 32 << 20
+)
+
+// replayedHeaders are the response headers a replay sends again, next to the
+// Content-Type, e.g. the Location of a record created on v2.
+var replayedHeaders = []string{"Location", "ETag"}
+
+// Middleware makes the routes creates reports true for honour an
+// Idempotency-Key: the first request with a key runs, a retry with the same
+// key and request gets its response replayed for cfg.TTL, and one sent while
+// the first is still running is rejected with 409. Keys are scoped to the
+// caller, so the middleware must run after the auth middleware.
+func Middleware(service Service, cfg config.IdempotencyConfig, creates func(method, route string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		if key == "" || !creates(c.Request.Method, c.FullPath()) {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			utils.Error(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		hash, err := requestHash(c)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, "request body could not be read")
+			c.Abort()
+			return
+		}
+
+		ctx := c.Request.Context()
+		stored, started, err := service.BeginKey(ctx, BeginKeyRequest{
+			Scope:       scope(c),
+			Key:         key,
+			RequestHash: hash,
+			TTL:         cfg.TTL,
+			LockTimeout: cfg.LockTimeout,
+		})
+		if err != nil {
+			if errors.Is(err, ErrKeyInProgress) {
+				c.Header("Retry-After", "1")
+			}
+			utils.ErrorFrom(c, http.StatusInternalServerError, err)
+			c.Abort()
+			return
+		}
+		if !started {
+			c.Header(ReplayedHeader, "true")
+			setStoredHeaders(c, stored.ResponseHeaders)
+			c.Data(stored.ResponseStatus, stored.ResponseContentType, []byte(stored.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		//? the key outlives the request, whose context may be cancelled by now
+		ctx = context.WithoutCancel(ctx)
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		defer func() {
+			//* a panic or a server error leaves nothing to replay, the retry runs again
+			if rec := recover(); rec != nil {
+				release(ctx, service, stored.ID)
+				panic(rec)
+			}
+			if c.Writer.Status() >= http.StatusInternalServerError {
+				release(ctx, service, stored.ID)
+				return
+			}
+			err := service.CompleteKey(ctx, CompleteKeyDTO{
+				ID:                  stored.ID,
+				ResponseStatus:      c.Writer.Status(),
+				ResponseContentType: c.Writer.Header().Get("Content-Type"),
+				ResponseHeaders:     storedHeaders(c.Writer.Header()),
+				ResponseBody:        recorder.body.String(),
+			})
+			if err != nil {
+				utils.LoggerFrom(ctx).WithError(err).Error("store idempotent response")
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// storedHeaders encodes the replayed headers the response set.
+func storedHeaders(header http.Header) string {
+	stored := map[string]string{}
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			stored[name] = value
+		}
+	}
+	if len(stored) == 0 {
+		return ""
+	}
+	body, _ := json.Marshal(stored)
+	return string(body)
+}
+
+// setStoredHeaders sets the headers storedHeaders encoded again.
+func setStoredHeaders(c *gin.Context, stored string) {
+	if stored == "" {
+		return
+	}
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(stored), &headers); err != nil {
+		utils.LoggerFrom(c.Request.Context()).WithError(err).Error("read stored idempotent headers")
+		return
+	}
+	for name, value := range headers {
+		c.Header(name, value)
+	}
+}
+
+func release(ctx context.Context, service Service, id int) {
+	if err := service.ReleaseKey(ctx, id); err != nil {
+		utils.LoggerFrom(ctx).WithError(err).Error("release idempotency key")
+	}
+}
+
+// scope keeps the keys of different callers apart. An API key gets its own
+// scope, so it cannot replay what its owner created with a token.
+func scope(c *gin.Context) string {
+	if keyID, ok := c.Get("api_key_id"); ok {
+		return fmt.Sprintf("api-key:%v", keyID)
+	}
+	if userID, ok := utils.GetAuthUserID(c); ok {
+		return "user:" + strconv.Itoa(userID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// requestHash fingerprints the method, path and body of the request. A
+// multipart body is hashed by its fields and files, as clients pick a new
+// boundary for every attempt.
+func requestHash(c *gin.Context) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", c.Request.Method, c.Request.URL.Path)
+
+	if c.ContentType() != gin.MIMEMultipartPOSTForm {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	//? the handler reads the parsed form, the body is consumed here
+	if err := c.Request.ParseMultipartForm(multipartMemory); err != nil {
+		return "", err
+	}
+	form := c.Request.MultipartForm
+	for _, name := range sortedKeys(form.Value) {
+		fmt.Fprintf(h, "%s=%q\n", name, form.Value[name])
+	}
+	for _, name := range sortedKeys(form.File) {
+		for _, header := range form.File[name] {
+			file, err := header.Open()
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "%s:%q:", name, header.Filename)
+			_, err = io.Copy(h, file)
+			file.Close()
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// responseRecorder keeps a copy of the response body for replays.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body strings.Builder
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,25 @@
+package idempotency
+
+import "time"
+
+// IdempotencyKey is an Idempotency-Key sent by a client, with the request it
+// was first sent with and, once that request finished, its response.
+// ResponseStatus stays 0 while the request is in progress.
+type IdempotencyKey struct {
+	ID                  int    `json:"id" gorm:"primaryKey"`
+	Scope               string `json:"scope"`
+	Key                 string `json:"key" gorm:"column:idempotency_key"`
+	RequestHash         string `json:"request_hash"`
+	ResponseStatus      int    `json:"response_status"`
+	ResponseContentType string `json:"response_content_type"`
+	ResponseHeaders     string `json:"response_headers"` // JSON object of the replayed headers
+	ResponseBody        string `json:"response_body"`
+	ExpiresAt           time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// InProgress reports whether the request that sent the key has not finished.
+func (k IdempotencyKey) InProgress() bool {
+	return k.ResponseStatus == 0
+}
@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindByKey(ctx context.Context, scope, key string) (IdempotencyKey, error)
+	CreateKey(ctx context.Context, p CreateKeyDTO) (IdempotencyKey, error)
+	CompleteKey(ctx context.Context, p CompleteKeyDTO) error
+	ReleaseKey(ctx context.Context, id int) error
+	DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindByKey(ctx context.Context, scope, key string) (IdempotencyKey, error) {
+	var data IdempotencyKey
+	err := r.db.WithContext(ctx).Where("scope = ? AND idempotency_key = ?", scope, key).First(&data).Error
+	return data, err
+}
+
+// CreateKey fails on the unique index when the scope already holds the key.
+func (r *repository) CreateKey(ctx context.Context, p CreateKeyDTO) (IdempotencyKey, error) {
+	data := IdempotencyKey{
+		Scope:       p.Scope,
+		Key:         p.Key,
+		RequestHash: p.RequestHash,
+		ExpiresAt:   p.ExpiresAt,
+	}
+	err := r.db.WithContext(ctx).Create(&data).Error
+	return data, err
+}
+
+func (r *repository) CompleteKey(ctx context.Context, p CompleteKeyDTO) error {
+	return r.db.WithContext(ctx).Model(&IdempotencyKey{}).Where("id = ?", p.ID).Updates(map[string]interface{}{
+		"response_status":       p.ResponseStatus,
+		"response_content_type": p.ResponseContentType,
+		"response_headers":      p.ResponseHeaders,
+		"response_body":         p.ResponseBody,
+	}).Error
+}
+
+// ReleaseKey deletes the key while its request is in progress, so a retry can
+// send it again.
+func (r *repository) ReleaseKey(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Where("id = ? AND response_status = 0", id).Delete(&IdempotencyKey{}).Error
+}
+
+func (r *repository) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
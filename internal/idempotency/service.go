@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrKeyInProgress rejects a retry sent while the first request with the
+	// same key is still running.
+	ErrKeyInProgress = utils.NewStatusError(http.StatusConflict, "a request with this Idempotency-Key is still in progress")
+	// ErrKeyReused rejects a key sent again with a different request.
+	ErrKeyReused = utils.NewStatusError(http.StatusUnprocessableEntity, "this Idempotency-Key was already used for a different request")
+)
+
+type Service interface {
+	BeginKey(ctx context.Context, p BeginKeyRequest) (IdempotencyKey, bool, error)
+	CompleteKey(ctx context.Context, p CompleteKeyDTO) error
+	ReleaseKey(ctx context.Context, id int) error
+	PurgeExpiredKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{repo: r}
+}
+
+// BeginKey stores the key of a request about to run and returns it with true.
+// When the key was already sent with the same request and that request
+// finished, it returns the stored key with false, for its response to be
+// replayed.
+func (s *service) BeginKey(ctx context.Context, p BeginKeyRequest) (IdempotencyKey, bool, error) {
+	//? one retry after removing an expired or abandoned key
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		created, err := s.repo.CreateKey(ctx, CreateKeyDTO{
+			Scope:       p.Scope,
+			Key:         p.Key,
+			RequestHash: p.RequestHash,
+			ExpiresAt:   now.Add(p.TTL),
+		})
+		if err == nil {
+			return created, true, nil
+		}
+
+		//* the insert hit the unique index, unless the key is not there
+		existing, findErr := s.repo.FindByKey(ctx, p.Scope, p.Key)
+		if errors.Is(findErr, gorm.ErrRecordNotFound) {
+			return IdempotencyKey{}, false, err
+		}
+		if findErr != nil {
+			return IdempotencyKey{}, false, findErr
+		}
+
+		switch {
+		case !existing.ExpiresAt.After(now):
+			if _, err := s.repo.DeleteExpiredKeys(ctx, now); err != nil {
+				return IdempotencyKey{}, false, err
+			}
+		case existing.RequestHash != p.RequestHash:
+			return IdempotencyKey{}, false, ErrKeyReused
+		case existing.InProgress() && existing.CreatedAt.Before(now.Add(-p.LockTimeout)):
+			//? the request never finished, e.g. the server stopped mid-way
+			if err := s.repo.ReleaseKey(ctx, existing.ID); err != nil {
+				return IdempotencyKey{}, false, err
+			}
+		case existing.InProgress():
+			return IdempotencyKey{}, false, ErrKeyInProgress
+		default:
+			return existing, false, nil
+		}
+	}
+	return IdempotencyKey{}, false, ErrKeyInProgress
+}
+
+// CompleteKey stores the response of the request holding the key.
+func (s *service) CompleteKey(ctx context.Context, p CompleteKeyDTO) error {
+	return s.repo.CompleteKey(ctx, p)
+}
+
+// ReleaseKey frees the key of a request that failed, so it can be retried.
+func (s *service) ReleaseKey(ctx context.Context, id int) error {
+	return s.repo.ReleaseKey(ctx, id)
+}
+
+func (s *service) PurgeExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteExpiredKeys(ctx, now)
+}
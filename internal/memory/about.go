@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"gorm.io/gorm"
+)
+
+type aboutRepository struct {
+	s *Store
+}
+
+func (r *aboutRepository) FindAll(ctx context.Context) ([]about.About, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.abouts.filter(func(about.About) bool { return true }), nil
+}
+
+func (r *aboutRepository) FindById(ctx context.Context, id int) (about.About, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.abouts.get(id)
+	if !ok {
+		return about.About{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *aboutRepository) CreateAbout(ctx context.Context, p about.CreateAboutDTO) (about.About, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := about.About{
+		Title:           p.Title,
+		DescriptionHTML: p.DescriptionHTML,
+		AvatarUrl:       p.AvatarUrl,
+		AvatarFileName:  p.AvatarFileName,
+	}
+	r.s.abouts.insert(&data)
+	return data, nil
+}
+
+func (r *aboutRepository) UpdateAbout(ctx context.Context, p about.UpdateAboutDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.abouts.updateVersioned(p.ID, p.Version, func(row *about.About) {
+		row.Title = p.Title
+		row.DescriptionHTML = p.DescriptionHTML
+		row.AvatarUrl = p.AvatarUrl
+		row.AvatarFileName = p.AvatarFileName
+		row.IsUsed = p.IsUsed
+	})
+}
+
+func (r *aboutRepository) DeleteAbout(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.abouts.deleteID(id)
+	return nil
+}
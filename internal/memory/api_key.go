@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/api_key"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	s *Store
+}
+
+func (r *apiKeyRepository) FindAllByUser(ctx context.Context, userID int) ([]api_key.ApiKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	keys := r.s.apiKeys.filter(func(k api_key.ApiKey) bool { return k.UserID == userID })
+	if err := sortRows(keys, "id", "DESC"); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) FindByIdAndUser(ctx context.Context, id int, userID int) (api_key.ApiKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	key, ok := r.s.apiKeys.find(func(k api_key.ApiKey) bool { return k.ID == id && k.UserID == userID })
+	if !ok {
+		return api_key.ApiKey{}, gorm.ErrRecordNotFound
+	}
+	return *key, nil
+}
+
+func (r *apiKeyRepository) FindActiveByHash(ctx context.Context, keyHash string) (api_key.ApiKey, string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	now := time.Now()
+	key, ok := r.s.apiKeys.find(func(k api_key.ApiKey) bool {
+		return k.KeyHash == keyHash && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+	})
+	if !ok {
+		return api_key.ApiKey{}, "", gorm.ErrRecordNotFound
+	}
+
+	owner, ok := r.s.users.get(key.UserID)
+	if !ok {
+		return api_key.ApiKey{}, "", gorm.ErrRecordNotFound
+	}
+	return *key, owner.Username, nil
+}
+
+func (r *apiKeyRepository) CreateApiKey(ctx context.Context, p api_key.CreateApiKeyDTO) (api_key.ApiKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := api_key.ApiKey{
+		UserID:    p.UserID,
+		Name:      p.Name,
+		Prefix:    p.Prefix,
+		KeyHash:   p.KeyHash,
+		Scopes:    p.Scopes,
+		ExpiresAt: p.ExpiresAt,
+	}
+	r.s.apiKeys.insert(&data)
+	return data, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	//? UpdateColumn in the SQL version, so updated_at stays as is
+	if key, ok := r.s.apiKeys.get(id); ok {
+		key.LastUsedAt = &usedAt
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) DeleteApiKey(ctx context.Context, id int, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.apiKeys.delete(func(k api_key.ApiKey) bool { return k.ID == id && k.UserID == userID })
+	return nil
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/auth"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authRepository struct {
+	s *Store
+}
+
+func (r *authRepository) RegisterUser(ctx context.Context, payload user.User) (auth.RegisterResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.insert(&payload)
+	return auth.RegisterResponse{
+		ID:       payload.ID,
+		Username: payload.Username,
+		Email:    payload.Email,
+	}, nil
+}
+
+func (r *authRepository) LoginUser(ctx context.Context, payload auth.LoginUserRequest) (auth.LoginResponse, error) {
+	r.s.mu.RLock()
+	found, ok := r.s.users.find(func(u user.User) bool { return u.Email == payload.Email })
+	var data user.User
+	if ok {
+		data = *found
+	}
+	r.s.mu.RUnlock()
+
+	if !ok {
+		return auth.LoginResponse{}, utils.NewStatusError(http.StatusUnauthorized, "email not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(data.Password), []byte(payload.Password)); err != nil {
+		return auth.LoginResponse{}, utils.NewStatusError(http.StatusUnauthorized, "invalid username or password")
+	}
+
+	token, err := utils.GenerateJWT(data.ID, data.Username)
+	if err != nil {
+		return auth.LoginResponse{}, fmt.Errorf("error generating token")
+	}
+
+	return auth.LoginResponse{
+		ID:       data.ID,
+		Username: data.Username,
+		Email:    data.Email,
+		Token:    token,
+	}, nil
+}
+
+func (r *authRepository) CheckUniqueEmail(ctx context.Context, email string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, taken := r.s.users.find(func(u user.User) bool { return u.Email == email })
+	return !taken, nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+)
+
+type authorRepository struct {
+	s *Store
+}
+
+func (r *authorRepository) FindAll(ctx context.Context, params author.GetAllAuthorParams) ([]author.Author, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.authors.filter(func(a author.Author) bool {
+		return (params.Name == "" || contains(a.Name, params.Name)) &&
+			createdAt(a.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *authorRepository) FindById(ctx context.Context, id int) (author.Author, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.authors.get(id)
+	if !ok {
+		return author.Author{}, fmt.Errorf("author with id %d not found", id)
+	}
+	return *row, nil
+}
+
+func (r *authorRepository) CreateAuthor(ctx context.Context, p author.CreateAuthorDTO) (author.Author, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := author.Author{
+		Name:           p.Name,
+		AvatarUrl:      p.AvatarUrl,
+		AvatarFileName: p.AvatarFileName,
+	}
+	r.s.authors.insert(&data)
+	return data, nil
+}
+
+func (r *authorRepository) UpdateAuthor(ctx context.Context, p author.UpdateAuthorDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	//? Updates(&struct) in the SQL version skips zero fields
+	r.s.authors.update(func(a author.Author) bool { return a.ID == p.ID }, func(a *author.Author) {
+		if p.Name != "" {
+			a.Name = p.Name
+		}
+		if p.AvatarUrl != "" {
+			a.AvatarUrl = p.AvatarUrl
+		}
+		if p.AvatarFileName != "" {
+			a.AvatarFileName = p.AvatarFileName
+		}
+	})
+	return nil
+}
+
+func (r *authorRepository) DeleteAuthor(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.authors.deleteID(id)
+	return nil
+}
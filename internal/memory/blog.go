@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"gorm.io/gorm"
+)
+
+type blogRepository struct {
+	s *Store
+}
+
+func (r *blogRepository) FindAll(ctx context.Context, params blog.GetAllBlogParams) ([]blog.Blog, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	publishedAt, err := dateFilterPtr("published_at", params.PublishedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.blogs.filter(func(b blog.Blog) bool {
+		return (params.Title == "" || contains(b.Title, params.Title)) &&
+			(params.Slug == "" || b.Slug == params.Slug) &&
+			(params.Status == "" || b.Status == params.Status) &&
+			(params.AuthorID == 0 || b.AuthorID == params.AuthorID) &&
+			publishedAt(b.PublishedAt) &&
+			createdAt(b.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *blogRepository) FindByIdWithRelations(ctx context.Context, id int) ([]blog.RawBlogRelationResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	b, ok := r.s.blogs.get(id)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	base := blog.RawBlogRelationResponse{
+		ID:              b.ID,
+		Title:           b.Title,
+		DescriptionHTML: b.DescriptionHTML,
+		BannerUrl:       b.BannerUrl,
+		BannerFileName:  b.BannerFileName,
+		Summary:         b.Summary,
+		Status:          b.Status,
+		Slug:            b.Slug,
+		IsHighlight:     b.IsHighlight,
+		ReviewerID:      b.ReviewerID,
+		Version:         b.Version,
+		PublishedAt:     b.PublishedAt,
+		CreatedAt:       b.CreatedAt,
+	}
+	if a, ok := r.s.authors.get(b.AuthorID); ok {
+		base.AuthorID, base.AuthorName = a.ID, a.Name
+	}
+	if rt, ok := r.s.readingTimes.get(b.ReadingTimeID); ok {
+		base.ReadingTimeID = rt.ID
+		base.ReadingTimeMinutes = rt.Minutes
+		base.ReadingTimeTextLength = rt.TextLength
+		base.ReadingTimeEstimatedSeconds = rt.EstimatedSeconds
+		base.ReadingTimeWordCount = rt.WordCount
+		base.ReadingTimeType = rt.Type
+	}
+	if s, ok := r.s.statistics.get(b.StatisticID); ok {
+		base.StatisticID = s.ID
+		base.StatisticLikes = deref(s.Likes)
+		base.StatisticViews = deref(s.Views)
+		base.StatisticType = s.Type
+	}
+
+	//? one row per topic x content image, like the LEFT JOINs
+	var datas []blog.RawBlogRelationResponse
+	for _, t := range r.s.blogTopicsOf(b.ID) {
+		for _, img := range r.s.blogImagesOf(b.ID) {
+			row := base
+			row.TopicID, row.TopicName = t.ID, t.Name
+			row.BlogContentImageID = img.ID
+			row.BlogContentImageUrl = img.ImageUrl
+			row.BlogContentImageFileName = img.ImageFileName
+			datas = append(datas, row)
+		}
+	}
+	return datas, nil
+}
+
+func (r *blogRepository) FindById(ctx context.Context, id int) (blog.BlogResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	b, ok := r.s.blogs.get(id)
+	if !ok {
+		return blog.BlogResponse{}, gorm.ErrRecordNotFound
+	}
+	return blog.BlogResponse{
+		ID:              b.ID,
+		StatisticID:     b.StatisticID,
+		ReadingTimeID:   b.ReadingTimeID,
+		AuthorID:        b.AuthorID,
+		Title:           b.Title,
+		DescriptionHTML: b.DescriptionHTML,
+		BannerUrl:       b.BannerUrl,
+		BannerFileName:  b.BannerFileName,
+		Summary:         b.Summary,
+		Status:          b.Status,
+		Slug:            b.Slug,
+		IsHighlight:     b.IsHighlight,
+		ReviewerID:      b.ReviewerID,
+		Version:         b.Version,
+		PublishedAt:     formatDateTimePtr(b.PublishedAt),
+		CreatedAt:       formatDateTime(b.CreatedAt),
+	}, nil
+}
+
+func (r *blogRepository) CreateBlog(ctx context.Context, p blog.CreateBlogDTO, tx *gorm.DB) (blog.Blog, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := blog.Blog{
+		AuthorID:        p.AuthorID,
+		StatisticID:     p.StatisticID,
+		ReadingTimeID:   p.ReadingTimeID,
+		Title:           p.Title,
+		DescriptionHTML: p.DescriptionHTML,
+		BannerUrl:       p.BannerUrl,
+		BannerFileName:  p.BannerFileName,
+		Summary:         p.Summary,
+		Status:          p.Status,
+		Slug:            p.Slug,
+		PublishedAt:     p.PublishedAt,
+		IsHighlight:     false,
+	}
+	r.s.blogs.insert(&data)
+	return data, nil
+}
+
+func (r *blogRepository) UpdateBlog(ctx context.Context, p blog.UpdateBlogDTO, tx *gorm.DB) (blog.Blog, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	err := r.s.blogs.updateVersioned(p.ID, p.Version, func(b *blog.Blog) {
+		b.StatisticID = p.StatisticID
+		b.ReadingTimeID = p.ReadingTimeID
+		b.AuthorID = p.AuthorID
+		b.Title = p.Title
+		b.DescriptionHTML = p.DescriptionHTML
+		b.BannerUrl = p.BannerUrl
+		b.BannerFileName = p.BannerFileName
+		b.Summary = p.Summary
+		b.Slug = p.Slug
+		b.IsHighlight = p.IsHighlight == "Y"
+	})
+	if err != nil {
+		return blog.Blog{}, err
+	}
+
+	data := blog.Blog{
+		ID:              p.ID,
+		Version:         p.Version + 1,
+		StatisticID:     p.StatisticID,
+		ReadingTimeID:   p.ReadingTimeID,
+		AuthorID:        p.AuthorID,
+		Title:           p.Title,
+		DescriptionHTML: p.DescriptionHTML,
+		BannerUrl:       p.BannerUrl,
+		BannerFileName:  p.BannerFileName,
+		Summary:         p.Summary,
+		Status:          p.Status,
+		Slug:            p.Slug,
+		IsHighlight:     p.IsHighlight == "Y",
+		UpdatedAt:       time.Now(),
+	}
+	return data, nil
+}
+
+func (r *blogRepository) DeleteBlog(ctx context.Context, id int) (blog.Blog, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.blogs.get(id)
+	if !ok {
+		return blog.Blog{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.blogs.deleteID(id)
+	return data, nil
+}
+
+func (r *blogRepository) ChangeStatusBlog(ctx context.Context, id int, status string, data blog.BlogResponse, tx *gorm.DB) (blog.BlogChangeStatusResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	published := status == string(editorial.StatusPublished)
+	match := func(b blog.Blog) bool { return b.ID == id && b.Status == data.Status }
+	if _, ok := r.s.blogs.find(match); !ok {
+		return blog.BlogChangeStatusResponse{}, editorial.ErrStatusChanged(data.Status)
+	}
+	r.s.blogs.update(match, func(b *blog.Blog) {
+		b.Status = status
+		if published {
+			b.PublishedAt = &now
+		}
+	})
+
+	var publishedAt *string
+	if published {
+		publishedAt = formatDateTimePtr(&now)
+	}
+	return blog.BlogChangeStatusResponse{
+		ID:          id,
+		Title:       data.Title,
+		Status:      status,
+		PublishedAt: publishedAt,
+	}, nil
+}
+
+func (r *blogRepository) AssignReviewer(ctx context.Context, id int, reviewerID *int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.blogs.update(func(b blog.Blog) bool { return b.ID == id }, func(b *blog.Blog) {
+		b.ReviewerID = reviewerID
+	})
+	return nil
+}
+
+func (r *blogRepository) CheckUniqueSlug(ctx context.Context, slug string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, taken := r.s.blogs.find(func(b blog.Blog) bool { return b.Slug == slug })
+	return !taken, nil
+}
+
+// blogTopicsOf returns the topics linked to a blog, or one empty topic when
+// there are none, which is what the LEFT JOIN yields.
+func (s *Store) blogTopicsOf(blogID int) []topic.Topic {
+	var topics []topic.Topic
+	for _, bt := range s.blogTopics.filter(func(bt blog_topic.BlogTopic) bool { return bt.BlogID == blogID }) {
+		t, _ := s.topics.get(bt.TopicID)
+		if t == nil {
+			t = &topic.Topic{}
+		}
+		topics = append(topics, *t)
+	}
+	if len(topics) == 0 {
+		return []topic.Topic{{}}
+	}
+	return topics
+}
+
+// blogImagesOf is blogTopicsOf for content images.
+func (s *Store) blogImagesOf(blogID int) []blog_content_image.BlogContentImage {
+	images := s.blogContentImages.filter(func(img blog_content_image.BlogContentImage) bool {
+		return img.BlogID != nil && *img.BlogID == blogID
+	})
+	if len(images) == 0 {
+		return []blog_content_image.BlogContentImage{{}}
+	}
+	return images
+}
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
+	"gorm.io/gorm"
+)
+
+type blogContentImageRepository struct {
+	s *Store
+}
+
+func (r *blogContentImageRepository) FindAll(ctx context.Context) ([]blog_content_image.BlogContentImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.blogContentImages.filter(func(blog_content_image.BlogContentImage) bool { return true }), nil
+}
+
+func (r *blogContentImageRepository) FindUnlinked(ctx context.Context, before time.Time) ([]blog_content_image.BlogContentImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.blogContentImages.filter(func(img blog_content_image.BlogContentImage) bool {
+		return img.BlogID == nil && img.CreatedAt.Before(before)
+	}), nil
+}
+
+func (r *blogContentImageRepository) FindById(ctx context.Context, id int) (blog_content_image.BlogContentImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.blogContentImages.get(id)
+	if !ok {
+		return blog_content_image.BlogContentImage{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *blogContentImageRepository) CreateBlogContentImage(ctx context.Context, p blog_content_image.CreateBlogContentImageDTO) (blog_content_image.BlogContentImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := blog_content_image.BlogContentImage{
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+	}
+	r.s.blogContentImages.insert(&data)
+	return data, nil
+}
+
+func (r *blogContentImageRepository) UpdateBlogContentImage(ctx context.Context, p blog_content_image.UpdateBlogContentImageDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.blogContentImages.update(func(img blog_content_image.BlogContentImage) bool { return img.ID == p.ID }, func(img *blog_content_image.BlogContentImage) {
+		if p.BlogID != nil {
+			img.BlogID = p.BlogID
+		}
+		if p.ImageUrl != "" {
+			img.ImageUrl = p.ImageUrl
+		}
+		if p.ImageFileName != "" {
+			img.ImageFileName = p.ImageFileName
+		}
+	})
+	return nil
+}
+
+func (r *blogContentImageRepository) DeleteBlogContentImage(ctx context.Context, id int) (blog_content_image.BlogContentImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.blogContentImages.get(id)
+	if !ok {
+		return blog_content_image.BlogContentImage{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.blogContentImages.deleteID(id)
+	return data, nil
+}
+
+func (r *blogContentImageRepository) CountUnlinkedImages(ctx context.Context, image_urls []string) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.blogContentImages.count(func(img blog_content_image.BlogContentImage) bool {
+		return inStrings(image_urls, img.ImageUrl) && img.BlogID == nil
+	}), nil
+}
+
+func (r *blogContentImageRepository) MarkImagesUsedByBlog(ctx context.Context, p blog_content_image.BlogContentImageBulkUpdateDTO, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.blogContentImages.update(func(img blog_content_image.BlogContentImage) bool { return inStrings(p.ImageUrls, img.ImageUrl) }, func(img *blog_content_image.BlogContentImage) {
+		img.BlogID = ptr(p.BlogID)
+	})
+	return nil
+}
+
+func (r *blogContentImageRepository) CountImagesLinkedToBlog(ctx context.Context, image_urls []string, blog_id int) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.blogContentImages.count(func(img blog_content_image.BlogContentImage) bool {
+		return inStrings(image_urls, img.ImageUrl) && deref(img.BlogID) == blog_id
+	}), nil
+}
+
+func (r *blogContentImageRepository) FindImageExist(ctx context.Context, image_urls []string, blog_id int) ([]blog_content_image.BlogContentImageExistingResponse, error) {
+	return r.existing(func(img blog_content_image.BlogContentImage) bool {
+		return inStrings(image_urls, img.ImageUrl) && (img.BlogID == nil || *img.BlogID == blog_id)
+	}), nil
+}
+
+func (r *blogContentImageRepository) FindImageNotExist(ctx context.Context, image_urls []string, blog_id int) ([]blog_content_image.BlogContentImageExistingResponse, error) {
+	return r.existing(func(img blog_content_image.BlogContentImage) bool {
+		return deref(img.BlogID) == blog_id && !inStrings(image_urls, img.ImageUrl)
+	}), nil
+}
+
+func (r *blogContentImageRepository) existing(match func(blog_content_image.BlogContentImage) bool) []blog_content_image.BlogContentImageExistingResponse {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	datas := []blog_content_image.BlogContentImageExistingResponse{}
+	for _, img := range r.s.blogContentImages.filter(match) {
+		datas = append(datas, blog_content_image.BlogContentImageExistingResponse{
+			ID:       img.ID,
+			BlogID:   img.BlogID,
+			ImageUrl: img.ImageUrl,
+		})
+	}
+	return datas
+}
+
+func (r *blogContentImageRepository) BatchUpdateImagesById(ctx context.Context, ids []int, blog_id int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.blogContentImages.update(func(img blog_content_image.BlogContentImage) bool { return inInts(ids, img.ID) }, func(img *blog_content_image.BlogContentImage) {
+		img.BlogID = ptr(blog_id)
+	})
+	return nil
+}
+
+func (r *blogContentImageRepository) BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.blogContentImages.delete(func(img blog_content_image.BlogContentImage) bool { return inStrings(image_urls, img.ImageUrl) })
+	return nil
+}
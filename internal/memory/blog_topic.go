@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"gorm.io/gorm"
+)
+
+type blogTopicRepository struct {
+	s *Store
+}
+
+func (r *blogTopicRepository) FindAll(ctx context.Context) ([]blog_topic.BlogTopic, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.blogTopics.filter(func(blog_topic.BlogTopic) bool { return true }), nil
+}
+
+func (r *blogTopicRepository) FindById(ctx context.Context, id int) (blog_topic.BlogTopic, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.blogTopics.get(id)
+	if !ok {
+		return blog_topic.BlogTopic{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *blogTopicRepository) CreateBlogTopic(ctx context.Context, p blog_topic.CreateBlogTopicRequest) (blog_topic.BlogTopic, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := blog_topic.BlogTopic{BlogID: p.BlogID, TopicID: p.TopicID}
+	r.s.blogTopics.insert(&data)
+	return data, nil
+}
+
+func (r *blogTopicRepository) BulkCreateBlogTopic(ctx context.Context, topic_ids []int, blog_id int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, topic_id := range topic_ids {
+		data := blog_topic.BlogTopic{BlogID: blog_id, TopicID: topic_id}
+		r.s.blogTopics.insert(&data)
+	}
+	return nil
+}
+
+func (r *blogTopicRepository) UpdateBlogTopic(ctx context.Context, p blog_topic.UpdateBlogTopicRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.blogTopics.update(func(bt blog_topic.BlogTopic) bool { return bt.ID == p.ID }, func(bt *blog_topic.BlogTopic) {
+		if p.BlogID != 0 {
+			bt.BlogID = p.BlogID
+		}
+		if p.TopicID != 0 {
+			bt.TopicID = p.TopicID
+		}
+	})
+	return nil
+}
+
+func (r *blogTopicRepository) DeleteBlogTopic(ctx context.Context, id int) (blog_topic.BlogTopic, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.blogTopics.get(id)
+	if !ok {
+		return blog_topic.BlogTopic{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.blogTopics.deleteID(id)
+	return data, nil
+}
+
+func (r *blogTopicRepository) BulkDeleteHard(ctx context.Context, topic_ids []int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	//? matches the SQL version, which is not scoped to one blog
+	r.s.blogTopics.delete(func(bt blog_topic.BlogTopic) bool { return inInts(topic_ids, bt.TopicID) })
+	return nil
+}
+
+func (r *blogTopicRepository) FindExistingBlogTopics(ctx context.Context, blog_id int) ([]blog_topic.BlogTopicExistingResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	datas := []blog_topic.BlogTopicExistingResponse{}
+	for _, bt := range r.s.blogTopics.filter(func(bt blog_topic.BlogTopic) bool { return bt.BlogID == blog_id }) {
+		datas = append(datas, blog_topic.BlogTopicExistingResponse{ID: bt.ID, BlogID: bt.BlogID, TopicID: bt.TopicID})
+	}
+	return datas, nil
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"gorm.io/gorm"
+)
+
+type editorialRepository struct {
+	s *Store
+}
+
+func (r *editorialRepository) CreateEvent(ctx context.Context, p editorial.RecordEventDTO, tx *gorm.DB) (editorial.Event, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := editorial.Event{
+		ContentType: p.ContentType,
+		ContentID:   p.ContentID,
+		UserID:      p.UserID,
+		Action:      p.Action,
+		FromStatus:  string(p.FromStatus),
+		ToStatus:    string(p.ToStatus),
+		ReviewerID:  p.ReviewerID,
+		Comment:     p.Comment,
+	}
+	r.s.editorialEvents.insert(&data)
+	return data, nil
+}
+
+func (r *editorialRepository) FindByContent(ctx context.Context, contentType string, contentID int) ([]editorial.RawEventResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	//? rows are appended in creation order, which is created_at ASC, id ASC
+	datas := []editorial.RawEventResponse{}
+	for _, e := range r.s.editorialEvents.filter(func(e editorial.Event) bool {
+		return e.ContentType == contentType && e.ContentID == contentID
+	}) {
+		raw := editorial.RawEventResponse{Event: e}
+		if u, ok := r.s.users.get(e.UserID); ok {
+			raw.Username = u.Username
+		}
+		datas = append(datas, raw)
+	}
+	return datas, nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"gorm.io/gorm"
+)
+
+type experienceRepository struct {
+	s *Store
+}
+
+func (r *experienceRepository) FindAll(ctx context.Context, params experience.GetAllExperienceParams) ([]experience.Experience, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	fromDate, err := dateFilter("from_date", params.FromDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	toDate, err := dateFilterPtr("to_date", params.ToDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.experiences.filter(func(e experience.Experience) bool {
+		return (params.Position == "" || contains(e.Position, params.Position)) &&
+			(params.CompanyName == "" || contains(e.CompanyName, params.CompanyName)) &&
+			(params.WorkType == "" || contains(e.WorkType, params.WorkType)) &&
+			(params.Country == "" || contains(e.Country, params.Country)) &&
+			(params.City == "" || containsPtr(e.City, params.City)) &&
+			(params.SummaryHTML == "" || contains(e.SummaryHTML, params.SummaryHTML)) &&
+			(params.IsCurrent == "" || e.IsCurrent == (params.IsCurrent == "Y")) &&
+			fromDate(e.FromDate) &&
+			toDate(e.ToDate) &&
+			createdAt(e.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *experienceRepository) FindById(ctx context.Context, id int) (experience.Experience, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.experiences.get(id)
+	if !ok {
+		return experience.Experience{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *experienceRepository) CreateExperience(ctx context.Context, p experience.CreateExperienceDTO) (experience.Experience, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := experience.Experience{
+		Position:          p.Position,
+		CompanyName:       p.CompanyName,
+		WorkType:          p.WorkType,
+		Country:           p.Country,
+		City:              p.City,
+		SummaryHTML:       p.SummaryHTML,
+		FromDate:          p.FromDate,
+		ToDate:            p.ToDate,
+		CompImageUrl:      p.CompImageUrl,
+		CompImageFileName: p.CompImageFileName,
+		CompWebsiteUrl:    p.CompWebsiteUrl,
+		IsCurrent:         p.IsCurrent,
+	}
+	r.s.experiences.insert(&data)
+	return data, nil
+}
+
+func (r *experienceRepository) UpdateExperience(ctx context.Context, p experience.UpdateExperienceDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.experiences.updateVersioned(p.ID, p.Version, func(e *experience.Experience) {
+		e.Position = p.Position
+		e.CompanyName = p.CompanyName
+		e.WorkType = p.WorkType
+		e.Country = p.Country
+		e.City = p.City
+		e.SummaryHTML = p.SummaryHTML
+		e.FromDate = p.FromDate
+		e.ToDate = p.ToDate
+		e.CompImageUrl = p.CompImageUrl
+		e.CompImageFileName = p.CompImageFileName
+		e.CompWebsiteUrl = p.CompWebsiteUrl
+		e.IsCurrent = p.IsCurrent
+	})
+}
+
+func (r *experienceRepository) DeleteExperience(ctx context.Context, id int) (experience.Experience, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.experiences.get(id)
+	if !ok {
+		return experience.Experience{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.experiences.deleteID(id)
+	return data, nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/idempotency"
+	"gorm.io/gorm"
+)
+
+type idempotencyRepository struct {
+	s *Store
+}
+
+func (r *idempotencyRepository) FindByKey(ctx context.Context, scope, key string) (idempotency.IdempotencyKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	data, ok := r.s.idempotencyKeys.find(func(k idempotency.IdempotencyKey) bool { return k.Scope == scope && k.Key == key })
+	if !ok {
+		return idempotency.IdempotencyKey{}, gorm.ErrRecordNotFound
+	}
+	return *data, nil
+}
+
+func (r *idempotencyRepository) CreateKey(ctx context.Context, p idempotency.CreateKeyDTO) (idempotency.IdempotencyKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	//? the unique index on scope and key in the SQL version
+	if _, ok := r.s.idempotencyKeys.find(func(k idempotency.IdempotencyKey) bool { return k.Scope == p.Scope && k.Key == p.Key }); ok {
+		return idempotency.IdempotencyKey{}, gorm.ErrDuplicatedKey
+	}
+
+	data := idempotency.IdempotencyKey{
+		Scope:       p.Scope,
+		Key:         p.Key,
+		RequestHash: p.RequestHash,
+		ExpiresAt:   p.ExpiresAt,
+	}
+	r.s.idempotencyKeys.insert(&data)
+	return data, nil
+}
+
+func (r *idempotencyRepository) CompleteKey(ctx context.Context, p idempotency.CompleteKeyDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.idempotencyKeys.update(func(k idempotency.IdempotencyKey) bool { return k.ID == p.ID }, func(k *idempotency.IdempotencyKey) {
+		k.ResponseStatus = p.ResponseStatus
+		k.ResponseContentType = p.ResponseContentType
+		k.ResponseHeaders = p.ResponseHeaders
+		k.ResponseBody = p.ResponseBody
+	})
+	return nil
+}
+
+func (r *idempotencyRepository) ReleaseKey(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.idempotencyKeys.delete(func(k idempotency.IdempotencyKey) bool { return k.ID == id && k.InProgress() })
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	expired := r.s.idempotencyKeys.count(func(k idempotency.IdempotencyKey) bool { return !k.ExpiresAt.After(now) })
+	r.s.idempotencyKeys.delete(func(k idempotency.IdempotencyKey) bool { return !k.ExpiresAt.After(now) })
+	return int64(expired), nil
+}
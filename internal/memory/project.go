@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"gorm.io/gorm"
+)
+
+type projectRepository struct {
+	s *Store
+}
+
+func (r *projectRepository) FindAll(ctx context.Context, params project.GetAllProjectParams) ([]project.Project, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	publishedAt, err := dateFilterPtr("published_at", params.PublishedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.projects.filter(func(p project.Project) bool {
+		return (params.Title == "" || contains(p.Title, params.Title)) &&
+			(params.Slug == "" || p.Slug == params.Slug) &&
+			(params.Status == "" || p.Status == params.Status) &&
+			publishedAt(p.PublishedAt) &&
+			createdAt(p.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *projectRepository) FindByIdWithRelations(ctx context.Context, id int) ([]project.RawProjectRelationResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.projects.get(id)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	base := project.RawProjectRelationResponse{
+		ID:            p.ID,
+		Title:         p.Title,
+		Slug:          p.Slug,
+		IsHighlight:   p.IsHighlight,
+		OwnerID:       p.OwnerID,
+		ReviewerID:    p.ReviewerID,
+		Version:       p.Version,
+		Description:   p.Description,
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+		RepositoryUrl: p.RepositoryUrl,
+		Summary:       p.Summary,
+		Status:        p.Status,
+		PublishedAt:   p.PublishedAt,
+		CreatedAt:     p.CreatedAt,
+	}
+	if s, ok := r.s.statistics.get(p.StatisticID); ok {
+		base.StatisticID = s.ID
+		base.StatisticLikes = deref(s.Likes)
+		base.StatisticViews = deref(s.Views)
+		base.StatisticType = s.Type
+	}
+
+	//? one row per technology x content image, like the LEFT JOINs
+	var datas []project.RawProjectRelationResponse
+	for _, tech := range r.s.projectTechnologiesOf(p.ID) {
+		for _, img := range r.s.projectImagesOf(p.ID) {
+			row := base
+			row.ProjectTechnologyID = tech.link.ID
+			row.TechnologyID, row.TechnologyName = tech.ID, tech.Name
+			row.ProjectImgID = img.ID
+			row.ProjectImgFileName = img.ImageFileName
+			row.ProjectImgUrl = img.ImageUrl
+			datas = append(datas, row)
+		}
+	}
+	return datas, nil
+}
+
+func (r *projectRepository) FindById(ctx context.Context, id int) (project.ProjectResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.projects.get(id)
+	if !ok {
+		return project.ProjectResponse{}, gorm.ErrRecordNotFound
+	}
+	return project.ProjectResponse{
+		ID:            p.ID,
+		Title:         p.Title,
+		Description:   p.Description,
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+		RepositoryUrl: p.RepositoryUrl,
+		Summary:       p.Summary,
+		Status:        p.Status,
+		Slug:          p.Slug,
+		IsHighlight:   p.IsHighlight,
+		OwnerID:       p.OwnerID,
+		ReviewerID:    p.ReviewerID,
+		Version:       p.Version,
+		PublishedAt:   formatDateTimePtr(p.PublishedAt),
+		CreatedAt:     formatDateTime(p.CreatedAt),
+	}, nil
+}
+
+func (r *projectRepository) CreateProject(ctx context.Context, p project.CreateProjectDTO, tx *gorm.DB) (project.Project, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := project.Project{
+		StatisticID:   p.StatisticID,
+		Title:         p.Title,
+		Description:   p.Description,
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+		RepositoryUrl: p.RepositoryUrl,
+		Summary:       p.Summary,
+		Status:        p.Status,
+		Slug:          p.Slug,
+		OwnerID:       p.OwnerID,
+		PublishedAt:   p.PublishedAt,
+	}
+	r.s.projects.insert(&data)
+	return data, nil
+}
+
+func (r *projectRepository) UpdateProject(ctx context.Context, p project.UpdateProjectDTO, tx *gorm.DB) (project.Project, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	err := r.s.projects.updateVersioned(p.Id, p.Version, func(row *project.Project) {
+		row.Title = p.Title
+		row.Description = p.Description
+		row.ImageUrl = p.ImageUrl
+		row.ImageFileName = p.ImageFileName
+		row.RepositoryUrl = p.RepositoryUrl
+		row.Summary = p.Summary
+		row.Slug = p.Slug
+		row.IsHighlight = p.IsHighlight == "Y"
+	})
+	if err != nil {
+		return project.Project{}, err
+	}
+
+	data := project.Project{
+		ID:            p.Id,
+		Version:       p.Version + 1,
+		Title:         p.Title,
+		Description:   p.Description,
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+		RepositoryUrl: p.RepositoryUrl,
+		Summary:       p.Summary,
+		Status:        p.Status,
+		Slug:          p.Slug,
+		IsHighlight:   p.IsHighlight == "Y",
+		UpdatedAt:     time.Now(),
+	}
+	return data, nil
+}
+
+func (r *projectRepository) UpdateProjectStatistic(ctx context.Context, p project.ProjectStatisticUpdateDTO) (project.ProjectStatisticUpdateResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateStatistic(p.StatisticID, p.Likes, p.Views, p.Type)
+	return project.ProjectStatisticUpdateResponse{
+		ProjectID:    p.ProjectID,
+		ProjectTitle: p.ProjectTitle,
+		StatisticID:  p.StatisticID,
+		Likes:        deref(p.Likes),
+		Views:        deref(p.Views),
+		Type:         p.Type,
+	}, nil
+}
+
+func (r *projectRepository) DeleteProject(ctx context.Context, id int) (project.Project, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.projects.get(id)
+	if !ok {
+		return project.Project{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.projects.deleteID(id)
+	return data, nil
+}
+
+func (r *projectRepository) CheckUniqueSlug(ctx context.Context, slug string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, taken := r.s.projects.find(func(p project.Project) bool { return p.Slug == slug })
+	return !taken, nil
+}
+
+func (r *projectRepository) ChangeStatusProject(ctx context.Context, id int, status string, data project.ProjectResponse, tx *gorm.DB) (project.ProjectChangeStatusResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	published := status == string(editorial.StatusPublished)
+	match := func(p project.Project) bool { return p.ID == id && p.Status == data.Status }
+	if _, ok := r.s.projects.find(match); !ok {
+		return project.ProjectChangeStatusResponse{}, editorial.ErrStatusChanged(data.Status)
+	}
+	r.s.projects.update(match, func(p *project.Project) {
+		p.Status = status
+		if published {
+			p.PublishedAt = &now
+		}
+	})
+
+	var publishedAt *string
+	if published {
+		publishedAt = formatDateTimePtr(&now)
+	}
+	return project.ProjectChangeStatusResponse{
+		ID:          id,
+		Title:       data.Title,
+		Status:      status,
+		PublishedAt: publishedAt,
+	}, nil
+}
+
+func (r *projectRepository) AssignReviewer(ctx context.Context, id int, reviewerID *int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projects.update(func(p project.Project) bool { return p.ID == id }, func(p *project.Project) {
+		p.ReviewerID = reviewerID
+	})
+	return nil
+}
+
+// linkedTechnology is a technology joined through one project_technologies row.
+type linkedTechnology struct {
+	technology.Technology
+	link project_technology.ProjectTechnology
+}
+
+// projectTechnologiesOf returns the technologies linked to a project, or one
+// empty entry when there are none, which is what the LEFT JOIN yields.
+func (s *Store) projectTechnologiesOf(projectID int) []linkedTechnology {
+	var techs []linkedTechnology
+	for _, pt := range s.projectTechnologies.filter(func(pt project_technology.ProjectTechnology) bool { return pt.ProjectID == projectID }) {
+		tech := linkedTechnology{link: pt}
+		if t, ok := s.technologies.get(pt.TechnologyID); ok {
+			tech.Technology = *t
+		}
+		techs = append(techs, tech)
+	}
+	if len(techs) == 0 {
+		return []linkedTechnology{{}}
+	}
+	return techs
+}
+
+// projectImagesOf is projectTechnologiesOf for content images.
+func (s *Store) projectImagesOf(projectID int) []project_content_image.ProjectContentImage {
+	images := s.projectContentImages.filter(func(img project_content_image.ProjectContentImage) bool {
+		return img.ProjectID != nil && *img.ProjectID == projectID
+	})
+	if len(images) == 0 {
+		return []project_content_image.ProjectContentImage{{}}
+	}
+	return images
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
+	"gorm.io/gorm"
+)
+
+type projectContentImageRepository struct {
+	s *Store
+}
+
+func (r *projectContentImageRepository) FindAll(ctx context.Context) ([]project_content_image.ProjectContentImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.projectContentImages.filter(func(project_content_image.ProjectContentImage) bool { return true }), nil
+}
+
+func (r *projectContentImageRepository) FindUnlinked(ctx context.Context, before time.Time) ([]project_content_image.ProjectContentImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.projectContentImages.filter(func(img project_content_image.ProjectContentImage) bool {
+		return img.ProjectID == nil && img.CreatedAt.Before(before)
+	}), nil
+}
+
+func (r *projectContentImageRepository) FindById(ctx context.Context, id int) (project_content_image.ProjectContentImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.projectContentImages.get(id)
+	if !ok {
+		return project_content_image.ProjectContentImage{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *projectContentImageRepository) CreateProjectContentImage(ctx context.Context, p project_content_image.CreateProjectContentImageDTO) (project_content_image.ProjectContentImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := project_content_image.ProjectContentImage{
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+	}
+	r.s.projectContentImages.insert(&data)
+	return data, nil
+}
+
+func (r *projectContentImageRepository) UpdateProjectContentImage(ctx context.Context, p project_content_image.UpdateProjectContentImageDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projectContentImages.update(func(img project_content_image.ProjectContentImage) bool { return img.ID == p.ID }, func(img *project_content_image.ProjectContentImage) {
+		if p.ProjectID != nil {
+			img.ProjectID = p.ProjectID
+		}
+		if p.ImageUrl != "" {
+			img.ImageUrl = p.ImageUrl
+		}
+		if p.ImageFileName != "" {
+			img.ImageFileName = p.ImageFileName
+		}
+	})
+	return nil
+}
+
+func (r *projectContentImageRepository) DeleteProjectContentImage(ctx context.Context, id int) (project_content_image.ProjectContentImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.projectContentImages.get(id)
+	if !ok {
+		return project_content_image.ProjectContentImage{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.projectContentImages.deleteID(id)
+	return data, nil
+}
+
+func (r *projectContentImageRepository) CountUnusedProjectImages(ctx context.Context, image_urls []string) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.projectContentImages.count(func(img project_content_image.ProjectContentImage) bool {
+		return inStrings(image_urls, img.ImageUrl) && img.ProjectID == nil
+	}), nil
+}
+
+func (r *projectContentImageRepository) BatchUpdateProjectImages(ctx context.Context, projectImages []string, project_id int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projectContentImages.update(func(img project_content_image.ProjectContentImage) bool {
+		return inStrings(projectImages, img.ImageUrl)
+	}, func(img *project_content_image.ProjectContentImage) {
+		img.ProjectID = ptr(project_id)
+	})
+	return nil
+}
+
+func (r *projectContentImageRepository) FindImageExist(ctx context.Context, image_urls []string, project_id int) ([]project_content_image.ProjectImagesFindResponse, error) {
+	return r.existing(func(img project_content_image.ProjectContentImage) bool {
+		return inStrings(image_urls, img.ImageUrl) && (img.ProjectID == nil || *img.ProjectID == project_id)
+	}), nil
+}
+
+func (r *projectContentImageRepository) FindImageNotExist(ctx context.Context, image_urls []string, project_id int) ([]project_content_image.ProjectImagesFindResponse, error) {
+	return r.existing(func(img project_content_image.ProjectContentImage) bool {
+		return deref(img.ProjectID) == project_id && !inStrings(image_urls, img.ImageUrl)
+	}), nil
+}
+
+func (r *projectContentImageRepository) existing(match func(project_content_image.ProjectContentImage) bool) []project_content_image.ProjectImagesFindResponse {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	datas := []project_content_image.ProjectImagesFindResponse{}
+	for _, img := range r.s.projectContentImages.filter(match) {
+		datas = append(datas, project_content_image.ProjectImagesFindResponse{
+			ID:        img.ID,
+			ProjectID: img.ProjectID,
+			ImageUrl:  img.ImageUrl,
+		})
+	}
+	return datas
+}
+
+func (r *projectContentImageRepository) BatchUpdateImagesById(ctx context.Context, ids []int, project_id int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projectContentImages.update(func(img project_content_image.ProjectContentImage) bool { return inInts(ids, img.ID) }, func(img *project_content_image.ProjectContentImage) {
+		img.ProjectID = ptr(project_id)
+	})
+	return nil
+}
+
+func (r *projectContentImageRepository) BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projectContentImages.delete(func(img project_content_image.ProjectContentImage) bool { return inStrings(image_urls, img.ImageUrl) })
+	return nil
+}
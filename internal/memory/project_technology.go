@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"gorm.io/gorm"
+)
+
+type projectTechnologyRepository struct {
+	s *Store
+}
+
+func (r *projectTechnologyRepository) FindAll(ctx context.Context) ([]project_technology.ProjectTechnology, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.projectTechnologies.filter(func(project_technology.ProjectTechnology) bool { return true }), nil
+}
+
+func (r *projectTechnologyRepository) FindById(ctx context.Context, id int) (project_technology.ProjectTechnology, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.projectTechnologies.get(id)
+	if !ok {
+		return project_technology.ProjectTechnology{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *projectTechnologyRepository) CreateProjectTechnology(ctx context.Context, p project_technology.CreateProjectTechnologyRequest) (project_technology.ProjectTechnology, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := project_technology.ProjectTechnology{ProjectID: p.ProjectID, TechnologyID: p.TechnologyID}
+	r.s.projectTechnologies.insert(&data)
+	return data, nil
+}
+
+func (r *projectTechnologyRepository) UpdateProjectTechnology(ctx context.Context, p project_technology.UpdateProjectTechnologyRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projectTechnologies.update(func(pt project_technology.ProjectTechnology) bool { return pt.ID == p.ID }, func(pt *project_technology.ProjectTechnology) {
+		if p.ProjectID != 0 {
+			pt.ProjectID = p.ProjectID
+		}
+		if p.TechnologyID != 0 {
+			pt.TechnologyID = p.TechnologyID
+		}
+	})
+	return nil
+}
+
+func (r *projectTechnologyRepository) DeleteProjectTechnology(ctx context.Context, id int) (project_technology.ProjectTechnology, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.projectTechnologies.get(id)
+	if !ok {
+		return project_technology.ProjectTechnology{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.projectTechnologies.deleteID(id)
+	return data, nil
+}
+
+func (r *projectTechnologyRepository) CountTechnologiesByIDs(ctx context.Context, ids []int) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.technologies.count(func(t technology.Technology) bool { return inInts(ids, t.ID) }), nil
+}
+
+func (r *projectTechnologyRepository) BulkCreateTechnologies(ctx context.Context, tech_ids []project_technology.ProjectTechnology, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i := range tech_ids {
+		r.s.projectTechnologies.insert(&tech_ids[i])
+	}
+	return nil
+}
+
+func (r *projectTechnologyRepository) FindExistingProjectTechnologies(ctx context.Context, project_id int) ([]project_technology.ProjectTechnologyExistingResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	datas := []project_technology.ProjectTechnologyExistingResponse{}
+	for _, pt := range r.s.projectTechnologies.filter(func(pt project_technology.ProjectTechnology) bool { return pt.ProjectID == project_id }) {
+		datas = append(datas, project_technology.ProjectTechnologyExistingResponse{ID: pt.ID, ProjectID: pt.ProjectID, TechnologyID: pt.TechnologyID})
+	}
+	return datas, nil
+}
+
+func (r *projectTechnologyRepository) BulkHardDeleteTechnology(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.projectTechnologies.delete(func(pt project_technology.ProjectTechnology) bool {
+		return inInts(tech_ids, pt.TechnologyID) && pt.ProjectID == project_id
+	})
+	return nil
+}
@@ -0,0 +1,565 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/public"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"gorm.io/gorm"
+)
+
+type publicRepository struct {
+	s *Store
+}
+
+// publicBlog carries the joined statistic views so blogs can be ordered by
+// "views" like the SQL ORDER BY s.views.
+type publicBlog struct {
+	blog.Blog
+	Views int
+}
+
+// sqlBool renders a boolean the way a MySQL TINYINT scans into a string.
+func sqlBool(b bool) string {
+	return strconv.Itoa(boolInt(b))
+}
+
+func (r *publicRepository) GetTechnologiesPublic(ctx context.Context) ([]public.TechnologyProfilePublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var datas []public.TechnologyProfilePublicResponse
+	for _, t := range r.s.technologies.filter(func(t technology.Technology) bool { return t.IsMajor }) {
+		datas = append(datas, public.TechnologyProfilePublicResponse{
+			ID:           t.ID,
+			Name:         t.Name,
+			LogoUrl:      t.LogoUrl,
+			LogoFileName: t.LogoFileName,
+		})
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetAboutPublic(ctx context.Context) (public.AboutPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	a, ok := r.s.abouts.find(func(a about.About) bool { return a.IsUsed })
+	if !ok {
+		return public.AboutPublicResponse{}, gorm.ErrRecordNotFound
+	}
+	return public.AboutPublicResponse{
+		ID:              a.ID,
+		Title:           a.Title,
+		DescriptionHTML: a.DescriptionHTML,
+		AvatarUrl:       a.AvatarUrl,
+		AvatarFileName:  a.AvatarFileName,
+	}, nil
+}
+
+func (r *publicRepository) GetCurrentWork(ctx context.Context) (public.CurrentWorkPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	e, ok := r.s.experiences.find(func(e experience.Experience) bool { return e.IsCurrent })
+	if !ok {
+		return public.CurrentWorkPublicResponse{}, nil
+	}
+	return public.CurrentWorkPublicResponse{
+		Position:       e.Position,
+		CompanyName:    e.CompanyName,
+		WorkType:       e.WorkType,
+		Country:        e.Country,
+		City:           deref(e.City),
+		CompWebsiteUrl: e.CompWebsiteUrl,
+	}, nil
+}
+
+func (r *publicRepository) GetExperiencesPublic(ctx context.Context) ([]public.ExperiencesPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.experiences.filter(func(experience.Experience) bool { return true })
+	if err := sortRows(rows, "from_date", "DESC"); err != nil {
+		return nil, err
+	}
+
+	var datas []public.ExperiencesPublicResponse
+	for _, e := range rows {
+		var toDate string
+		if e.ToDate != nil {
+			toDate = formatDateTime(*e.ToDate)
+		}
+		datas = append(datas, public.ExperiencesPublicResponse{
+			Position:          e.Position,
+			CompanyName:       e.CompanyName,
+			WorkType:          e.WorkType,
+			Country:           e.Country,
+			City:              deref(e.City),
+			CompWebsiteUrl:    e.CompWebsiteUrl,
+			SummaryHTML:       e.SummaryHTML,
+			FromDate:          formatDateTime(e.FromDate),
+			ToDate:            toDate,
+			CompImageUrl:      e.CompImageUrl,
+			CompImageFileName: e.CompImageFileName,
+			IsCurrent:         sqlBool(e.IsCurrent),
+		})
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetRawPublicPaginateBlogs(ctx context.Context, params public.BlogPublicParams) ([]public.BlogPaginatePublicRaw, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.publicBlogs(func(b blog.Blog) bool {
+		return b.Status == string(editorial.StatusPublished) &&
+			(params.Search == "" || contains(b.Title, params.Search) || contains(b.Summary, params.Search)) &&
+			(!params.HighlightOnly() || b.IsHighlight)
+	})
+	page, total, err := paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datas := []public.BlogPaginatePublicRaw{}
+	for _, b := range page {
+		raw := public.BlogPaginatePublicRaw{ID: b.ID, Title: b.Title}
+		if s, ok := r.s.statistics.get(b.StatisticID); ok {
+			raw.StatisticID = s.ID
+			raw.StatisticLikes = deref(s.Likes)
+			raw.StatisticViews = deref(s.Views)
+			raw.StatisticType = s.Type
+		}
+		datas = append(datas, raw)
+	}
+	return datas, total, nil
+}
+
+func (r *publicRepository) GetRawPublicBlogs(ctx context.Context, params public.BlogPublicParams, uniquePaginateBlogIDs []int) ([]public.BlogPublicRaw, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.publicBlogs(func(b blog.Blog) bool {
+		return len(uniquePaginateBlogIDs) == 0 || inInts(uniquePaginateBlogIDs, b.ID)
+	})
+	if err := sortRows(rows, params.Order, params.Sort); err != nil {
+		return nil, err
+	}
+
+	datas := []public.BlogPublicRaw{}
+	for _, b := range rows {
+		base := public.BlogPublicRaw{
+			ID:              b.ID,
+			Title:           b.Title,
+			BannerUrl:       b.BannerUrl,
+			BannerFileName:  b.BannerFileName,
+			Summary:         b.Summary,
+			DescriptionHTML: b.DescriptionHTML,
+			Status:          b.Status,
+			Slug:            b.Slug,
+			IsHighlight:     b.IsHighlight,
+			PublishedAt:     b.PublishedAt,
+		}
+		if a, ok := r.s.authors.get(b.AuthorID); ok {
+			base.AuthorID, base.AuthorName = a.ID, a.Name
+		}
+		if rt, ok := r.s.readingTimes.get(b.ReadingTimeID); ok {
+			base.ReadingTimeID = rt.ID
+			base.ReadingTimeMinutes = rt.Minutes
+			base.ReadingTimeTextLength = rt.TextLength
+			base.ReadingTimeEstimatedSeconds = rt.EstimatedSeconds
+			base.ReadingTimeWordCount = rt.WordCount
+			base.ReadingTimeType = rt.Type
+		}
+		if s, ok := r.s.statistics.get(b.StatisticID); ok {
+			base.StatisticID = s.ID
+			base.StatisticLikes = deref(s.Likes)
+			base.StatisticViews = deref(s.Views)
+			base.StatisticType = s.Type
+		}
+
+		for _, t := range r.s.blogTopicsOf(b.ID) {
+			if len(params.Topics) > 0 && !inInts(params.Topics, t.ID) {
+				continue
+			}
+			row := base
+			row.TopicID, row.TopicName = t.ID, t.Name
+			datas = append(datas, row)
+		}
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetPublicBlogBySlug(ctx context.Context, slug string) ([]public.SingleBlogPublicRaw, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	b, ok := r.s.blogs.find(func(b blog.Blog) bool {
+		return b.Slug == slug && b.Status == string(editorial.StatusPublished)
+	})
+	if !ok {
+		return []public.SingleBlogPublicRaw{}, errors.New("data not found")
+	}
+
+	base := public.SingleBlogPublicRaw{
+		ID:              b.ID,
+		Title:           b.Title,
+		DescriptionHTML: b.DescriptionHTML,
+		BannerUrl:       b.BannerUrl,
+		BannerFileName:  b.BannerFileName,
+		Summary:         b.Summary,
+		Status:          b.Status,
+		Slug:            b.Slug,
+		IsHighlight:     b.IsHighlight,
+		PublishedAt:     b.PublishedAt,
+	}
+	if a, ok := r.s.authors.get(b.AuthorID); ok {
+		base.AuthorID, base.AuthorName = a.ID, a.Name
+	}
+	if rt, ok := r.s.readingTimes.get(b.ReadingTimeID); ok {
+		base.ReadingTimeID = rt.ID
+		base.ReadingTimeMinutes = rt.Minutes
+		base.ReadingTimeTextLength = rt.TextLength
+		base.ReadingTimeEstimatedSeconds = rt.EstimatedSeconds
+		base.ReadingTimeWordCount = rt.WordCount
+		base.ReadingTimeType = rt.Type
+	}
+	if s, ok := r.s.statistics.get(b.StatisticID); ok {
+		base.StatisticID = s.ID
+		base.StatisticLikes = deref(s.Likes)
+		base.StatisticViews = deref(s.Views)
+		base.StatisticType = s.Type
+	}
+
+	var datas []public.SingleBlogPublicRaw
+	for _, img := range r.s.blogImagesOf(b.ID) {
+		for _, t := range r.s.blogTopicsOf(b.ID) {
+			row := base
+			row.ContentImageID = img.ID
+			row.ContentImageUrl = img.ImageUrl
+			row.ContentImageFileName = img.ImageFileName
+			row.TopicID, row.TopicName = t.ID, t.Name
+			datas = append(datas, row)
+		}
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetRawPublicBlogTopics(ctx context.Context, params public.BlogPublicParams, uniqueBlogIDs []int) ([]public.BlogTopicPublicRaw, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.publicBlogs(func(b blog.Blog) bool {
+		return len(uniqueBlogIDs) == 0 || inInts(uniqueBlogIDs, b.ID)
+	})
+	if err := sortRows(rows, params.Order, params.Sort); err != nil {
+		return nil, err
+	}
+
+	datas := []public.BlogTopicPublicRaw{}
+	for _, b := range rows {
+		for _, t := range r.s.blogTopicsOf(b.ID) {
+			datas = append(datas, public.BlogTopicPublicRaw{BlogID: b.ID, TopicID: t.ID, TopicName: t.Name})
+		}
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetPublicTestimonials(ctx context.Context) ([]public.TestimonialPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.testimonials.filter(func(t testimonial.Testimonial) bool { return t.IsUsed })
+	if err := sortRows(rows, "updated_at", "DESC"); err != nil {
+		return nil, err
+	}
+
+	var datas []public.TestimonialPublicResponse
+	for _, t := range rows {
+		datas = append(datas, public.TestimonialPublicResponse{
+			ID:         t.ID,
+			Name:       t.Name,
+			Via:        t.Via,
+			Role:       t.Role,
+			Message:    t.Message,
+			WorkingAt:  t.WorkingAt,
+			CompanyURL: t.CompanyURL,
+			IsUsed:     sqlBool(t.IsUsed),
+			CreatedAt:  formatDateTime(t.CreatedAt),
+		})
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetPublicTopics(ctx context.Context) ([]public.TopicPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.topics.filter(func(topic.Topic) bool { return true })
+	if err := sortRows(rows, "created_at", "DESC"); err != nil {
+		return nil, err
+	}
+
+	var datas []public.TopicPublicResponse
+	for _, t := range rows {
+		datas = append(datas, public.TopicPublicResponse{ID: t.ID, Name: t.Name})
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetRawPublicPaginateProjects(ctx context.Context, params public.ProjectPublicParams) ([]public.ProjectPaginatePublicRaw, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.projects.filter(func(p project.Project) bool {
+		return p.Status == string(editorial.StatusPublished) &&
+			(params.Search == "" || contains(p.Title, params.Search) || contains(p.Summary, params.Search)) &&
+			(!params.HighlightOnly() || p.IsHighlight)
+	})
+	page, total, err := paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datas := []public.ProjectPaginatePublicRaw{}
+	for _, p := range page {
+		datas = append(datas, public.ProjectPaginatePublicRaw{
+			ID:            p.ID,
+			Title:         p.Title,
+			Summary:       p.Summary,
+			ImageURL:      p.ImageUrl,
+			ImageFileName: p.ImageFileName,
+			RepositoryURL: p.RepositoryUrl,
+			PublishedAt:   p.PublishedAt,
+			Slug:          p.Slug,
+			IsHighlight:   p.IsHighlight,
+		})
+	}
+	return datas, total, nil
+}
+
+func (r *publicRepository) GetRawPublicProjectTechnologies(ctx context.Context, params public.ProjectPublicParams, uniqueProjectIDs []int) ([]public.ProjectTechnologyPublicRaw, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.projects.filter(func(p project.Project) bool {
+		return len(uniqueProjectIDs) == 0 || inInts(uniqueProjectIDs, p.ID)
+	})
+	if err := sortRows(rows, params.Order, params.Sort); err != nil {
+		return nil, err
+	}
+
+	datas := []public.ProjectTechnologyPublicRaw{}
+	for _, p := range rows {
+		for _, t := range r.s.projectTechnologiesOf(p.ID) {
+			datas = append(datas, public.ProjectTechnologyPublicRaw{
+				ProjectID:        p.ID,
+				TechID:           t.ID,
+				TechName:         t.Name,
+				TechLogoURL:      t.LogoUrl,
+				TechLogoFileName: t.LogoFileName,
+				TechLink:         deref(t.Link),
+			})
+		}
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetPublicProjectBySlug(ctx context.Context, slug string) ([]public.SingleProjectPublicRaw, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.projects.find(func(p project.Project) bool {
+		return p.Slug == slug && p.Status == string(editorial.StatusPublished)
+	})
+	if !ok {
+		return []public.SingleProjectPublicRaw{}, errors.New("data not found")
+	}
+
+	base := public.SingleProjectPublicRaw{
+		ID:            p.ID,
+		Title:         p.Title,
+		Description:   p.Description,
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+		RepositoryUrl: p.RepositoryUrl,
+		Summary:       p.Summary,
+		Status:        p.Status,
+		Slug:          p.Slug,
+		IsHighlight:   p.IsHighlight,
+		PublishedAt:   p.PublishedAt,
+	}
+	if s, ok := r.s.statistics.get(p.StatisticID); ok {
+		base.StatisticID = s.ID
+		base.StatisticLikes = deref(s.Likes)
+		base.StatisticViews = deref(s.Views)
+		base.StatisticType = s.Type
+	}
+
+	var datas []public.SingleProjectPublicRaw
+	for _, img := range r.s.projectImagesOf(p.ID) {
+		for _, t := range r.s.projectTechnologiesOf(p.ID) {
+			row := base
+			row.ContentImageID = img.ID
+			row.ContentImageUrl = img.ImageUrl
+			row.ContentImageFileName = img.ImageFileName
+			row.TechID, row.TechName = t.ID, t.Name
+			row.TechLogoURL = t.LogoUrl
+			row.TechLink = deref(t.Link)
+			datas = append(datas, row)
+		}
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetPublicTechnologies(ctx context.Context) ([]public.TechnologyPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.technologies.filter(func(technology.Technology) bool { return true })
+	if err := sortRows(rows, "updated_at", "DESC"); err != nil {
+		return nil, err
+	}
+
+	var datas []public.TechnologyPublicResponse
+	for _, t := range rows {
+		datas = append(datas, public.TechnologyPublicResponse{
+			ID:           t.ID,
+			Name:         t.Name,
+			LogoURL:      t.LogoUrl,
+			LogoFileName: t.LogoFileName,
+			IsMajor:      sqlBool(t.IsMajor),
+		})
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) GetPublicAuthors(ctx context.Context) ([]public.AuthorPublicResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rows := r.s.authors.filter(func(author.Author) bool { return true })
+	if err := sortRows(rows, "updated_at", "DESC"); err != nil {
+		return nil, err
+	}
+
+	var datas []public.AuthorPublicResponse
+	for _, a := range rows {
+		datas = append(datas, public.AuthorPublicResponse{
+			ID:             a.ID,
+			Name:           a.Name,
+			AvatarUrl:      a.AvatarUrl,
+			AvatarFileName: a.AvatarFileName,
+		})
+	}
+	return datas, nil
+}
+
+func (r *publicRepository) FindProjectById(ctx context.Context, id int) (public.ProjectByIdResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.projects.get(id)
+	if !ok {
+		return public.ProjectByIdResponse{}, gorm.ErrRecordNotFound
+	}
+	return public.ProjectByIdResponse{
+		ID:            p.ID,
+		Title:         p.Title,
+		Description:   p.Description,
+		ImageUrl:      p.ImageUrl,
+		ImageFileName: p.ImageFileName,
+		RepositoryUrl: p.RepositoryUrl,
+		Summary:       p.Summary,
+		Status:        p.Status,
+		Slug:          p.Slug,
+		PublishedAt:   formatDateTimePtr(p.PublishedAt),
+		CreatedAt:     formatDateTime(p.CreatedAt),
+	}, nil
+}
+
+func (r *publicRepository) UpdatePublicProjectStatistic(ctx context.Context, p public.ProjectStatisticUpdatePublicDTO) (public.ProjectStatisticUpdatePubblicResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateStatistic(p.StatisticID, p.Likes, p.Views, p.Type)
+	return public.ProjectStatisticUpdatePubblicResponse{
+		ProjectID:    p.ProjectID,
+		ProjectTitle: p.ProjectTitle,
+		StatisticID:  p.StatisticID,
+		Likes:        deref(p.Likes),
+		Views:        deref(p.Views),
+		Type:         p.Type,
+	}, nil
+}
+
+func (r *publicRepository) FindBlogById(ctx context.Context, id int) (public.BlogByIdResponse, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	b, ok := r.s.blogs.get(id)
+	if !ok {
+		return public.BlogByIdResponse{}, gorm.ErrRecordNotFound
+	}
+	return public.BlogByIdResponse{
+		ID:             b.ID,
+		Title:          b.Title,
+		BannerUrl:      b.BannerUrl,
+		BannerFileName: b.BannerFileName,
+		Status:         b.Status,
+		Slug:           b.Slug,
+		PublishedAt:    formatDateTimePtr(b.PublishedAt),
+		CreatedAt:      formatDateTime(b.CreatedAt),
+	}, nil
+}
+
+func (r *publicRepository) UpdatePublicBlogStatistic(ctx context.Context, p public.BlogStatisticUpdatePublicDTO) (public.BlogStatisticUpdatePubblicResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateStatistic(p.StatisticID, p.Likes, p.Views, p.Type)
+	return public.BlogStatisticUpdatePubblicResponse{
+		BlogID:      p.BlogID,
+		Title:       p.Title,
+		StatisticID: p.StatisticID,
+		Likes:       deref(p.Likes),
+		Views:       deref(p.Views),
+		Type:        p.Type,
+	}, nil
+}
+
+func (r *publicRepository) FindStatisticById(ctx context.Context, id int) (statistic.Statistic, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.statistics.get(id)
+	if !ok {
+		return statistic.Statistic{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+// publicBlogs returns the matching blogs with their statistic views joined in.
+func (s *Store) publicBlogs(keep func(blog.Blog) bool) []publicBlog {
+	rows := []publicBlog{}
+	for _, b := range s.blogs.filter(keep) {
+		row := publicBlog{Blog: b}
+		if st, ok := s.statistics.get(b.StatisticID); ok {
+			row.Views = deref(st.Views)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
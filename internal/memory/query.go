@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var naming = schema.NamingStrategy{}
+
+// paginate orders rows like "ORDER BY order sort" and returns one page plus
+// the total before paging, mirroring the SQL FindAll queries.
+func paginate[T any](rows []T, order, sort string, page, limit int) ([]T, int, error) {
+	if err := sortRows(rows, order, sort); err != nil {
+		return nil, 0, err
+	}
+
+	total := len(rows)
+	if limit <= 0 {
+		return rows, total, nil
+	}
+
+	start := (page - 1) * limit
+	if start < 0 || start >= total {
+		return []T{}, total, nil
+	}
+	return rows[start:min(start+limit, total)], total, nil
+}
+
+// sortRows sorts by the field whose column name is order, e.g. created_at.
+// Ties keep the id order, like the primary key index would.
+func sortRows[T any](rows []T, order, sort string) error {
+	if order == "" {
+		order = "id"
+	}
+
+	var zero T
+	field, ok := columnField(reflect.TypeOf(zero), order)
+	if !ok {
+		return fmt.Errorf("unknown column %q in order clause", order)
+	}
+	desc := strings.EqualFold(sort, "DESC")
+
+	slices.SortStableFunc(rows, func(a, b T) int {
+		c := compareValues(reflect.ValueOf(a).FieldByIndex(field), reflect.ValueOf(b).FieldByIndex(field))
+		if desc {
+			return -c
+		}
+		return c
+	})
+	return nil
+}
+
+func columnField(t reflect.Type, column string) ([]int, bool) {
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		if naming.ColumnName("", f.Name) == column {
+			return f.Index, true
+		}
+	}
+	return nil, false
+}
+
+// compareValues orders two values of the same field; nil sorts first, as in
+// MySQL and in PostgreSQL with NULLS FIRST.
+func compareValues(a, b reflect.Value) int {
+	if a.Kind() == reflect.Pointer {
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+		return compareValues(a.Elem(), b.Elem())
+	}
+
+	switch v := a.Interface().(type) {
+	case time.Time:
+		return v.Compare(b.Interface().(time.Time))
+	case gorm.DeletedAt:
+		return v.Time.Compare(b.Interface().(gorm.DeletedAt).Time)
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// contains is the in-memory "LOWER(column) LIKE %search%".
+func contains(value, search string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(search))
+}
+
+func containsPtr(value *string, search string) bool {
+	return value != nil && contains(*value, search)
+}
+
+// dateFilter returns a match func for a date query param, following
+// utils.DateFilter; no values match everything.
+func dateFilter(column string, values []string) (func(time.Time) bool, error) {
+	if len(values) == 0 {
+		return func(time.Time) bool { return true }, nil
+	}
+
+	start, end, err := utils.DateRange(column, values)
+	if err != nil {
+		return nil, err
+	}
+	return func(t time.Time) bool {
+		return !t.Before(start) && t.Before(end)
+	}, nil
+}
+
+// dateFilterPtr is dateFilter for nullable columns, where NULL never matches
+// a filter.
+func dateFilterPtr(column string, values []string) (func(*time.Time) bool, error) {
+	match, err := dateFilter(column, values)
+	if err != nil {
+		return nil, err
+	}
+	return func(t *time.Time) bool {
+		if len(values) == 0 {
+			return true
+		}
+		return t != nil && match(*t)
+	}, nil
+}
+
+// rangeFilter is the in-memory "column >= min AND column <= max" for the
+// min/max query params, which arrive as strings; an empty bound is open.
+func rangeFilter(minValue, maxValue string) (func(float64) bool, error) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	var err error
+	if minValue != "" {
+		if lo, err = strconv.ParseFloat(minValue, 64); err != nil {
+			return nil, err
+		}
+	}
+	if maxValue != "" {
+		if hi, err = strconv.ParseFloat(maxValue, 64); err != nil {
+			return nil, err
+		}
+	}
+	return func(v float64) bool { return v >= lo && v <= hi }, nil
+}
+
+func inInts(values []int, v int) bool {
+	return slices.Contains(values, v)
+}
+
+func inStrings(values []string, v string) bool {
+	return slices.Contains(values, v)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+// formatDateTime renders a timestamp the way the string response fields
+// scanned from SQL carry it.
+func formatDateTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func formatDateTimePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := formatDateTime(*t)
+	return &s
+}
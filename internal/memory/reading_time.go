@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
+	"gorm.io/gorm"
+)
+
+type readingTimeRepository struct {
+	s *Store
+}
+
+func (r *readingTimeRepository) FindAll(ctx context.Context, params reading_time.GetAllReadingTimeParams) ([]reading_time.ReadingTime, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	minutes, err := rangeFilter(params.MinMinutes, params.MaxMinutes)
+	if err != nil {
+		return nil, 0, err
+	}
+	estimates, err := rangeFilter(params.MinEstimates, params.MaxEstimates)
+	if err != nil {
+		return nil, 0, err
+	}
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.readingTimes.filter(func(rt reading_time.ReadingTime) bool {
+		return minutes(float64(rt.Minutes)) &&
+			estimates(rt.EstimatedSeconds) &&
+			createdAt(rt.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *readingTimeRepository) FindById(ctx context.Context, id int) (reading_time.ReadingTime, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.readingTimes.get(id)
+	if !ok {
+		return reading_time.ReadingTime{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *readingTimeRepository) CreateReadingTime(ctx context.Context, p reading_time.CreateReadingTimeRequest, tx *gorm.DB) (reading_time.ReadingTime, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := reading_time.ReadingTime{
+		Minutes:          p.Minutes,
+		TextLength:       p.TextLength,
+		EstimatedSeconds: p.EstimatedSeconds,
+		WordCount:        p.WordCount,
+		Type:             p.Type,
+	}
+	r.s.readingTimes.insert(&data)
+	return data, nil
+}
+
+func (r *readingTimeRepository) UpdateReadingTime(ctx context.Context, p reading_time.UpdateReadingTimeRequest, tx *gorm.DB) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	//? Updates(&struct) in the SQL version skips zero fields
+	r.s.readingTimes.update(func(rt reading_time.ReadingTime) bool { return rt.ID == p.ID }, func(rt *reading_time.ReadingTime) {
+		if p.Minutes != 0 {
+			rt.Minutes = p.Minutes
+		}
+		if p.TextLength != 0 {
+			rt.TextLength = p.TextLength
+		}
+		if p.EstimatedSeconds != 0 {
+			rt.EstimatedSeconds = p.EstimatedSeconds
+		}
+		if p.WordCount != 0 {
+			rt.WordCount = p.WordCount
+		}
+		if p.Type != "" {
+			rt.Type = p.Type
+		}
+	})
+	return nil
+}
+
+func (r *readingTimeRepository) DeleteReadingTime(ctx context.Context, id int) (reading_time.ReadingTime, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.readingTimes.get(id)
+	if !ok {
+		return reading_time.ReadingTime{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.readingTimes.deleteID(id)
+	return data, nil
+}
@@ -0,0 +1,30 @@
+package memory
+
+import "github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+
+// NewRepositories returns every module's repository backed by s.
+func NewRepositories(s *Store) container.Repositories {
+	return container.Repositories{
+		About:               &aboutRepository{s: s},
+		ApiKey:              &apiKeyRepository{s: s},
+		Auth:                &authRepository{s: s},
+		Author:              &authorRepository{s: s},
+		Blog:                &blogRepository{s: s},
+		BlogContentImage:    &blogContentImageRepository{s: s},
+		BlogTopic:           &blogTopicRepository{s: s},
+		Editorial:           &editorialRepository{s: s},
+		Experience:          &experienceRepository{s: s},
+		Idempotency:         &idempotencyRepository{s: s},
+		Project:             &projectRepository{s: s},
+		ProjectContentImage: &projectContentImageRepository{s: s},
+		ProjectTechnology:   &projectTechnologyRepository{s: s},
+		Public:              &publicRepository{s: s},
+		ReadingTime:         &readingTimeRepository{s: s},
+		Statistic:           &statisticRepository{s: s},
+		System:              &systemRepository{s: s},
+		Technology:          &technologyRepository{s: s},
+		Testimonial:         &testimonialRepository{s: s},
+		Topic:               &topicRepository{s: s},
+		User:                &userRepository{s: s},
+	}
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Credentials of the admin account Seed creates.
+const (
+	DemoEmail    = "admin@example.com"
+	DemoPassword = "password123"
+)
+
+// NewSeededStore returns a store filled by Seed.
+func NewSeededStore() (*Store, error) {
+	s := NewStore()
+	if err := Seed(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Seed fills s with a small portfolio: an admin user, an about page, work
+// experiences, testimonials, and published blogs and projects with their
+// topics, technologies and statistics.
+func Seed(s *Store) error {
+	password, err := bcrypt.GenerateFromPassword([]byte(DemoPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	published := string(editorial.StatusPublished)
+
+	jane := author.Author{Name: "Jane Doe"}
+	s.authors.insert(&jane)
+	s.users.insert(&user.User{
+		Username: "admin",
+		Email:    DemoEmail,
+		Password: string(password),
+		Role:     user.RoleAdmin,
+		AuthorID: &jane.ID,
+	})
+
+	s.abouts.insert(&about.About{
+		Title:           "Hi, I'm Jane",
+		DescriptionHTML: "<p>Backend engineer who enjoys clean architecture and boring, reliable systems.</p>",
+		IsUsed:          true,
+	})
+
+	s.experiences.insert(&experience.Experience{
+		Position:       "Software Engineer",
+		CompanyName:    "Acme Corp",
+		WorkType:       "Office",
+		Country:        "Indonesia",
+		City:           ptr("Jakarta"),
+		SummaryHTML:    "<p>Built internal tooling and payment services.</p>",
+		FromDate:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:         ptr(time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)),
+		CompWebsiteUrl: "https://example.com",
+	})
+	s.experiences.insert(&experience.Experience{
+		Position:       "Senior Backend Engineer",
+		CompanyName:    "Globex",
+		WorkType:       "Remote",
+		Country:        "Singapore",
+		SummaryHTML:    "<p>Leading the content platform team.</p>",
+		FromDate:       time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+		CompWebsiteUrl: "https://example.org",
+		IsCurrent:      true,
+	})
+
+	s.testimonials.insert(&testimonial.Testimonial{
+		Name:      "John Smith",
+		Role:      ptr("Engineering Manager"),
+		Message:   ptr("Jane ships careful, well-tested code."),
+		WorkingAt: ptr("Acme Corp"),
+		IsUsed:    true,
+	})
+
+	var topics []topic.Topic
+	for _, name := range []string{"Go", "Architecture", "Databases"} {
+		t := topic.Topic{Name: name}
+		s.topics.insert(&t)
+		topics = append(topics, t)
+	}
+
+	var techs []technology.Technology
+	for _, t := range []technology.Technology{
+		{Name: "Go", DescriptionHTML: "<p>The language of this API.</p>", IsMajor: true, Link: ptr("https://go.dev")},
+		{Name: "MySQL", DescriptionHTML: "<p>The default database.</p>", IsMajor: true, Link: ptr("https://www.mysql.com")},
+		{Name: "Docker", DescriptionHTML: "<p>Runs MinIO and the database locally.</p>", Link: ptr("https://www.docker.com")},
+	} {
+		s.technologies.insert(&t)
+		techs = append(techs, t)
+	}
+
+	blogs := []struct {
+		title, slug, summary string
+		highlight            bool
+		topics               []topic.Topic
+	}{
+		{"Clean Architecture in Go", "clean-architecture-in-go", "How this API splits handlers, services and repositories.", true, topics[:2]},
+		{"Portable SQL with GORM", "portable-sql-with-gorm", "Writing queries that run on MySQL and PostgreSQL.", false, topics[1:]},
+	}
+	for i, b := range blogs {
+		stat := statistic.Statistic{Likes: ptr(3 * (i + 1)), Views: ptr(40 * (i + 1)), Type: "Blog"}
+		s.statistics.insert(&stat)
+		rt := reading_time.ReadingTime{Minutes: 4, TextLength: 4200, EstimatedSeconds: 240, WordCount: 800, Type: "Blog"}
+		s.readingTimes.insert(&rt)
+
+		row := blog.Blog{
+			StatisticID:     stat.ID,
+			ReadingTimeID:   rt.ID,
+			AuthorID:        jane.ID,
+			Title:           b.title,
+			DescriptionHTML: "<p>" + b.summary + "</p>",
+			Summary:         b.summary,
+			Status:          published,
+			Slug:            b.slug,
+			IsHighlight:     b.highlight,
+			PublishedAt:     ptr(now.AddDate(0, 0, -7*(len(blogs)-i))),
+		}
+		s.blogs.insert(&row)
+		for _, t := range b.topics {
+			s.blogTopics.insert(&blog_topic.BlogTopic{BlogID: row.ID, TopicID: t.ID})
+		}
+	}
+
+	projects := []struct {
+		title, slug, summary string
+		highlight            bool
+		techs                []technology.Technology
+	}{
+		{"Portfolio API", "portfolio-api", "The backend serving this portfolio.", true, techs},
+		{"Link Shortener", "link-shortener", "A tiny URL shortener with click stats.", false, techs[:2]},
+	}
+	for i, p := range projects {
+		stat := statistic.Statistic{Likes: ptr(5 * (i + 1)), Views: ptr(60 * (i + 1)), Type: "Project"}
+		s.statistics.insert(&stat)
+
+		row := project.Project{
+			StatisticID:   stat.ID,
+			Title:         p.title,
+			Description:   "<p>" + p.summary + "</p>",
+			RepositoryUrl: ptr("https://github.com/example/" + p.slug),
+			Summary:       p.summary,
+			Status:        published,
+			Slug:          p.slug,
+			IsHighlight:   p.highlight,
+			PublishedAt:   ptr(now.AddDate(0, -1*(len(projects)-i), 0)),
+		}
+		s.projects.insert(&row)
+		for _, t := range p.techs {
+			s.projectTechnologies.insert(&project_technology.ProjectTechnology{ProjectID: row.ID, TechnologyID: t.ID})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"gorm.io/gorm"
+)
+
+type statisticRepository struct {
+	s *Store
+}
+
+func (r *statisticRepository) FindAll(ctx context.Context, params statistic.GetAllStatisticParams) ([]statistic.Statistic, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	likes, err := rangeFilter(params.MinLikes, params.MaxLikes)
+	if err != nil {
+		return nil, 0, err
+	}
+	views, err := rangeFilter(params.MinViews, params.MaxViews)
+	if err != nil {
+		return nil, 0, err
+	}
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.statistics.filter(func(s statistic.Statistic) bool {
+		return (params.Type == "" || contains(s.Type, params.Type)) &&
+			likes(float64(deref(s.Likes))) &&
+			views(float64(deref(s.Views))) &&
+			createdAt(s.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *statisticRepository) FindById(ctx context.Context, id int) (statistic.Statistic, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.statistics.get(id)
+	if !ok {
+		return statistic.Statistic{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *statisticRepository) CreateStatistic(ctx context.Context, p statistic.CreateStatisticRequest) (statistic.Statistic, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := statistic.Statistic{
+		Likes: ptr(deref(p.Likes)),
+		Views: ptr(deref(p.Views)),
+		Type:  p.Type,
+	}
+	r.s.statistics.insert(&data)
+	return data, nil
+}
+
+func (r *statisticRepository) CreateStatisticWithTx(ctx context.Context, p statistic.CreateStatisticRequest, tx *gorm.DB) (statistic.Statistic, error) {
+	return r.CreateStatistic(ctx, p)
+}
+
+func (r *statisticRepository) UpdateStatistic(ctx context.Context, p statistic.UpdateStatisticRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateStatistic(p.ID, p.Likes, p.Views, p.Type)
+	return nil
+}
+
+func (r *statisticRepository) DeleteStatistic(ctx context.Context, id int) (statistic.Statistic, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.statistics.get(id)
+	if !ok {
+		return statistic.Statistic{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.statistics.deleteID(id)
+	return data, nil
+}
+
+// updateStatistic applies Updates(&Statistic{...}), which skips nil pointers
+// and an empty type. It is shared by the statistic, project and public
+// repositories.
+func (s *Store) updateStatistic(id int, likes, views *int, statType string) {
+	s.statistics.update(func(row statistic.Statistic) bool { return row.ID == id }, func(row *statistic.Statistic) {
+		if likes != nil {
+			row.Likes = ptr(*likes)
+		}
+		if views != nil {
+			row.Views = ptr(*views)
+		}
+		if statType != "" {
+			row.Type = statType
+		}
+	})
+}
@@ -0,0 +1,181 @@
+// Package memory implements every module's Repository interface on plain Go
+// slices. It backs the demo mode (APP_MODE=demo), which runs without MySQL,
+// PostgreSQL or MinIO, the route check of cmd/openapi and the handler tests
+// of internal/app/router.
+//
+// All repositories share one Store so the joined reads (blog relations, the
+// public endpoints) see the same rows the writes produced. Transactions are
+// not emulated: the tx arguments are ignored and every write applies at once.
+// Soft deletes remove the row.
+package memory
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/api_key"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog_topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/idempotency"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/reading_time"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Store holds one table per model behind a single lock.
+type Store struct {
+	mu sync.RWMutex
+
+	abouts               table[about.About]
+	apiKeys              table[api_key.ApiKey]
+	authors              table[author.Author]
+	blogs                table[blog.Blog]
+	blogContentImages    table[blog_content_image.BlogContentImage]
+	blogTopics           table[blog_topic.BlogTopic]
+	editorialEvents      table[editorial.Event]
+	experiences          table[experience.Experience]
+	idempotencyKeys      table[idempotency.IdempotencyKey]
+	projects             table[project.Project]
+	projectContentImages table[project_content_image.ProjectContentImage]
+	projectTechnologies  table[project_technology.ProjectTechnology]
+	readingTimes         table[reading_time.ReadingTime]
+	statistics           table[statistic.Statistic]
+	technologies         table[technology.Technology]
+	testimonials         table[testimonial.Testimonial]
+	topics               table[topic.Topic]
+	users                table[user.User]
+}
+
+// NewStore returns an empty store.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// table is the rows of one model plus its auto-increment counter. Rows must
+// have an int ID field; CreatedAt and UpdatedAt are filled like GORM does, and
+// a Version starts at 1 like the column default.
+type table[T any] struct {
+	rows   []T
+	nextID int
+}
+
+func (t *table[T]) insert(row *T) {
+	t.nextID++
+	now := time.Now()
+
+	v := reflect.ValueOf(row).Elem()
+	v.FieldByName("ID").SetInt(int64(t.nextID))
+	for _, name := range []string{"CreatedAt", "UpdatedAt"} {
+		if f := v.FieldByName(name); f.IsValid() && f.Interface().(time.Time).IsZero() {
+			f.Set(reflect.ValueOf(now))
+		}
+	}
+	if f := v.FieldByName("Version"); f.IsValid() && f.Int() == 0 {
+		f.SetInt(1)
+	}
+
+	t.rows = append(t.rows, *row)
+}
+
+// get returns the row with id; the pointer is only valid while the lock is held.
+func (t *table[T]) get(id int) (*T, bool) {
+	for i := range t.rows {
+		if rowID(&t.rows[i]) == id {
+			return &t.rows[i], true
+		}
+	}
+	return nil, false
+}
+
+func (t *table[T]) find(match func(T) bool) (*T, bool) {
+	for i := range t.rows {
+		if match(t.rows[i]) {
+			return &t.rows[i], true
+		}
+	}
+	return nil, false
+}
+
+func (t *table[T]) filter(keep func(T) bool) []T {
+	out := []T{}
+	for _, row := range t.rows {
+		if keep(row) {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func (t *table[T]) count(match func(T) bool) int {
+	n := 0
+	for _, row := range t.rows {
+		if match(row) {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *table[T]) update(match func(T) bool, apply func(*T)) {
+	for i := range t.rows {
+		if match(t.rows[i]) {
+			apply(&t.rows[i])
+			touch(&t.rows[i])
+		}
+	}
+}
+
+// updateVersioned applies apply to the row id when it is still at version and
+// bumps the version, like utils.UpdateVersioned.
+func (t *table[T]) updateVersioned(id, version int, apply func(*T)) error {
+	row, ok := t.get(id)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	current := reflect.ValueOf(row).Elem().FieldByName("Version")
+	if err := utils.CheckVersion(version, int(current.Int())); err != nil {
+		return err
+	}
+	apply(row)
+	current.SetInt(int64(version + 1))
+	touch(row)
+	return nil
+}
+
+func (t *table[T]) delete(match func(T) bool) {
+	kept := t.rows[:0]
+	for _, row := range t.rows {
+		if !match(row) {
+			kept = append(kept, row)
+		}
+	}
+	t.rows = kept
+}
+
+func (t *table[T]) deleteID(id int) {
+	t.delete(func(row T) bool { return rowID(&row) == id })
+}
+
+func rowID[T any](row *T) int {
+	return int(reflect.ValueOf(row).Elem().FieldByName("ID").Int())
+}
+
+func touch[T any](row *T) {
+	if f := reflect.ValueOf(row).Elem().FieldByName("UpdatedAt"); f.IsValid() {
+		f.Set(reflect.ValueOf(time.Now()))
+	}
+}
@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/system"
+)
+
+// systemRepository reports a store that is always up and has no schema
+// migrations.
+type systemRepository struct {
+	s *Store
+}
+
+func (r *systemRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (r *systemRepository) PoolStats(ctx context.Context) (sql.DBStats, error) {
+	return sql.DBStats{}, nil
+}
+
+func (r *systemRepository) FindMigrationVersion(ctx context.Context) (*system.MigrationVersion, error) {
+	return nil, nil
+}
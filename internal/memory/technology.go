@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"gorm.io/gorm"
+)
+
+type technologyRepository struct {
+	s *Store
+}
+
+func (r *technologyRepository) FindAll(ctx context.Context, params technology.GetAllTechnologyParams) ([]technology.Technology, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.technologies.filter(func(t technology.Technology) bool {
+		return (params.Name == "" || contains(t.Name, params.Name)) &&
+			(params.DescriptionHTML == "" || contains(t.DescriptionHTML, params.DescriptionHTML)) &&
+			(params.IsMajor == "" || t.IsMajor == (params.IsMajor == "Y")) &&
+			createdAt(t.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *technologyRepository) FindById(ctx context.Context, id int) (technology.Technology, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.technologies.get(id)
+	if !ok {
+		return technology.Technology{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *technologyRepository) CreateTechnology(ctx context.Context, p technology.CreateTechnologyDTO) (technology.Technology, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := technology.Technology{
+		Name:            p.Name,
+		DescriptionHTML: p.DescriptionHTML,
+		LogoUrl:         p.LogoUrl,
+		LogoFileName:    p.LogoFileName,
+		IsMajor:         p.IsMajor,
+		Link:            p.Link,
+	}
+	r.s.technologies.insert(&data)
+	return data, nil
+}
+
+func (r *technologyRepository) UpdateTechnology(ctx context.Context, p technology.UpdateTechnologyDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.technologies.updateVersioned(p.ID, p.Version, func(t *technology.Technology) {
+		t.Name = p.Name
+		t.DescriptionHTML = p.DescriptionHTML
+		t.LogoUrl = p.LogoUrl
+		t.LogoFileName = p.LogoFileName
+		t.IsMajor = p.IsMajor
+		t.Link = p.Link
+	})
+}
+
+func (r *technologyRepository) DeleteTechnology(ctx context.Context, id int) (technology.Technology, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.technologies.get(id)
+	if !ok {
+		return technology.Technology{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.technologies.deleteID(id)
+	return data, nil
+}
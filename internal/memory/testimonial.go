@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"gorm.io/gorm"
+)
+
+type testimonialRepository struct {
+	s *Store
+}
+
+func (r *testimonialRepository) FindAll(ctx context.Context, params testimonial.GetAllTestimonialParams) ([]testimonial.Testimonial, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.testimonials.filter(func(t testimonial.Testimonial) bool {
+		return (params.Name == "" || contains(t.Name, params.Name)) &&
+			(params.Role == "" || containsPtr(t.Role, params.Role)) &&
+			(params.WorkingAt == "" || containsPtr(t.WorkingAt, params.WorkingAt)) &&
+			(params.IsUsed == "" || t.IsUsed == (params.IsUsed == "Y")) &&
+			createdAt(t.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *testimonialRepository) FindById(ctx context.Context, id int) (testimonial.Testimonial, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.testimonials.get(id)
+	if !ok {
+		return testimonial.Testimonial{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *testimonialRepository) FindByMultiId(ctx context.Context, ids []int) ([]testimonial.Testimonial, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.testimonials.filter(func(t testimonial.Testimonial) bool { return inInts(ids, t.ID) }), nil
+}
+
+func (r *testimonialRepository) CreateTestimonial(ctx context.Context, p testimonial.CreateTestimonialDTO) (testimonial.Testimonial, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := testimonial.Testimonial{
+		Name:       p.Name,
+		Via:        p.Via,
+		Role:       p.Role,
+		Message:    p.Message,
+		WorkingAt:  p.WorkingAt,
+		CompanyURL: p.CompanyURL,
+		IsUsed:     p.IsUsed,
+	}
+	r.s.testimonials.insert(&data)
+	return data, nil
+}
+
+func (r *testimonialRepository) UpdateTestimonial(ctx context.Context, p testimonial.UpdateTestimonialDTO) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.testimonials.update(func(t testimonial.Testimonial) bool { return t.ID == p.ID }, func(t *testimonial.Testimonial) {
+		t.Name = p.Name
+		t.Via = p.Via
+		t.Role = p.Role
+		t.Message = p.Message
+		t.WorkingAt = p.WorkingAt
+		t.CompanyURL = p.CompanyURL
+		t.IsUsed = p.IsUsed
+	})
+	return nil
+}
+
+func (r *testimonialRepository) DeleteTestimonial(ctx context.Context, id int) (testimonial.Testimonial, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.testimonials.get(id)
+	if !ok {
+		return testimonial.Testimonial{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.testimonials.deleteID(id)
+	return data, nil
+}
+
+func (r *testimonialRepository) ChangeStatusTestimonial(ctx context.Context, id int, isUsed bool) error {
+	return r.ChangeMultiStatusTestimonial(ctx, []int{id}, isUsed)
+}
+
+func (r *testimonialRepository) ChangeMultiStatusTestimonial(ctx context.Context, ids []int, isUsed bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.testimonials.update(func(t testimonial.Testimonial) bool { return inInts(ids, t.ID) }, func(t *testimonial.Testimonial) {
+		t.IsUsed = isUsed
+	})
+	return nil
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"gorm.io/gorm"
+)
+
+type topicRepository struct {
+	s *Store
+}
+
+func (r *topicRepository) FindAll(ctx context.Context, params topic.GetAllTopicParams) ([]topic.Topic, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.topics.filter(func(t topic.Topic) bool {
+		return (params.Name == "" || contains(t.Name, params.Name)) &&
+			createdAt(t.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *topicRepository) FindById(ctx context.Context, id int) (topic.Topic, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.topics.get(id)
+	if !ok {
+		return topic.Topic{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *topicRepository) CreateTopic(ctx context.Context, p topic.CreateTopicRequest) (topic.Topic, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	data := topic.Topic{Name: p.Name}
+	r.s.topics.insert(&data)
+	return data, nil
+}
+
+func (r *topicRepository) UpdateTopic(ctx context.Context, p topic.UpdateTopicRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.topics.update(func(t topic.Topic) bool { return t.ID == p.ID }, func(t *topic.Topic) {
+		if p.Name != "" {
+			t.Name = p.Name
+		}
+	})
+	return nil
+}
+
+func (r *topicRepository) DeleteTopic(ctx context.Context, id int) (topic.Topic, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.topics.get(id)
+	if !ok {
+		return topic.Topic{}, gorm.ErrRecordNotFound
+	}
+	data := *row
+	r.s.topics.deleteID(id)
+	return data, nil
+}
+
+func (r *topicRepository) CheckTopicIds(ctx context.Context, ids []int) ([]topic.Topic, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.topics.filter(func(t topic.Topic) bool { return inInts(ids, t.ID) }), nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	s *Store
+}
+
+func (r *userRepository) FindAll(ctx context.Context, params user.GetAllUserParams) ([]user.User, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	createdAt, err := dateFilter("created_at", params.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := r.s.users.filter(func(u user.User) bool {
+		return (params.Username == "" || contains(u.Username, params.Username)) &&
+			(params.Email == "" || contains(u.Email, params.Email)) &&
+			createdAt(u.CreatedAt)
+	})
+	return paginate(rows, params.Order, params.Sort, params.Page, params.Limit)
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int) (user.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.users.get(id)
+	if !ok {
+		return user.User{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *userRepository) CreateUser(ctx context.Context, data user.User) (user.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.insert(&data)
+	return data, nil
+}
+
+func (r *userRepository) UpdateUser(ctx context.Context, data user.User) (user.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.users.get(data.ID)
+	if !ok {
+		//? Save inserts when the primary key does not exist yet
+		r.s.users.insert(&data)
+		return data, nil
+	}
+	touch(&data)
+	*row = data
+	return data, nil
+}
+
+func (r *userRepository) DeleteUser(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.deleteID(id)
+	return nil
+}
+
+func (r *userRepository) CountByRole(ctx context.Context, role string) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.users.count(func(u user.User) bool { return u.Role == role }), nil
+}
+
+func (r *userRepository) CheckUniqueEmail(ctx context.Context, email string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, taken := r.s.users.find(func(u user.User) bool { return u.Email == email })
+	return !taken, nil
+}
+
+func (r *userRepository) FindByAuthorId(ctx context.Context, authorID int) (user.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.users.find(func(u user.User) bool { return u.AuthorID != nil && *u.AuthorID == authorID })
+	if !ok {
+		return user.User{}, gorm.ErrRecordNotFound
+	}
+	return *row, nil
+}
+
+func (r *userRepository) LinkAuthor(ctx context.Context, id int, authorID *int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.update(func(u user.User) bool { return u.ID == id }, func(u *user.User) {
+		u.AuthorID = authorID
+	})
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id int, password string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.update(func(u user.User) bool { return u.ID == id }, func(u *user.User) {
+		u.Password = password
+	})
+	return nil
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, id int, role string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.update(func(u user.User) bool { return u.ID == id }, func(u *user.User) {
+		u.Role = role
+	})
+	return nil
+}
@@ -0,0 +1,449 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// Route documents one registered route. Request and response types are given
+// as zero values, e.g. JSON: topic.CreateTopicRequest{}.
+type Route struct {
+	Method      string
+	Path        string // gin syntax, e.g. /api/blogs/:id
+	Tag         string
+	Summary     string
+	Description string
+	Public      bool // no JWT or API key needed
+	Query       []Param
+	JSON        any // application/json body
+	Form        any // multipart/form-data body, field names from form tags
+	Data        any // the data of the success envelope, nil when it is empty
+	List        bool
+	Paginated   bool
+	Status      int    // success status, 200 when zero
+	ContentType string // a body that is not the JSON envelope, e.g. text/html
+	Raw         any    // a JSON body that is not wrapped in the envelope
+	Errors      []int  // error statuses on top of the ones derived from the route
+	Partial     bool   // every body field is optional, omitted ones keep their value
+	Deprecated  bool   // answered with Deprecation and Link headers
+	Versioned   bool   // the record has an ETag, its updates take If-Match
+	Idempotent  bool   // takes an Idempotency-Key, retries get the first response
+	Location    bool   // answers with the Location of the created record
+}
+
+// Param is a query parameter.
+type Param struct {
+	Name        string
+	Type        string // string, integer or boolean
+	Description string
+	Enum        []string
+}
+
+// Pagination are the query parameters of every paginated list.
+var Pagination = []Param{
+	{Name: "page", Type: "integer", Description: "page number, starting at 1"},
+	{Name: "limit", Type: "integer", Description: "items per page"},
+	{Name: "sort", Type: "string", Description: "sort direction", Enum: []string{"ASC", "DESC"}},
+	{Name: "order", Type: "string", Description: "column to order by"},
+}
+
+const (
+	bearerAuth = "bearerAuth"
+	apiKeyAuth = "apiKeyAuth"
+)
+
+// Builder collects routes into a Document.
+type Builder struct {
+	doc     *Document
+	schemas schemas
+	tags    map[string]bool
+}
+
+func NewBuilder(info Info) *Builder {
+	b := &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]PathItem{},
+			Components: Components{
+				Schemas: map[string]*Schema{},
+				SecuritySchemes: map[string]SecurityScheme{
+					bearerAuth: {
+						Type:         "http",
+						Scheme:       "bearer",
+						BearerFormat: "JWT",
+						Description:  "access token from POST /api/v2/auth/login",
+					},
+					apiKeyAuth: {
+						Type:        "apiKey",
+						In:          "header",
+						Name:        "X-API-Key",
+						Description: "API key from POST /api/v2/api-keys, also accepted as Authorization: Bearer pfk_...",
+					},
+				},
+			},
+			Security: []SecurityRequirement{{bearerAuth: {}}, {apiKeyAuth: {}}},
+		},
+		tags: map[string]bool{},
+	}
+	b.schemas.components = b.doc.Components.Schemas
+	b.errorComponents()
+	return b
+}
+
+// errorComponents registers the problem details object every error
+// response carries, see utils.WriteProblem.
+func (b *Builder) errorComponents() {
+	components := b.doc.Components.Schemas
+	components["Problem"] = &Schema{
+		Type:        "object",
+		Description: "RFC 7807 problem details",
+		Properties: map[string]*Schema{
+			"type":       {Type: "string", Description: utils.ProblemTypeBlank + ", or " + utils.ProblemTypeValidation + " when errors lists the invalid fields"},
+			"title":      {Type: "string"},
+			"status":     {Type: "integer"},
+			"detail":     {Type: "string"},
+			"errors":     b.schemas.of(reflect.TypeOf([]utils.FieldError{})),
+			"request_id": {Type: "string", Description: "the " + utils.RequestIDHeader + " of the request"},
+		},
+		Required: []string{"type", "title", "status"},
+	}
+	components["Pagination"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"page":  {Type: "integer"},
+			"limit": {Type: "integer"},
+			"total": {Type: "integer"},
+		},
+		Required: []string{"page", "limit", "total"},
+	}
+
+	errorResponse := func(description string) *Response {
+		return &Response{
+			Description: description,
+			Content: map[string]MediaType{
+				utils.ProblemContentType: {Schema: &Schema{Ref: "#/components/schemas/Problem"}},
+			},
+		}
+	}
+	b.doc.Components.Responses = map[string]*Response{
+		"BadRequest":          errorResponse("invalid request, field errors are listed when known"),
+		"Unauthorized":        errorResponse("missing or invalid token or API key"),
+		"Forbidden":           errorResponse("the caller may not perform this request"),
+		"NotFound":            errorResponse("no such resource"),
+		"Conflict":            errorResponse("the request conflicts with the current state"),
+		"PreconditionFailed":  errorResponse("the If-Match ETag is not the current version, or the record has no version"),
+		"UnprocessableEntity": errorResponse("the Idempotency-Key was already used for a different request"),
+		"ServiceUnavailable":  errorResponse("a dependency is not available"),
+		"InternalServerError": errorResponse("unexpected error"),
+	}
+}
+
+// Add documents routes; a route added twice keeps the last definition.
+func (b *Builder) Add(routes ...Route) {
+	for _, r := range routes {
+		path, pathParams := openAPIPath(r.Path)
+		item, ok := b.doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+			b.doc.Paths[path] = item
+		}
+		item[strings.ToLower(r.Method)] = b.operation(r, pathParams)
+
+		if r.Tag != "" && !b.tags[r.Tag] {
+			b.tags[r.Tag] = true
+			b.doc.Tags = append(b.doc.Tags, Tag{Name: r.Tag})
+		}
+	}
+}
+
+// Document returns the document built so far.
+func (b *Builder) Document() *Document {
+	sort.Slice(b.doc.Tags, func(i, j int) bool { return b.doc.Tags[i].Name < b.doc.Tags[j].Name })
+	return b.doc
+}
+
+func (b *Builder) operation(r Route, pathParams []string) *Operation {
+	op := &Operation{
+		Summary:     r.Summary,
+		Description: r.Description,
+		OperationID: operationID(r.Method, r.Path),
+		Responses:   map[string]*Response{},
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if r.Public {
+		op.Security = &[]SecurityRequirement{}
+	}
+	op.Deprecated = r.Deprecated
+
+	for _, name := range pathParams {
+		schema := &Schema{Type: "string"}
+		if name == "id" {
+			schema = &Schema{Type: "integer"}
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	for _, q := range r.Query {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        q.Name,
+			In:          "query",
+			Description: q.Description,
+			Schema:      &Schema{Type: q.Type, Enum: q.Enum},
+		})
+	}
+
+	switch {
+	case r.JSON != nil:
+		schema := b.body(b.schemas.of(reflect.TypeOf(r.JSON)), pathParams, r.Partial)
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: schema}},
+		}
+	case r.Form != nil:
+		schema := b.body(b.schemas.ofNamed(reflect.TypeOf(r.Form), formNames), pathParams, r.Partial)
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{"multipart/form-data": {
+				Schema:   schema,
+				Encoding: b.jsonFields(schema),
+			}},
+		}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	op.Responses[strconv.Itoa(status)] = b.success(r)
+	headers := map[string]Header{}
+	if r.Location {
+		headers["Location"] = Header{Description: "the path of the created record", Schema: &Schema{Type: "string"}}
+	}
+	if r.Deprecated {
+		headers["Deprecation"] = Header{Description: "when the route was deprecated, as @<unix seconds>", Schema: &Schema{Type: "string"}}
+		headers["Link"] = Header{Description: "the route replacing it, rel=\"successor-version\"", Schema: &Schema{Type: "string"}}
+	}
+	if r.Versioned {
+		headers["ETag"] = Header{Description: "the version of the record, to send as If-Match", Schema: &Schema{Type: "string"}}
+		if r.Method != http.MethodGet {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:        "If-Match",
+				In:          "header",
+				Description: "the ETag of the record the update is based on, instead of the version field; * matches any version",
+				Schema:      &Schema{Type: "string"},
+			})
+			r.Errors = append(r.Errors, http.StatusConflict, http.StatusPreconditionFailed)
+		}
+	} else if r.Method != http.MethodGet && len(pathParams) > 0 && strings.HasPrefix(r.Path, "/api/v2/") {
+		//? utils.IfMatchMiddleware refuses If-Match on records without a version
+		r.Errors = append(r.Errors, http.StatusPreconditionFailed)
+	}
+	if r.Idempotent {
+		headers["Idempotent-Replayed"] = Header{Description: "true when the response is the one stored for the Idempotency-Key", Schema: &Schema{Type: "string"}}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        "Idempotency-Key",
+			In:          "header",
+			Description: "a unique key per record to create; a retry with the same key and body gets the first response instead of creating the record again, at most 255 characters",
+			Schema:      &Schema{Type: "string"},
+		})
+		r.Errors = append(r.Errors, http.StatusConflict, http.StatusUnprocessableEntity)
+	}
+	if len(headers) > 0 {
+		op.Responses[strconv.Itoa(status)].Headers = headers
+	}
+
+	if r.ContentType == "" && r.Raw == nil {
+		errors := []int{http.StatusInternalServerError}
+		if r.JSON != nil || r.Form != nil || len(pathParams) > 0 || len(r.Query) > 0 {
+			errors = append(errors, http.StatusBadRequest)
+		}
+		if !r.Public {
+			errors = append(errors, http.StatusUnauthorized, http.StatusForbidden)
+		}
+		if len(pathParams) > 0 {
+			errors = append(errors, http.StatusNotFound)
+		}
+		errors = append(errors, r.Errors...)
+		for _, code := range errors {
+			op.Responses[strconv.Itoa(code)] = &Response{Ref: "#/components/responses/" + errorResponseName(code)}
+		}
+	}
+	return op
+}
+
+// success describes the body of a successful response, the envelope of
+// utils.Success, utils.Created or utils.PaginatedSuccess unless the route
+// writes something else.
+func (b *Builder) success(r Route) *Response {
+	if r.ContentType != "" {
+		return &Response{
+			Description: r.Summary,
+			Content:     map[string]MediaType{r.ContentType: {Schema: &Schema{Type: "string"}}},
+		}
+	}
+	if r.Raw != nil {
+		return &Response{
+			Description: r.Summary,
+			Content:     map[string]MediaType{"application/json": {Schema: b.schemas.of(reflect.TypeOf(r.Raw))}},
+		}
+	}
+
+	data := &Schema{Description: "empty"}
+	if r.Data != nil {
+		data = b.schemas.of(reflect.TypeOf(r.Data))
+		switch {
+		case r.Paginated:
+			data = &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"items":      {Type: "array", Items: data},
+					"pagination": {Ref: "#/components/schemas/Pagination"},
+				},
+				Required: []string{"items", "pagination"},
+			}
+		case r.List:
+			data = &Schema{Type: "array", Items: data}
+		}
+	}
+
+	return &Response{
+		Description: r.Summary,
+		Content: map[string]MediaType{"application/json": {Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"status":  {Type: "string", Enum: []string{"ok"}},
+				"message": {Type: "string"},
+				"data":    data,
+			},
+			Required: []string{"status", "message", "data"},
+		}}},
+	}
+}
+
+// body drops the fields a handler takes from the path, like the id of
+// PATCH /blogs/:id, and the required list of a partial update. The
+// component is left alone, other routes share it.
+func (b *Builder) body(schema *Schema, pathParams []string, partial bool) *Schema {
+	object := schema
+	if schema.Ref != "" {
+		object = b.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+
+	drop := map[string]bool{}
+	for _, name := range pathParams {
+		if _, ok := object.Properties[name]; ok {
+			drop[name] = true
+		}
+	}
+	if len(drop) == 0 && (!partial || len(object.Required) == 0) {
+		return schema
+	}
+
+	inline := *object
+	inline.Properties = map[string]*Schema{}
+	for name, prop := range object.Properties {
+		if !drop[name] {
+			inline.Properties[name] = prop
+		}
+	}
+	inline.Required = nil
+	if !partial {
+		for _, name := range object.Required {
+			if !drop[name] {
+				inline.Required = append(inline.Required, name)
+			}
+		}
+	}
+	return &inline
+}
+
+// jsonFields marks the multipart fields that carry JSON, like topic_ids,
+// which handlers decode from the form value.
+func (b *Builder) jsonFields(schema *Schema) map[string]Encoding {
+	if schema.Ref != "" {
+		schema = b.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	encoding := map[string]Encoding{}
+	for name, prop := range schema.Properties {
+		if prop.Type == "array" || prop.Type == "object" || prop.Ref != "" {
+			encoding[name] = Encoding{ContentType: "application/json"}
+		}
+	}
+	if len(encoding) == 0 {
+		return nil
+	}
+	return encoding
+}
+
+func errorResponseName(code int) string {
+	switch code {
+	case http.StatusBadRequest:
+		return "BadRequest"
+	case http.StatusUnauthorized:
+		return "Unauthorized"
+	case http.StatusForbidden:
+		return "Forbidden"
+	case http.StatusNotFound:
+		return "NotFound"
+	case http.StatusConflict:
+		return "Conflict"
+	case http.StatusPreconditionFailed:
+		return "PreconditionFailed"
+	case http.StatusUnprocessableEntity:
+		return "UnprocessableEntity"
+	case http.StatusServiceUnavailable:
+		return "ServiceUnavailable"
+	default:
+		return "InternalServerError"
+	}
+}
+
+// openAPIPath turns /blogs/:id and /uploads/*filepath into /blogs/{id} and
+// /uploads/{filepath}, returning the parameter names.
+func openAPIPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			params = append(params, s[1:])
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable id such as getApiBlogsById from the route.
+func operationID(method, ginPath string) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(method))
+	for _, s := range strings.Split(ginPath, "/") {
+		if s == "" {
+			continue
+		}
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			id.WriteString("By")
+			s = s[1:]
+		}
+		upper := true
+		for _, r := range s {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				upper = true
+				continue
+			}
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			id.WriteRune(r)
+		}
+	}
+	return id.String()
+}
@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Diff compares the document with the routes gin registered. missing lists
+// the registered routes the document leaves out, stale the documented ones
+// nothing serves, both as "METHOD /path" in the document's path syntax.
+func Diff(doc *Document, routes gin.RoutesInfo) (missing, stale []string) {
+	registered := map[string]bool{}
+	for _, r := range routes {
+		method := r.Method
+		//? r.Static registers HEAD next to GET, documenting GET covers both
+		if method == http.MethodHead {
+			method = http.MethodGet
+		}
+		path, _ := openAPIPath(r.Path)
+		registered[method+" "+path] = true
+	}
+
+	documented := map[string]bool{}
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	for route := range registered {
+		if !documented[route] {
+			missing = append(missing, route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	return missing, stale
+}
@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"mime/multipart"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// schemas turns Go types into schemas, registering every named struct once
+// under components/schemas.
+type schemas struct {
+	components map[string]*Schema
+}
+
+// fieldNames selects the tag a body's field names come from.
+type fieldNames int
+
+const (
+	jsonNames fieldNames = iota
+	// formNames reads the form tag, for multipart bodies that handlers parse
+	// field by field.
+	formNames
+)
+
+// of returns the schema of t, a $ref for named structs.
+func (s *schemas) of(t reflect.Type) *Schema {
+	return s.ofNamed(t, jsonNames)
+}
+
+func (s *schemas) ofNamed(t reflect.Type, names fieldNames) *Schema {
+	if t.Kind() == reflect.Pointer {
+		schema := s.ofNamed(t.Elem(), names)
+		if schema.Ref != "" || schema.Format == "binary" {
+			return schema
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == fileHeaderType:
+		return &Schema{Type: "string", Format: "binary"}
+	case t.Name() == "DeletedAt" && t.PkgPath() == "gorm.io/gorm":
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t, names)
+		}
+		name := componentName(t)
+		if _, ok := s.components[name]; !ok {
+			//? registered before the fields so self references terminate
+			s.components[name] = &Schema{}
+			*s.components[name] = *s.object(t, names)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		//? interfaces and the like accept any value
+		return &Schema{}
+	}
+}
+
+// object lists the exported fields of a struct, flattening embedded ones the
+// way encoding/json does.
+func (s *schemas) object(t reflect.Type, names fieldNames) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := s.object(embedded, names)
+				for name, prop := range inner.Properties {
+					schema.Properties[name] = prop
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+
+		name, ok := fieldName(f, names)
+		if !ok {
+			continue
+		}
+		prop := s.of(f.Type)
+		rules := validationRules(f)
+		if values := oneOf(rules); values != nil {
+			enum := *prop
+			enum.Enum = values
+			prop = &enum
+		}
+		schema.Properties[name] = prop
+		if hasRule(rules, "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// fieldName returns the wire name of a field, false when it is not sent.
+func fieldName(f reflect.StructField, names fieldNames) (string, bool) {
+	tags := []string{"json"}
+	if names == formNames {
+		tags = []string{"form", "json"}
+	}
+	for _, key := range tags {
+		tag, ok := f.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return f.Name, true
+}
+
+// validationRules merges the validate and binding tags, the two ways DTOs
+// declare their rules.
+func validationRules(f reflect.StructField) []string {
+	var rules []string
+	for _, key := range []string{"validate", "binding"} {
+		if tag := f.Tag.Get(key); tag != "" {
+			//? dive starts the rules of the elements, they do not apply to the field
+			tag, _, _ = strings.Cut(tag, ",dive")
+			rules = append(rules, strings.Split(tag, ",")...)
+		}
+	}
+	return rules
+}
+
+func hasRule(rules []string, rule string) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func oneOf(rules []string) []string {
+	for _, r := range rules {
+		if values, ok := strings.CutPrefix(r, "oneof="); ok {
+			return strings.Fields(values)
+		}
+	}
+	return nil
+}
+
+// componentName qualifies a type with its package, e.g. blog.BlogResponse,
+// since several modules reuse names like CreateRequest.
+func componentName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
@@ -6,29 +6,28 @@ import (
 )
 
 type CreateProjectRequest struct {
-	Title         string `validate:"required"`
-	Description   string `validate:"required"`
-	ImageFile     *multipart.FileHeader
-	RepositoryUrl *string
-	Summary       string `validate:"required"`
-	IsPublished   string `validate:"required,oneof=Y N"`
-	Slug          string `validate:"required"`
-	TechnologyIds []int
-	ContentImages []string
+	Title         string                `form:"title" validate:"required"`
+	Description   string                `form:"description" validate:"required"`
+	ImageFile     *multipart.FileHeader `form:"image_file"`
+	RepositoryUrl *string               `form:"repository_url"`
+	Summary       string                `form:"summary" validate:"required"`
+	Slug          string                `form:"slug" validate:"required"`
+	TechnologyIds []int                 `form:"technology_ids"`
+	ContentImages []string              `form:"project_images"`
 }
 
 type UpdateProjectRequest struct {
-	Id            int                   `validate:"required"`
-	Title         string                `validate:"required"`
-	Description   string                `validate:"required"`
-	ImageFile     *multipart.FileHeader `validate:"omitempty"`
-	RepositoryUrl *string
-	Summary       string                     `validate:"required"`
-	IsPublished   string                     `validate:"required,oneof=Y N"`
-	Slug          string                     `validate:"required"`
-	IsHighlight   string                     `validate:"required,oneof=Y N"`
-	TechnologyIds []ProjectTechUpdatePayload `json:"technology_ids" validate:"required,dive"`
-	ProjectImages []string                   `json:"project_images" validate:"required,dive"`
+	Id            int                        `form:"id" validate:"required"`
+	Title         string                     `form:"title" validate:"required"`
+	Description   string                     `form:"description" validate:"required"`
+	ImageFile     *multipart.FileHeader      `form:"image_file" validate:"omitempty"`
+	RepositoryUrl *string                    `form:"repository_url"`
+	Summary       string                     `form:"summary" validate:"required"`
+	Slug          string                     `form:"slug" validate:"required"`
+	IsHighlight   string                     `form:"is_highlight" validate:"required,oneof=Y N"`
+	TechnologyIds []ProjectTechUpdatePayload `form:"technology_ids" json:"technology_ids" validate:"required,dive"`
+	ProjectImages []string                   `form:"project_images" json:"project_images" validate:"required,dive"`
+	Version       int                        `form:"version" validate:"required"`
 }
 
 type CreateProjectDTO struct {
@@ -45,11 +44,13 @@ type CreateProjectDTO struct {
 	Status               string
 	Slug                 string
 	IsHighlight          bool
+	OwnerID              *int
 	PublishedAt          *time.Time
 }
 
 type UpdateProjectDTO struct {
 	Id            int
+	Version       int
 	Title         string
 	Description   string
 	RepositoryUrl *string
@@ -59,7 +60,6 @@ type UpdateProjectDTO struct {
 	Status        string
 	Slug          string
 	IsHighlight   string
-	PublishedAt   *time.Time
 }
 
 type ProjectResponse struct {
@@ -73,6 +73,9 @@ type ProjectResponse struct {
 	Status        string  `json:"status"`
 	Slug          string  `json:"slug"`
 	IsHighlight   bool    `json:"is_highlight"`
+	OwnerID       *int    `json:"owner_id"`
+	ReviewerID    *int    `json:"reviewer_id"`
+	Version       int     `json:"version"`
 	PublishedAt   *string `json:"published_at"`
 	CreatedAt     string  `json:"created_at"`
 }
@@ -92,6 +95,9 @@ type RawProjectRelationResponse struct {
 	Title               string     `json:"title"`
 	Slug                string     `json:"slug"`
 	IsHighlight         bool       `json:"is_highlight"`
+	OwnerID             *int       `json:"owner_id"`
+	ReviewerID          *int       `json:"reviewer_id"`
+	Version             int        `json:"version"`
 	Description         string     `json:"description"`
 	ImageUrl            string     `json:"image_url"`
 	ImageFileName       string     `json:"image_file_name"`
@@ -113,6 +119,9 @@ type ProjectRelationResponse struct {
 	Summary       string                    `json:"summary"`
 	Status        string                    `json:"status"`
 	IsHighlight   bool                      `json:"is_highlight"`
+	OwnerID       *int                      `json:"owner_id"`
+	ReviewerID    *int                      `json:"reviewer_id"`
+	Version       int                       `json:"version"`
 	PublishedAt   *string                   `json:"published_at"`
 	CreatedAt     string                    `json:"created_at"`
 	StatisticID   int                       `json:"statistic_id"`
@@ -151,6 +160,7 @@ type ProjectUpdateResponse struct {
 	Status        string  `json:"status"`
 	Slug          string  `json:"slug"`
 	IsHighlight   bool    `json:"is_highlight"`
+	Version       int     `json:"version"`
 	PublishedAt   *string `json:"published_at"`
 }
 
@@ -195,8 +205,19 @@ type ProjectDeleteRequest struct {
 }
 
 type ProjectChangeStatusRequest struct {
-	ID     int    `json:"id" binding:"required"`
-	Status string `json:"status" binding:"required,oneof=Published Unpublished"`
+	ID      int     `json:"id" binding:"required"`
+	Status  string  `json:"status" binding:"required,oneof=Draft InReview Approved Published"`
+	Comment *string `json:"comment"`
+}
+
+type ProjectAssignReviewerRequest struct {
+	ID         int  `json:"id" binding:"required"`
+	ReviewerID *int `json:"reviewer_id"`
+}
+
+type ProjectCommentRequest struct {
+	ID      int    `json:"id" binding:"required"`
+	Comment string `json:"comment" binding:"required"`
 }
 
 type ProjectChangeStatusResponse struct {
@@ -212,6 +233,7 @@ type GetAllProjectParams struct {
 	Sort        string
 	Order       string
 	Title       string
+	Slug        string
 	Status      string
 	PublishedAt []string
 	CreatedAt   []string
@@ -234,6 +256,9 @@ func ToProjectResponse(p Project) ProjectResponse {
 		Status:        p.Status,
 		Slug:          p.Slug,
 		IsHighlight:   p.IsHighlight,
+		OwnerID:       p.OwnerID,
+		ReviewerID:    p.ReviewerID,
+		Version:       p.Version,
 		PublishedAt:   publishedAtPointer,
 		CreatedAt:     p.CreatedAt.Format("2006-01-02"),
 	}
@@ -255,6 +280,7 @@ func ToProjectUpdateResponse(p Project) ProjectUpdateResponse {
 		Summary:       p.Summary,
 		Status:        p.Status,
 		Slug:          p.Slug,
+		Version:       p.Version,
 		PublishedAt:   publishedAtPointer,
 	}
 }
@@ -2,43 +2,49 @@ package project
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
-	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 )
 
 type handler struct {
-	service Service
+	service     Service
+	userService user.Service
+	upload      config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-
-	//* Create project_technology repo & service
-	projectTechRepo := project_technology.NewRepository(db)
-	projectTechService := project_technology.NewService(projectTechRepo)
-
-	//* Create project_content_image repo & service
-	projectImagesRepo := project_content_image.NewRepository(db)
-	projectImagesService := project_content_image.NewService(projectImagesRepo)
-
-	//* Create statistic repo & service
-	statisticRepo := statistic.NewRepository(db)
-	statisticService := statistic.NewService(statisticRepo)
-
-	projectRepo := NewRepository(db)
-	projectService := NewService(projectTechService, projectImagesService, statisticService, projectRepo, db)
-
-	h := handler{service: projectService}
+func RegisterRoutes(r *gin.RouterGroup, service Service, userService user.Service, upload config.UploadConfig) {
+	h := handler{service: service, userService: userService, upload: upload}
 
 	project := r.Group("/projects")
 	{
 		project.GET("", h.GetAll)
 		project.GET("/:id", h.GetProjectByIdWithRelations)
+		project.GET("/:id/reviews", h.GetProjectReviews)
 		project.POST("/store", h.CreateProject)
 		project.POST("/update", h.UpdateProject)
 		project.POST("/update-statistic", h.UpdateProjectStatistic)
 		project.POST("/delete", h.DeleteProject)
 		project.POST("/change-status", h.ChangeStatusProject)
+		project.POST("/assign-reviewer", h.AssignReviewerProject)
+		project.POST("/comment", h.CommentProject)
+	}
+}
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, userService user.Service, upload config.UploadConfig) {
+	h := handler{service: service, userService: userService, upload: upload}
+
+	project := r.Group("/projects")
+	{
+		project.GET("", h.GetAll)
+		project.GET("/:id", h.GetProjectByIdWithRelations)
+		project.POST("", h.CreateProject)
+		project.PATCH("/:id", h.PatchProject)
+		project.DELETE("/:id", h.DeleteProjectById)
+		project.GET("/:id/reviews", h.GetProjectReviews)
+		project.PATCH("/:id/statistic", h.PatchProjectStatistic)
+		project.POST("/:id/status", h.ChangeStatusProjectById)
+		project.PUT("/:id/reviewer", h.AssignReviewerProjectById)
+		project.POST("/:id/comments", h.CommentProjectById)
 	}
 }
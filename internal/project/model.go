@@ -18,6 +18,9 @@ type Project struct {
 	Status        string  `json:"status"`
 	Slug          string  `json:"slug"`
 	IsHighlight   bool    `json:"is_highlight"`
+	OwnerID       *int    `json:"owner_id"`
+	ReviewerID    *int    `json:"reviewer_id"`
+	Version       int     `json:"version" gorm:"not null;default:1"`
 	PublishedAt   *time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 )
 
@@ -21,6 +22,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	order := c.DefaultQuery("order", "id")
 	//? Filters
 	title := c.DefaultQuery("title", "")
+	slug := c.DefaultQuery("slug", "")
 	status := c.DefaultQuery("status", "")
 	published_at := c.DefaultQuery("published_at", "")
 	created_at := c.DefaultQuery("created_at", "")
@@ -41,6 +43,7 @@ func (h *handler) GetAll(c *gin.Context) {
 		Sort:        sort,
 		Order:       order,
 		Title:       title,
+		Slug:        slug,
 		Status:      status,
 		PublishedAt: publishedAtRange,
 		CreatedAt:   createdAtRange,
@@ -52,9 +55,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllProjects(params)
+	data, total_records, err := h.service.GetAllProjects(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -66,11 +69,12 @@ func (h *handler) GetProjectByIdWithRelations(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetProjectByIdWithRelations(id)
+	data, err := h.service.GetProjectByIdWithRelations(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success get data", data)
 }
 
@@ -78,14 +82,13 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	image_file, err := c.FormFile("image_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "image_file")
-		errors = utils.GenerateFieldErrorResponse("image_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("image_file", "{0} is required", "image_file")
 		return nil, errors, err
 	}
 
@@ -100,8 +103,7 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 		// Step 3: Validate size
 		if image_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "image_file")
-			errors := utils.GenerateFieldErrorResponse("image_file", err_name)
+			errors := utils.GenerateFieldErrorResponse("image_file", "{0} exceeds max size", "image_file")
 			return nil, errors, err
 		}
 	}
@@ -112,7 +114,6 @@ func (h *handler) ValidateImage(c *gin.Context, validationCheck []string) (file
 func (h *handler) CreateProject(c *gin.Context) {
 	title := c.PostForm("title")
 	description := c.PostForm("description")
-	is_published := c.PostForm("is_published") // Y or N
 	repository_url := c.PostForm("repository_url")
 	summary := c.PostForm("summary")
 	slug := c.PostForm("slug")
@@ -146,24 +147,28 @@ func (h *handler) CreateProject(c *gin.Context) {
 		ImageFile:     image_file,
 		RepositoryUrl: &repository_url,
 		Summary:       summary,
-		IsPublished:   is_published,
 		TechnologyIds: technology_ids,
 		ContentImages: project_images,
 		Slug:          slug,
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.CreateProject(req)
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.CreateProject(c.Request.Context(), actor, req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateProject(c *gin.Context) {
@@ -174,25 +179,78 @@ func (h *handler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	is_published := c.PostForm("is_published") // Y or N
-	repository_url := c.PostForm("repository_url")
-	summary := c.PostForm("summary")
-	slug := c.PostForm("slug")
-	is_highlight := c.PostForm("is_highlight") // Y or N
+	h.updateProject(c, UpdateProjectRequest{Id: id, RepositoryUrl: new(string)})
+}
 
-	var technologyIds []ProjectTechUpdatePayload
-	if err := json.Unmarshal([]byte(c.PostForm("technology_ids")), &technologyIds); err != nil {
-		utils.Error(c, http.StatusBadRequest, "Invalid technology_ids format")
+// PatchProject updates the fields the form names and keeps the others.
+func (h *handler) PatchProject(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetProjectByIdWithRelations(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	var project_images []string
-	if err := json.Unmarshal([]byte(c.PostForm("project_images")), &project_images); err != nil {
-		utils.Error(c, http.StatusBadRequest, "Invalid project_images format")
+	technologyIds := make([]ProjectTechUpdatePayload, 0, len(current.Technologies))
+	for _, technology := range current.Technologies {
+		technologyIds = append(technologyIds, ProjectTechUpdatePayload{TechID: technology.TechID})
+	}
+	project_images := make([]string, 0, len(current.ContentImages))
+	for _, image := range current.ContentImages {
+		project_images = append(project_images, image.ImageUrl)
+	}
+
+	h.updateProject(c, UpdateProjectRequest{
+		Id:            id,
+		Title:         current.Title,
+		Description:   current.Description,
+		RepositoryUrl: current.RepositoryUrl,
+		Summary:       current.Summary,
+		TechnologyIds: technologyIds,
+		ProjectImages: project_images,
+		Slug:          current.Slug,
+		IsHighlight:   utils.BoolToYN(current.IsHighlight),
+		Version:       current.Version,
+	})
+}
+
+// updateProject overlays the form values on req before saving it, provided
+// the project is still at the version the client sent.
+func (h *handler) updateProject(c *gin.Context, req UpdateProjectRequest) {
+	req.Title = utils.PostFormOr(c, "title", req.Title)
+	req.Description = utils.PostFormOr(c, "description", req.Description)
+	if repository_url, ok := c.GetPostForm("repository_url"); ok {
+		req.RepositoryUrl = &repository_url
+	}
+	req.Summary = utils.PostFormOr(c, "summary", req.Summary)
+	req.Slug = utils.PostFormOr(c, "slug", req.Slug)
+	req.IsHighlight = utils.PostFormOr(c, "is_highlight", req.IsHighlight) // Y or N
+	version, ok := utils.RequestVersion(c, req.Version)
+	if !ok {
 		return
 	}
+	req.Version = version
+
+	if value, ok := c.GetPostForm("technology_ids"); ok {
+		var technologyIds []ProjectTechUpdatePayload
+		if err := json.Unmarshal([]byte(value), &technologyIds); err != nil {
+			utils.Error(c, http.StatusBadRequest, "Invalid technology_ids format")
+			return
+		}
+		req.TechnologyIds = technologyIds
+	}
+
+	if value, ok := c.GetPostForm("project_images"); ok {
+		var project_images []string
+		if err := json.Unmarshal([]byte(value), &project_images); err != nil {
+			utils.Error(c, http.StatusBadRequest, "Invalid project_images format")
+			return
+		}
+		req.ProjectImages = project_images
+	}
 
 	validationCheck := []string{"extension", "size"}
 	image_file, errors, err := h.ValidateImage(c, validationCheck)
@@ -200,46 +258,71 @@ func (h *handler) UpdateProject(c *gin.Context) {
 		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
 		return
 	}
-
-	// Validate the struct using validator
-	req := UpdateProjectRequest{
-		Id:            id,
-		Title:         title,
-		Description:   description,
-		ImageFile:     image_file,
-		RepositoryUrl: &repository_url,
-		Summary:       summary,
-		IsPublished:   is_published,
-		TechnologyIds: technologyIds,
-		ProjectImages: project_images,
-		Slug:          slug,
-		IsHighlight:   is_highlight,
-	}
+	req.ImageFile = image_file
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.UpdateProject(req)
+	data, err := h.service.UpdateProject(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success updated data", data)
 }
 
 func (h *handler) UpdateProjectStatistic(c *gin.Context) {
 	var req ProjectStatisticUpdateRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.updateProjectStatistic(c, req)
+}
+
+// PatchProjectStatistic updates the likes or views the body names and keeps
+// the others.
+func (h *handler) PatchProjectStatistic(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetProjectByIdWithRelations(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	if current.Statistic == nil {
+		utils.Error(c, http.StatusNotFound, "project has no statistic")
+		return
+	}
+
+	likes, views := current.Statistic.Likes, current.Statistic.Views
+	req := ProjectStatisticUpdateRequest{
+		ProjectID:   id,
+		StatisticID: current.Statistic.ID,
+		Likes:       &likes,
+		Views:       &views,
+		Type:        current.Statistic.Type,
+	}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
+	req.ProjectID = id
+	req.StatisticID = current.Statistic.ID
 
-	data, err := h.service.UpdateProjectStatistic(req)
+	h.updateProjectStatistic(c, req)
+}
+
+func (h *handler) updateProjectStatistic(c *gin.Context, req ProjectStatisticUpdateRequest) {
+	data, err := h.service.UpdateProjectStatistic(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -249,15 +332,25 @@ func (h *handler) UpdateProjectStatistic(c *gin.Context) {
 func (h *handler) DeleteProject(c *gin.Context) {
 	var req ProjectDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteProject(c, req.ID)
+}
+
+func (h *handler) DeleteProjectById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteProject(c, id)
+}
 
-	data, err := h.service.DeleteProject(id)
+func (h *handler) deleteProject(c *gin.Context, id int) {
+	data, err := h.service.DeleteProject(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -266,14 +359,134 @@ func (h *handler) DeleteProject(c *gin.Context) {
 func (h *handler) ChangeStatusProject(c *gin.Context) {
 	var req ProjectChangeStatusRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.ChangeStatusProject(req)
+	h.changeStatusProject(c, req)
+}
+
+// ChangeStatusProjectById is ChangeStatusProject with the ID taken from the path.
+func (h *handler) ChangeStatusProjectById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := ProjectChangeStatusRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.changeStatusProject(c, req)
+}
+
+func (h *handler) changeStatusProject(c *gin.Context, req ProjectChangeStatusRequest) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.ChangeStatusProject(c.Request.Context(), actor, req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success change status", data)
 }
+
+func (h *handler) AssignReviewerProject(c *gin.Context) {
+	var req ProjectAssignReviewerRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.assignReviewerProject(c, req)
+}
+
+// AssignReviewerProjectById is AssignReviewerProject with the ID taken from the path.
+func (h *handler) AssignReviewerProjectById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := ProjectAssignReviewerRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.assignReviewerProject(c, req)
+}
+
+func (h *handler) assignReviewerProject(c *gin.Context, req ProjectAssignReviewerRequest) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.AssignReviewerProject(c.Request.Context(), actor, req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success assign reviewer", data)
+}
+
+func (h *handler) CommentProject(c *gin.Context) {
+	var req ProjectCommentRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.commentProject(c, req)
+}
+
+// CommentProjectById is CommentProject with the ID taken from the path.
+func (h *handler) CommentProjectById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := ProjectCommentRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.commentProject(c, req)
+}
+
+func (h *handler) commentProject(c *gin.Context, req ProjectCommentRequest) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	err := h.service.CommentProject(c.Request.Context(), actor, req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success add comment", nil)
+}
+
+func (h *handler) GetProjectReviews(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, "invalid ID")
+		return
+	}
+
+	data, err := h.service.GetProjectReviews(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success get data", data)
+}
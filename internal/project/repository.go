@@ -1,24 +1,28 @@
 package project
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllProjectParams) ([]Project, int, error)
-	FindByIdWithRelations(id int) ([]RawProjectRelationResponse, error)
-	FindById(id int) (ProjectResponse, error)
-	CreateProject(p CreateProjectDTO, tx *gorm.DB) (Project, error)
-	UpdateProject(p UpdateProjectDTO, tx *gorm.DB) (Project, error)
-	UpdateProjectStatistic(p ProjectStatisticUpdateDTO) (ProjectStatisticUpdateResponse, error)
-	DeleteProject(id int) (Project, error)
-	CheckUniqueSlug(slug string) (bool, error)
-	ChangeStatusProject(id int, status string, project ProjectResponse) (ProjectChangeStatusResponse, error)
+	FindAll(ctx context.Context, params GetAllProjectParams) ([]Project, int, error)
+	FindByIdWithRelations(ctx context.Context, id int) ([]RawProjectRelationResponse, error)
+	FindById(ctx context.Context, id int) (ProjectResponse, error)
+	CreateProject(ctx context.Context, p CreateProjectDTO, tx *gorm.DB) (Project, error)
+	UpdateProject(ctx context.Context, p UpdateProjectDTO, tx *gorm.DB) (Project, error)
+	UpdateProjectStatistic(ctx context.Context, p ProjectStatisticUpdateDTO) (ProjectStatisticUpdateResponse, error)
+	DeleteProject(ctx context.Context, id int) (Project, error)
+	CheckUniqueSlug(ctx context.Context, slug string) (bool, error)
+	ChangeStatusProject(ctx context.Context, id int, status string, project ProjectResponse, tx *gorm.DB) (ProjectChangeStatusResponse, error)
+	AssignReviewer(ctx context.Context, id int, reviewerID *int, tx *gorm.DB) error
 }
 
 type repository struct {
@@ -29,7 +33,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllProjectParams) ([]Project, int, error) {
 	var project []Project
 	var totalCount int
 
@@ -46,8 +50,14 @@ func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error)
 
 	//? field "title"
 	if params.Title != "" {
-		whereClauses = append(whereClauses, "(title LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Title+"%")
+		whereClauses = append(whereClauses, "(LOWER(title) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Title))
+	}
+
+	//? field "slug"
+	if params.Slug != "" {
+		whereClauses = append(whereClauses, "(slug = ?)")
+		queryArgs = append(queryArgs, params.Slug)
 	}
 
 	//? field "status"
@@ -57,46 +67,23 @@ func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error)
 	}
 
 	//? field "published_at"
-	if len(params.PublishedAt) == 1 {
-		// If only one date is provided, use equality
-		whereClauses = append(whereClauses, "(published_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.PublishedAt[0]+"%")
-	} else if len(params.PublishedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.PublishedAt[0])
+	if len(params.PublishedAt) > 0 {
+		clause, args, err := utils.DateFilter("published_at", params.PublishedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.PublishedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(from_date BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
-		if err != nil {
-			return nil, 0, err
-		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -110,7 +97,7 @@ func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error)
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -133,6 +120,9 @@ func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error)
 			status,
 			slug,
 			is_highlight,
+			owner_id,
+			reviewer_id,
+			version,
 			published_at,
 			created_at
 		FROM projects
@@ -153,7 +143,7 @@ func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error)
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&project).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&project).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -162,9 +152,9 @@ func (r *repository) FindAll(params GetAllProjectParams) ([]Project, int, error)
 	return project, totalCount, nil
 }
 
-func (r *repository) FindByIdWithRelations(id int) ([]RawProjectRelationResponse, error) {
+func (r *repository) FindByIdWithRelations(ctx context.Context, id int) ([]RawProjectRelationResponse, error) {
 	var data []RawProjectRelationResponse
-	err := r.db.Raw(`
+	err := r.db.WithContext(ctx).Raw(`
 		SELECT 
 			p.id, 
 			p.title, 
@@ -176,6 +166,9 @@ func (r *repository) FindByIdWithRelations(id int) ([]RawProjectRelationResponse
 			p.summary, 
 			p.status, 
 			p.is_highlight, 
+			p.owner_id,
+			p.reviewer_id,
+			p.version,
 			p.published_at,
 			p.created_at,
 			s.id as statistic_id,
@@ -207,21 +200,21 @@ func (r *repository) FindByIdWithRelations(id int) ([]RawProjectRelationResponse
 	return data, err
 }
 
-func (r *repository) FindById(id int) (ProjectResponse, error) {
+func (r *repository) FindById(ctx context.Context, id int) (ProjectResponse, error) {
 	var data ProjectResponse
-	err := r.db.Table("projects").Where("id = ?", id).Scan(&data).Error
+	err := r.db.WithContext(ctx).Table("projects").Where("id = ?", id).Scan(&data).Error
 	if data.ID == 0 {
 		return ProjectResponse{}, gorm.ErrRecordNotFound
 	}
 	return data, err
 }
 
-func (r *repository) CreateProject(p CreateProjectDTO, tx *gorm.DB) (Project, error) {
+func (r *repository) CreateProject(ctx context.Context, p CreateProjectDTO, tx *gorm.DB) (Project, error) {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	// Create Project
@@ -235,7 +228,10 @@ func (r *repository) CreateProject(p CreateProjectDTO, tx *gorm.DB) (Project, er
 		Summary:       p.Summary,
 		Status:        p.Status,
 		Slug:          p.Slug,
-		PublishedAt:   p.PublishedAt}
+		OwnerID:       p.OwnerID,
+		PublishedAt:   p.PublishedAt,
+		Version:       1,
+	}
 
 	err := db.Create(&data).Error
 
@@ -246,12 +242,12 @@ func (r *repository) CreateProject(p CreateProjectDTO, tx *gorm.DB) (Project, er
 	return data, err
 }
 
-func (r *repository) UpdateProject(p UpdateProjectDTO, tx *gorm.DB) (Project, error) {
+func (r *repository) UpdateProject(ctx context.Context, p UpdateProjectDTO, tx *gorm.DB) (Project, error) {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	//todo: UPDATE PROJECT
@@ -264,18 +260,17 @@ func (r *repository) UpdateProject(p UpdateProjectDTO, tx *gorm.DB) (Project, er
 		"image_file_name": p.ImageFileName,
 		"repository_url":  p.RepositoryUrl,
 		"summary":         p.Summary,
-		"status":          p.Status,
 		"slug":            p.Slug,
 		"is_highlight":    p.IsHighlight == "Y",
-		"published_at":    p.PublishedAt,
 		"updated_at":      time.Now(),
 	}
 
-	err := db.Model(&Project{}).Where("id = ?", p.Id).Updates(updateFields).Error
+	err := utils.UpdateVersioned(db, "projects", p.Id, p.Version, updateFields)
 
 	// Fetch the updated project to return
 	data := Project{
 		ID:            p.Id,
+		Version:       p.Version + 1,
 		Title:         p.Title,
 		Description:   p.Description,
 		ImageUrl:      p.ImageUrl,
@@ -285,21 +280,20 @@ func (r *repository) UpdateProject(p UpdateProjectDTO, tx *gorm.DB) (Project, er
 		Status:        p.Status,
 		Slug:          p.Slug,
 		IsHighlight:   p.IsHighlight == "Y",
-		PublishedAt:   p.PublishedAt,
 		UpdatedAt:     time.Now(),
 	}
 
 	return data, err
 }
 
-func (r *repository) UpdateProjectStatistic(p ProjectStatisticUpdateDTO) (ProjectStatisticUpdateResponse, error) {
+func (r *repository) UpdateProjectStatistic(ctx context.Context, p ProjectStatisticUpdateDTO) (ProjectStatisticUpdateResponse, error) {
 	data := statistic.Statistic{
 		ID:    p.StatisticID,
 		Likes: p.Likes,
 		Views: p.Views,
 		Type:  p.Type,
 	}
-	err := r.db.Where("ID = ?", p.StatisticID).Updates(&data).Error
+	err := r.db.WithContext(ctx).Where("ID = ?", p.StatisticID).Updates(&data).Error
 	if err != nil {
 		return ProjectStatisticUpdateResponse{}, err
 	}
@@ -316,16 +310,16 @@ func (r *repository) UpdateProjectStatistic(p ProjectStatisticUpdateDTO) (Projec
 	return res, nil
 }
 
-func (r *repository) DeleteProject(id int) (Project, error) {
+func (r *repository) DeleteProject(ctx context.Context, id int) (Project, error) {
 	var data Project
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Project{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return Project{}, err
 	}
 
@@ -333,10 +327,10 @@ func (r *repository) DeleteProject(id int) (Project, error) {
 	return data, nil
 }
 
-func (r *repository) CheckUniqueSlug(slug string) (bool, error) {
+func (r *repository) CheckUniqueSlug(ctx context.Context, slug string) (bool, error) {
 	var data ProjectResponse
 
-	err := r.db.Table("projects").Where("slug = ?", slug).Scan(&data).Error
+	err := r.db.WithContext(ctx).Table("projects").Where("slug = ?", slug).Scan(&data).Error
 
 	if err != nil {
 		return false, err // handle DB or syntax error
@@ -349,22 +343,33 @@ func (r *repository) CheckUniqueSlug(slug string) (bool, error) {
 	return false, nil
 }
 
-func (r *repository) ChangeStatusProject(id int, status string, project ProjectResponse) (ProjectChangeStatusResponse, error) {
+func (r *repository) ChangeStatusProject(ctx context.Context, id int, status string, project ProjectResponse, tx *gorm.DB) (ProjectChangeStatusResponse, error) {
+	var db *gorm.DB
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	} else {
+		db = r.db.WithContext(ctx)
+	}
+
 	now := time.Now()
 	var updateMap = make(map[string]interface{})
 	updateMap["status"] = status
-	if status == "Published" {
+	if status == string(editorial.StatusPublished) {
 		updateMap["published_at"] = now
 	}
-	err := r.db.Model(&Project{}).Where("id = ?", id).Updates(updateMap).Error
-
-	if err != nil {
-		return ProjectChangeStatusResponse{}, err
+	//? only update from the status the transition was checked against, so a
+	//? concurrent transition cannot be overwritten
+	result := db.Model(&Project{}).Where("id = ? AND status = ?", id, project.Status).Updates(updateMap)
+	if result.Error != nil {
+		return ProjectChangeStatusResponse{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ProjectChangeStatusResponse{}, editorial.ErrStatusChanged(project.Status)
 	}
 
 	// Return the updated data
 	var publishedAtStringPtr *string
-	if status == "Published" {
+	if status == string(editorial.StatusPublished) {
 		publishedAtString := now.Format("2006-01-02 15:04:05")
 		publishedAtStringPtr = &publishedAtString
 	}
@@ -377,3 +382,14 @@ func (r *repository) ChangeStatusProject(id int, status string, project ProjectR
 
 	return updatedData, nil
 }
+
+func (r *repository) AssignReviewer(ctx context.Context, id int, reviewerID *int, tx *gorm.DB) error {
+	var db *gorm.DB
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	} else {
+		db = r.db.WithContext(ctx)
+	}
+
+	return db.Model(&Project{}).Where("id = ?", id).Update("reviewer_id", reviewerID).Error
+}
@@ -2,53 +2,66 @@ package project
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"net/http"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_content_image"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project_technology"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAllProjects(params GetAllProjectParams) ([]ProjectResponse, int, error)
-	GetProjectByIdWithRelations(id int) (ProjectRelationResponse, error)
-	GetProjectById(id int) (ProjectResponse, error)
-	CreateProject(p CreateProjectRequest) (ProjectResponse, error)
-	UpdateProject(p UpdateProjectRequest) (ProjectUpdateResponse, error)
-	UpdateProjectStatistic(p ProjectStatisticUpdateRequest) (ProjectStatisticUpdateResponse, error)
-	DeleteProject(id int) (Project, error)
-	ChangeStatusProject(req ProjectChangeStatusRequest) (ProjectChangeStatusResponse, error)
+	GetAllProjects(ctx context.Context, params GetAllProjectParams) ([]ProjectResponse, int, error)
+	GetProjectByIdWithRelations(ctx context.Context, id int) (ProjectRelationResponse, error)
+	GetProjectById(ctx context.Context, id int) (ProjectResponse, error)
+	CreateProject(ctx context.Context, actor user.Actor, p CreateProjectRequest) (ProjectResponse, error)
+	UpdateProject(ctx context.Context, p UpdateProjectRequest) (ProjectUpdateResponse, error)
+	UpdateProjectStatistic(ctx context.Context, p ProjectStatisticUpdateRequest) (ProjectStatisticUpdateResponse, error)
+	DeleteProject(ctx context.Context, id int) (Project, error)
+	ChangeStatusProject(ctx context.Context, actor user.Actor, req ProjectChangeStatusRequest) (ProjectChangeStatusResponse, error)
+	AssignReviewerProject(ctx context.Context, actor user.Actor, req ProjectAssignReviewerRequest) (ProjectResponse, error)
+	CommentProject(ctx context.Context, actor user.Actor, req ProjectCommentRequest) error
+	GetProjectReviews(ctx context.Context, id int) ([]editorial.EventResponse, error)
 }
 
 type service struct {
 	projectTechService   project_technology.Service
 	projectImagesService project_content_image.Service
 	statisticService     statistic.Service
+	editorialService     editorial.Service
+	userService          user.Service
 	projectRepo          Repository
 	db                   *gorm.DB
+	storage              utils.Storage
 }
 
 func NewService(
 	projectTechSvc project_technology.Service,
 	projctImagesSvc project_content_image.Service,
 	statisticSvc statistic.Service,
+	editorialSvc editorial.Service,
+	userSvc user.Service,
 	r Repository,
 	db *gorm.DB,
+	storage utils.Storage,
 ) Service {
 	return &service{
 		projectTechService:   projectTechSvc,
 		projectImagesService: projctImagesSvc,
 		statisticService:     statisticSvc,
+		editorialService:     editorialSvc,
+		userService:          userSvc,
 		projectRepo:          r,
 		db:                   db,
+		storage:              storage,
 	}
 }
 
-func (s *service) GetAllProjects(params GetAllProjectParams) ([]ProjectResponse, int, error) {
-	datas, total, err := s.projectRepo.FindAll(params)
+func (s *service) GetAllProjects(ctx context.Context, params GetAllProjectParams) ([]ProjectResponse, int, error) {
+	datas, total, err := s.projectRepo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -60,8 +73,8 @@ func (s *service) GetAllProjects(params GetAllProjectParams) ([]ProjectResponse,
 	return result, total, nil
 }
 
-func (s *service) GetProjectByIdWithRelations(id int) (ProjectRelationResponse, error) {
-	data, err := s.projectRepo.FindByIdWithRelations(id)
+func (s *service) GetProjectByIdWithRelations(ctx context.Context, id int) (ProjectRelationResponse, error) {
+	data, err := s.projectRepo.FindByIdWithRelations(ctx, id)
 	if err != nil {
 		return ProjectRelationResponse{}, err
 	}
@@ -101,6 +114,9 @@ func (s *service) GetProjectByIdWithRelations(id int) (ProjectRelationResponse,
 				Summary:       row.Summary,
 				Status:        row.Status,
 				IsHighlight:   row.IsHighlight,
+				OwnerID:       row.OwnerID,
+				ReviewerID:    row.ReviewerID,
+				Version:       row.Version,
 				PublishedAt:   publishedAtPointer,
 				CreatedAt:     row.CreatedAt.Format("2006-01-02 15:04:05"),
 				StatisticID:   row.StatisticID,
@@ -152,144 +168,126 @@ func (s *service) GetProjectByIdWithRelations(id int) (ProjectRelationResponse,
 	return result, nil
 }
 
-func (s *service) GetProjectById(id int) (ProjectResponse, error) {
-	data, err := s.projectRepo.FindById(id)
+func (s *service) GetProjectById(ctx context.Context, id int) (ProjectResponse, error) {
+	data, err := s.projectRepo.FindById(ctx, id)
 	if err != nil {
 		return ProjectResponse{}, err
 	}
 	return data, nil
 }
 
-func (s *service) CreateProject(p CreateProjectRequest) (ProjectResponse, error) {
+func (s *service) CreateProject(ctx context.Context, actor user.Actor, p CreateProjectRequest) (ProjectResponse, error) {
 	//todo: Check Is Unique Slug
 	slugVal := utils.StringToSlug(p.Slug)
-	is_unique_slug, err := s.projectRepo.CheckUniqueSlug(slugVal)
+	is_unique_slug, err := s.projectRepo.CheckUniqueSlug(ctx, slugVal)
 	if err != nil {
 		return ProjectResponse{}, err
 	}
 	if !is_unique_slug {
-		err = fmt.Errorf("slug %s already exists", slugVal)
+		err = utils.NewError("slug {0} already exists", slugVal)
 		return ProjectResponse{}, err
 	}
 
 	//todo: Check Technology Ids
-	if err := s.projectTechService.CountTechnologiesByIDs(p.TechnologyIds); err != nil {
+	if err := s.projectTechService.CountTechnologiesByIDs(ctx, p.TechnologyIds); err != nil {
 		return ProjectResponse{}, err
 	}
 
 	//todo: Check Project Images
 	if len(p.ContentImages) > 0 {
-		if err := s.projectImagesService.CountUnusedProjectImages(p.ContentImages); err != nil {
+		if err := s.projectImagesService.CountUnusedProjectImages(ctx, p.ContentImages); err != nil {
 			return ProjectResponse{}, err
 		}
 	}
 
-	tx := s.db.Begin()
+	var data Project
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		//todo: Create Statistic
+		zero := 0
+		statisticPayload := statistic.CreateStatisticRequest{
+			Likes: &zero,
+			Views: &zero,
+			Type:  "Project"}
 
-	//todo: Create Statistic
-	zero := 0
-	statisticPayload := statistic.CreateStatisticRequest{
-		Likes: &zero,
-		Views: &zero,
-		Type:  "Project"}
-
-	statRes, err := s.statisticService.CreateStatisticWithTx(statisticPayload, tx)
-
-	if err != nil {
-		tx.Rollback()
-		return ProjectResponse{}, err
-	}
+		statRes, err := s.statisticService.CreateStatisticWithTx(ctx, statisticPayload, tx.DB)
+		if err != nil {
+			return err
+		}
 
-	//todo: Upload Image File to minio
-	imageRes, err := utils.HandlUploadFile(p.ImageFile, "project")
-	if err != nil {
-		return ProjectResponse{}, err
-	}
+		//todo: Upload Image File to minio
+		imageRes, err := s.storage.Upload(ctx, p.ImageFile, "project")
+		if err != nil {
+			return err
+		}
 
-	uploadedImage := imageRes.FileURL
-
-	var publishedAt *time.Time
-	var status string
-	if p.IsPublished == "Y" {
-		now := time.Now()
-		publishedAt = &now
-		status = "PUBLISHED"
-	} else if p.IsPublished == "N" {
-		status = "UNPUBLISHED"
-	}
-
-	payload := CreateProjectDTO{
-		StatisticID:          statRes.ID,
-		ProjectContentImages: p.ContentImages,
-		TechnologyIds:        p.TechnologyIds,
-		Title:                p.Title,
-		Description:          p.Description,
-		ImageUrl:             imageRes.FileURL,
-		ImageFileName:        imageRes.FileName,
-		RepositoryUrl:        p.RepositoryUrl,
-		Summary:              p.Summary,
-		Status:               status,
-		Slug:                 slugVal,
-		IsHighlight:          false,
-		PublishedAt:          publishedAt,
-	}
-
-	//todo: Create Project
-	data, err := s.projectRepo.CreateProject(payload, tx)
-	if err != nil {
-		tx.Rollback()
-		if uploadedImage != "" {
-			_ = utils.DeleteFromMinio(context.Background(), uploadedImage)
+		//? Delete image when the project is not saved
+		tx.AfterRollback(func(ctx context.Context) error {
+			return s.storage.Delete(ctx, imageRes.FileName)
+		})
+
+		payload := CreateProjectDTO{
+			StatisticID:          statRes.ID,
+			ProjectContentImages: p.ContentImages,
+			TechnologyIds:        p.TechnologyIds,
+			Title:                p.Title,
+			Description:          p.Description,
+			ImageUrl:             imageRes.FileURL,
+			ImageFileName:        imageRes.FileName,
+			RepositoryUrl:        p.RepositoryUrl,
+			Summary:              p.Summary,
+			Status:               string(editorial.StatusDraft),
+			Slug:                 slugVal,
+			IsHighlight:          false,
+		}
+		//? an actor no user row backs, like the seeder's, leaves the project to admins
+		if actor.UserID > 0 {
+			payload.OwnerID = &actor.UserID
 		}
-		return ProjectResponse{}, err
-	}
 
-	//todo: Bulk Create Project Technologies
-	err = s.projectTechService.BulkCreateTechnologies(p.TechnologyIds, data.ID, tx)
-	if err != nil {
-		tx.Rollback()
-		if uploadedImage != "" {
-			_ = utils.DeleteFromMinio(context.Background(), uploadedImage)
+		//todo: Create Project
+		data, err = s.projectRepo.CreateProject(ctx, payload, tx.DB)
+		if err != nil {
+			return err
 		}
-		return ProjectResponse{}, err
-	}
 
-	//todo: Batch Update Project Images
-	err = s.projectImagesService.BatchUpdateProjectImages(p.ContentImages, data.ID, tx)
-	if err != nil {
-		tx.Rollback()
-		if uploadedImage != "" {
-			_ = utils.DeleteFromMinio(context.Background(), uploadedImage)
+		//todo: Bulk Create Project Technologies
+		err = s.projectTechService.BulkCreateTechnologies(ctx, p.TechnologyIds, data.ID, tx.DB)
+		if err != nil {
+			return err
 		}
-		return ProjectResponse{}, err
-	}
 
-	//todo: Commit Transaction
-	if err := tx.Commit().Error; err != nil {
-		err = fmt.Errorf("error commit transaction")
+		//todo: Batch Update Project Images
+		return s.projectImagesService.BatchUpdateProjectImages(ctx, p.ContentImages, data.ID, tx.DB)
+	})
+	if err != nil {
 		return ProjectResponse{}, err
 	}
 
 	return ToProjectResponse(data), nil
 }
 
-func (s *service) UpdateProject(p UpdateProjectRequest) (ProjectUpdateResponse, error) {
+func (s *service) UpdateProject(ctx context.Context, p UpdateProjectRequest) (ProjectUpdateResponse, error) {
 	//todo: Get Project
-	project, err := s.GetProjectById(p.Id)
+	project, err := s.GetProjectById(ctx, p.Id)
 	if err != nil {
 		return ProjectUpdateResponse{}, err
 	}
 
+	//? fail before uploading anything, the update checks the version again
+	if err := utils.CheckVersion(p.Version, project.Version); err != nil {
+		return ProjectUpdateResponse{}, err
+	}
+
 	//todo: Check Is Unique Slug
 	slugVal := utils.StringToSlug(p.Slug)
 
 	if project.Slug != slugVal {
-		is_unique_slug, err := s.projectRepo.CheckUniqueSlug(slugVal)
+		is_unique_slug, err := s.projectRepo.CheckUniqueSlug(ctx, slugVal)
 		if err != nil {
 			return ProjectUpdateResponse{}, err
 		}
 		if !is_unique_slug {
-			err = fmt.Errorf("slug %s already exists", slugVal)
+			err = utils.NewError("slug {0} already exists", slugVal)
 			return ProjectUpdateResponse{}, err
 		}
 	}
@@ -305,114 +303,86 @@ func (s *service) UpdateProject(p UpdateProjectRequest) (ProjectUpdateResponse,
 	for _, tech := range p.TechnologyIds {
 		tech_ids = append(tech_ids, tech.TechID)
 	}
-	if err := s.projectTechService.CountTechnologiesByIDs(tech_ids); err != nil {
-		return ProjectUpdateResponse{}, err
-	}
-
-	tx := s.db.Begin()
-
-	//todo: Batch Update Technologies
-	err = s.projectTechService.BatchUpdateTechnologies(tech_ids, p.Id, tx)
-	if err != nil {
-		tx.Rollback()
-		return ProjectUpdateResponse{}, err
-	}
-
-	// todo: Sync Project Images
-	oldProjectImages, err := s.projectImagesService.SyncProjectImages(p.ProjectImages, p.Id, tx)
-
-	if err != nil {
-		tx.Rollback()
+	if err := s.projectTechService.CountTechnologiesByIDs(ctx, tech_ids); err != nil {
 		return ProjectUpdateResponse{}, err
 	}
 
-	var newFileURL string
-	var newFileName string
-
-	if p.ImageFile != nil {
-		imageRes, err := utils.HandlUploadFile(p.ImageFile, "project")
+	var data Project
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		//todo: Batch Update Technologies
+		err := s.projectTechService.BatchUpdateTechnologies(ctx, tech_ids, p.Id, tx.DB)
 		if err != nil {
-			return ProjectUpdateResponse{}, err
+			return err
 		}
 
-		newFileURL = imageRes.FileURL
-		newFileName = imageRes.FileName
-	} else {
-		newFileURL = project.ImageUrl // keep existing if not updated
-		newFileName = project.ImageFileName
-	}
-
-	var publishedAt *time.Time
-	var status string
-	var oldIsPublished string
-	if project.Status == "Published" {
-		oldIsPublished = "Y"
-	} else {
-		oldIsPublished = "N"
-	}
-
-	if oldIsPublished != p.IsPublished {
-		if p.IsPublished == "Y" {
-			now := time.Now()
-			publishedAt = &now
-			status = "Published"
-		} else if p.IsPublished == "N" {
-			status = "Unpublished"
+		// todo: Sync Project Images
+		oldProjectImages, err := s.projectImagesService.SyncProjectImages(ctx, p.ProjectImages, p.Id, tx.DB)
+		if err != nil {
+			return err
 		}
-	} else {
-		status = project.Status
-	}
 
-	payload := UpdateProjectDTO{
-		Id:            p.Id,
-		Title:         p.Title,
-		Description:   p.Description,
-		ImageUrl:      newFileURL,
-		ImageFileName: newFileName,
-		RepositoryUrl: p.RepositoryUrl,
-		Summary:       p.Summary,
-		Status:        status,
-		Slug:          slugVal,
-		IsHighlight:   p.IsHighlight,
-		PublishedAt:   publishedAt,
-	}
+		newFileURL := project.ImageUrl // keep existing if not updated
+		newFileName := project.ImageFileName
 
-	data, err := s.projectRepo.UpdateProject(payload, tx)
-	if err != nil {
-		tx.Rollback()
-		return ProjectUpdateResponse{}, err
-	}
+		if p.ImageFile != nil {
+			imageRes, err := s.storage.Upload(ctx, p.ImageFile, "project")
+			if err != nil {
+				return err
+			}
+
+			newFileURL = imageRes.FileURL
+			newFileName = imageRes.FileName
 
-	//todo: Delete Old Project Images
-	if len(oldProjectImages) > 0 {
-		slice_image_urls := []string{}
-		for _, item := range oldProjectImages {
-			slice_image_urls = append(slice_image_urls, item.ImageUrl)
+			//? the new image is dropped on failure, the replaced one only once the update is committed
+			tx.AfterRollback(func(ctx context.Context) error {
+				return s.storage.Delete(ctx, newFileName)
+			})
+			if oldFileName != "" {
+				tx.AfterCommit(func(ctx context.Context) error {
+					return s.storage.Delete(ctx, oldFileName)
+				})
+			}
+		}
+
+		payload := UpdateProjectDTO{
+			Id:            p.Id,
+			Version:       p.Version,
+			Title:         p.Title,
+			Description:   p.Description,
+			ImageUrl:      newFileURL,
+			ImageFileName: newFileName,
+			RepositoryUrl: p.RepositoryUrl,
+			Summary:       p.Summary,
+			Status:        project.Status,
+			Slug:          slugVal,
+			IsHighlight:   p.IsHighlight,
 		}
 
-		err = s.projectImagesService.BulkDeleteHardByImageUrls(slice_image_urls, tx)
+		data, err = s.projectRepo.UpdateProject(ctx, payload, tx.DB)
 		if err != nil {
-			tx.Rollback()
-			return ProjectUpdateResponse{}, err
+			return err
 		}
-	}
 
-	//todo: Commit Transaction
-	if err := tx.Commit().Error; err != nil {
-		err = fmt.Errorf("error commit transaction")
-		return ProjectUpdateResponse{}, err
-	}
+		//todo: Delete Old Project Images
+		if len(oldProjectImages) > 0 {
+			slice_image_urls := []string{}
+			for _, item := range oldProjectImages {
+				slice_image_urls = append(slice_image_urls, item.ImageUrl)
+			}
 
-	//todo: Delete Old Image
-	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+			return s.projectImagesService.BulkDeleteHardByImageUrls(ctx, slice_image_urls, tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return ProjectUpdateResponse{}, err
 	}
 
 	return ToProjectUpdateResponse(data), nil
 }
 
-func (s *service) UpdateProjectStatistic(p ProjectStatisticUpdateRequest) (ProjectStatisticUpdateResponse, error) {
-	project, err := s.GetProjectById(p.ProjectID)
+func (s *service) UpdateProjectStatistic(ctx context.Context, p ProjectStatisticUpdateRequest) (ProjectStatisticUpdateResponse, error) {
+	project, err := s.GetProjectById(ctx, p.ProjectID)
 	if err != nil {
 		return ProjectStatisticUpdateResponse{}, err
 	}
@@ -426,30 +396,156 @@ func (s *service) UpdateProjectStatistic(p ProjectStatisticUpdateRequest) (Proje
 		Type:         p.Type,
 	}
 
-	data, err := s.projectRepo.UpdateProjectStatistic(payload)
+	data, err := s.projectRepo.UpdateProjectStatistic(ctx, payload)
 	if err != nil {
 		return ProjectStatisticUpdateResponse{}, err
 	}
 	return data, nil
 }
 
-func (s *service) DeleteProject(id int) (Project, error) {
-	data, err := s.projectRepo.DeleteProject(id)
+func (s *service) DeleteProject(ctx context.Context, id int) (Project, error) {
+	data, err := s.projectRepo.DeleteProject(ctx, id)
 	if err != nil {
 		return Project{}, err
 	}
 	return data, nil
 }
 
-func (s *service) ChangeStatusProject(req ProjectChangeStatusRequest) (ProjectChangeStatusResponse, error) {
-	project, err := s.GetProjectById(req.ID)
+// participant describes the actor's part in the editorial workflow of a project.
+// Projects have no author, the user who created one owns it.
+func (s *service) participant(actor user.Actor, project ProjectResponse) editorial.Participant {
+	return editorial.Participant{
+		IsAdmin:    actor.IsAdmin(),
+		IsOwner:    ownsProject(actor.UserID, project),
+		IsReviewer: project.ReviewerID != nil && *project.ReviewerID == actor.UserID,
+	}
+}
+
+func ownsProject(userID int, project ProjectResponse) bool {
+	return project.OwnerID != nil && *project.OwnerID == userID
+}
+
+func (s *service) ChangeStatusProject(ctx context.Context, actor user.Actor, req ProjectChangeStatusRequest) (ProjectChangeStatusResponse, error) {
+	project, err := s.GetProjectById(ctx, req.ID)
 	if err != nil {
 		return ProjectChangeStatusResponse{}, err
 	}
 
-	data, err := s.projectRepo.ChangeStatusProject(req.ID, req.Status, project)
+	//todo: Validate Transition
+	from := editorial.ParseStatus(project.Status)
+	to := editorial.Status(req.Status)
+	err = s.editorialService.CheckTransition(ctx, from, to, s.participant(actor, project))
 	if err != nil {
 		return ProjectChangeStatusResponse{}, err
 	}
+
+	var data ProjectChangeStatusResponse
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		data, err = s.projectRepo.ChangeStatusProject(ctx, req.ID, string(to), project, tx.DB)
+		if err != nil {
+			return err
+		}
+
+		//todo: Record Transition
+		_, err = s.editorialService.RecordEvent(ctx, editorial.RecordEventDTO{
+			ContentType: editorial.ContentTypeProject,
+			ContentID:   req.ID,
+			UserID:      actor.UserID,
+			Action:      editorial.ActionTransition,
+			FromStatus:  from,
+			ToStatus:    to,
+			Comment:     req.Comment,
+		}, tx.DB)
+		return err
+	})
+	if err != nil {
+		return ProjectChangeStatusResponse{}, err
+	}
+
 	return data, nil
 }
+
+func (s *service) AssignReviewerProject(ctx context.Context, actor user.Actor, req ProjectAssignReviewerRequest) (ProjectResponse, error) {
+	project, err := s.GetProjectById(ctx, req.ID)
+	if err != nil {
+		return ProjectResponse{}, err
+	}
+
+	//todo: Check Ownership
+	if !actor.IsAdmin() && !ownsProject(actor.UserID, project) {
+		return ProjectResponse{}, utils.ErrForbidden
+	}
+
+	//todo: Check Reviewer
+	if req.ReviewerID != nil {
+		reviewer, err := s.userService.GetActor(ctx, *req.ReviewerID)
+		if err != nil {
+			return ProjectResponse{}, err
+		}
+		if !reviewer.CanReview() {
+			return ProjectResponse{}, utils.NewStatusError(http.StatusUnprocessableEntity, "user {0} cannot review, role must be admin or editor", *req.ReviewerID)
+		}
+		//? nobody approves their own project
+		if ownsProject(reviewer.UserID, project) {
+			return ProjectResponse{}, utils.NewStatusError(http.StatusUnprocessableEntity, "user {0} owns this content and cannot review it", *req.ReviewerID)
+		}
+	}
+
+	err = utils.WithTx(ctx, s.db, func(tx *utils.Tx) error {
+		err := s.projectRepo.AssignReviewer(ctx, req.ID, req.ReviewerID, tx.DB)
+		if err != nil {
+			return err
+		}
+
+		status := editorial.ParseStatus(project.Status)
+		_, err = s.editorialService.RecordEvent(ctx, editorial.RecordEventDTO{
+			ContentType: editorial.ContentTypeProject,
+			ContentID:   req.ID,
+			UserID:      actor.UserID,
+			Action:      editorial.ActionAssignReviewer,
+			FromStatus:  status,
+			ToStatus:    status,
+			ReviewerID:  req.ReviewerID,
+		}, tx.DB)
+		return err
+	})
+	if err != nil {
+		return ProjectResponse{}, err
+	}
+
+	project.ReviewerID = req.ReviewerID
+	return project, nil
+}
+
+func (s *service) CommentProject(ctx context.Context, actor user.Actor, req ProjectCommentRequest) error {
+	project, err := s.GetProjectById(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	who := s.participant(actor, project)
+	if !who.IsAdmin && !who.IsOwner && !who.IsReviewer {
+		return utils.ErrForbidden
+	}
+
+	status := editorial.ParseStatus(project.Status)
+	_, err = s.editorialService.RecordEvent(ctx, editorial.RecordEventDTO{
+		ContentType: editorial.ContentTypeProject,
+		ContentID:   req.ID,
+		UserID:      actor.UserID,
+		Action:      editorial.ActionComment,
+		FromStatus:  status,
+		ToStatus:    status,
+		Comment:     &req.Comment,
+	}, nil)
+	return err
+}
+
+func (s *service) GetProjectReviews(ctx context.Context, id int) ([]editorial.EventResponse, error) {
+	_, err := s.GetProjectById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.editorialService.GetHistory(ctx, editorial.ContentTypeProject, id)
+}
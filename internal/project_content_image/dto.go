@@ -9,7 +9,7 @@ type CreateProjectContentImageRequest struct {
 type UpdateProjectContentImageRequest struct {
 	ID        int                   `json:"id" validate:"required"`
 	ProjectID *int                  `json:"project_id" validate:"required"`
-	ImageFile *multipart.FileHeader `json:"image_file" validate:"required"`
+	ImageFile *multipart.FileHeader `json:"image_file"`
 }
 
 type CreateProjectContentImageDTO struct {
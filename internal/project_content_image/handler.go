@@ -2,17 +2,16 @@ package project_content_image
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
 )
 
 type handler struct {
 	service Service
+	upload  config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
-	h := handler{service: service}
+func RegisterRoutes(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
 
 	project_content_image := r.Group("/project-content-images")
 	{
@@ -23,3 +22,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		project_content_image.POST("/delete", h.DeleteProjectContentImage)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
+
+	project_content_image := r.Group("/project-content-images")
+	{
+		project_content_image.GET("", h.GetAll)
+		project_content_image.GET("/:id", h.GetProjectContentImageById)
+		project_content_image.POST("", h.CreateProjectContentImage)
+		project_content_image.PATCH("/:id", h.PatchProjectContentImage)
+		project_content_image.DELETE("/:id", h.DeleteProjectContentImageById)
+	}
+}
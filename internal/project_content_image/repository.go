@@ -1,21 +1,25 @@
 package project_content_image
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll() ([]ProjectContentImage, error)
-	FindById(id int) (ProjectContentImage, error)
-	CreateProjectContentImage(p CreateProjectContentImageDTO) (ProjectContentImage, error)
-	UpdateProjectContentImage(p UpdateProjectContentImageDTO) error
-	DeleteProjectContentImage(id int) (ProjectContentImage, error)
-	CountUnusedProjectImages(ids []string) (total int, err error)
-	BatchUpdateProjectImages(projectImages []string, project_id int, tx *gorm.DB) error
-	FindImageExist(image_urls []string, project_id int) ([]ProjectImagesFindResponse, error)
-	FindImageNotExist(image_urls []string, project_id int) ([]ProjectImagesFindResponse, error)
-	BatchUpdateImagesById(ids []int, project_id int, tx *gorm.DB) error
-	BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error
+	FindAll(ctx context.Context) ([]ProjectContentImage, error)
+	FindById(ctx context.Context, id int) (ProjectContentImage, error)
+	FindUnlinked(ctx context.Context, before time.Time) ([]ProjectContentImage, error)
+	CreateProjectContentImage(ctx context.Context, p CreateProjectContentImageDTO) (ProjectContentImage, error)
+	UpdateProjectContentImage(ctx context.Context, p UpdateProjectContentImageDTO) error
+	DeleteProjectContentImage(ctx context.Context, id int) (ProjectContentImage, error)
+	CountUnusedProjectImages(ctx context.Context, ids []string) (total int, err error)
+	BatchUpdateProjectImages(ctx context.Context, projectImages []string, project_id int, tx *gorm.DB) error
+	FindImageExist(ctx context.Context, image_urls []string, project_id int) ([]ProjectImagesFindResponse, error)
+	FindImageNotExist(ctx context.Context, image_urls []string, project_id int) ([]ProjectImagesFindResponse, error)
+	BatchUpdateImagesById(ctx context.Context, ids []int, project_id int, tx *gorm.DB) error
+	BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *gorm.DB) error
 }
 
 type repository struct {
@@ -26,46 +30,52 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll() ([]ProjectContentImage, error) {
+func (r *repository) FindAll(ctx context.Context) ([]ProjectContentImage, error) {
+	var datas []ProjectContentImage
+	err := r.db.WithContext(ctx).Find(&datas).Error
+	return datas, err
+}
+
+func (r *repository) FindUnlinked(ctx context.Context, before time.Time) ([]ProjectContentImage, error) {
 	var datas []ProjectContentImage
-	err := r.db.Find(&datas).Error
+	err := r.db.WithContext(ctx).Where("project_id IS NULL AND created_at < ?", before).Find(&datas).Error
 	return datas, err
 }
 
-func (r *repository) FindById(id int) (ProjectContentImage, error) {
+func (r *repository) FindById(ctx context.Context, id int) (ProjectContentImage, error) {
 	var data ProjectContentImage
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateProjectContentImage(p CreateProjectContentImageDTO) (ProjectContentImage, error) {
+func (r *repository) CreateProjectContentImage(ctx context.Context, p CreateProjectContentImageDTO) (ProjectContentImage, error) {
 	data := ProjectContentImage{
 		ImageUrl:      p.ImageUrl,
 		ImageFileName: p.ImageFileName}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateProjectContentImage(p UpdateProjectContentImageDTO) error {
+func (r *repository) UpdateProjectContentImage(ctx context.Context, p UpdateProjectContentImageDTO) error {
 	data := ProjectContentImage{
 		ID:            p.ID,
 		ProjectID:     p.ProjectID,
 		ImageUrl:      p.ImageUrl,
 		ImageFileName: p.ImageFileName}
-	err := r.db.Updates(&data).Error
+	err := r.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
 
-func (r *repository) DeleteProjectContentImage(id int) (ProjectContentImage, error) {
+func (r *repository) DeleteProjectContentImage(ctx context.Context, id int) (ProjectContentImage, error) {
 	var data ProjectContentImage
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return ProjectContentImage{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return ProjectContentImage{}, err
 	}
 
@@ -73,8 +83,8 @@ func (r *repository) DeleteProjectContentImage(id int) (ProjectContentImage, err
 	return data, nil
 }
 
-func (r *repository) CountUnusedProjectImages(ids []string) (total int, err error) {
-	err = r.db.Raw(`
+func (r *repository) CountUnusedProjectImages(ctx context.Context, ids []string) (total int, err error) {
+	err = r.db.WithContext(ctx).Raw(`
 		SELECT COUNT(*) FROM project_content_images 
 		WHERE image_url IN ? AND
 		project_id IS NULL AND
@@ -83,12 +93,12 @@ func (r *repository) CountUnusedProjectImages(ids []string) (total int, err erro
 	return total, err
 }
 
-func (r *repository) BatchUpdateProjectImages(projectImages []string, project_id int, tx *gorm.DB) error {
+func (r *repository) BatchUpdateProjectImages(ctx context.Context, projectImages []string, project_id int, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	err := db.Model(&ProjectContentImage{}).
@@ -104,12 +114,12 @@ func (r *repository) BatchUpdateProjectImages(projectImages []string, project_id
 	return nil
 }
 
-func (r *repository) BatchUpdateImagesById(ids []int, project_id int, tx *gorm.DB) error {
+func (r *repository) BatchUpdateImagesById(ctx context.Context, ids []int, project_id int, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	err := db.Table("project_content_images").
@@ -118,30 +128,30 @@ func (r *repository) BatchUpdateImagesById(ids []int, project_id int, tx *gorm.D
 	return err
 }
 
-func (r *repository) FindImageExist(image_urls []string, project_id int) ([]ProjectImagesFindResponse, error) {
+func (r *repository) FindImageExist(ctx context.Context, image_urls []string, project_id int) ([]ProjectImagesFindResponse, error) {
 	var data []ProjectImagesFindResponse
-	err := r.db.Table("project_content_images").
+	err := r.db.WithContext(ctx).Table("project_content_images").
 		Where("image_url IN ? AND (project_id = ? OR project_id IS NULL)", image_urls, project_id).
 		Select("id, project_id, image_url").
 		Find(&data).Error
 	return data, err
 }
 
-func (r *repository) FindImageNotExist(image_urls []string, project_id int) ([]ProjectImagesFindResponse, error) {
+func (r *repository) FindImageNotExist(ctx context.Context, image_urls []string, project_id int) ([]ProjectImagesFindResponse, error) {
 	var data []ProjectImagesFindResponse
-	err := r.db.Table("project_content_images").
+	err := r.db.WithContext(ctx).Table("project_content_images").
 		Where("project_id = ? AND image_url NOT IN ?", project_id, image_urls).
 		Select("id, project_id, image_url").
 		Find(&data).Error
 	return data, err
 }
 
-func (r *repository) BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error {
+func (r *repository) BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	// Create a raw SQL query to delete records with IDs in the slice
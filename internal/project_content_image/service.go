@@ -2,36 +2,36 @@ package project_content_image
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
+	"time"
 
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAllProjectContentImages() ([]ProjectContentImageResponse, error)
-	GetProjectContentImageById(id int) (ProjectContentImageResponse, error)
-	CreateProjectContentImage(p CreateProjectContentImageRequest) (ProjectContentImageResponse, error)
-	UpdateProjectContentImage(p UpdateProjectContentImageRequest) error
-	DeleteProjectContentImage(id int) (ProjectContentImageResponse, error)
-	CountUnusedProjectImages(ids []string) error
-	BatchUpdateProjectImages(projectImages []string, project_id int, tx *gorm.DB) error
-	SyncProjectImages(image_urls []string, project_id int, tx *gorm.DB) ([]ProjectImagesFindResponse, error)
-	BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error
+	GetAllProjectContentImages(ctx context.Context) ([]ProjectContentImageResponse, error)
+	GetProjectContentImageById(ctx context.Context, id int) (ProjectContentImageResponse, error)
+	GetUnlinkedImages(ctx context.Context, before time.Time) ([]ProjectContentImageResponse, error)
+	CreateProjectContentImage(ctx context.Context, p CreateProjectContentImageRequest) (ProjectContentImageResponse, error)
+	UpdateProjectContentImage(ctx context.Context, p UpdateProjectContentImageRequest) error
+	DeleteProjectContentImage(ctx context.Context, id int) (ProjectContentImageResponse, error)
+	CountUnusedProjectImages(ctx context.Context, ids []string) error
+	BatchUpdateProjectImages(ctx context.Context, projectImages []string, project_id int, tx *gorm.DB) error
+	SyncProjectImages(ctx context.Context, image_urls []string, project_id int, tx *gorm.DB) ([]ProjectImagesFindResponse, error)
+	BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *utils.Tx) error
 }
 
 type service struct {
-	repo Repository
+	repo    Repository
+	storage utils.Storage
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
 }
 
-func (s *service) GetAllProjectContentImages() ([]ProjectContentImageResponse, error) {
-	datas, err := s.repo.FindAll()
+func (s *service) GetAllProjectContentImages(ctx context.Context) ([]ProjectContentImageResponse, error) {
+	datas, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -43,16 +43,31 @@ func (s *service) GetAllProjectContentImages() ([]ProjectContentImageResponse, e
 	return result, nil
 }
 
-func (s *service) GetProjectContentImageById(id int) (ProjectContentImageResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetProjectContentImageById(ctx context.Context, id int) (ProjectContentImageResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return ProjectContentImageResponse{}, err
 	}
 	return ToProjectContentImageResponse(data), nil
 }
 
-func (s *service) CreateProjectContentImage(p CreateProjectContentImageRequest) (ProjectContentImageResponse, error) {
-	imageRes, err := utils.HandlUploadFile(p.ImageFile, "project")
+// GetUnlinkedImages returns the images uploaded before the given time that
+// never got attached to a project, e.g. because the editor was abandoned.
+func (s *service) GetUnlinkedImages(ctx context.Context, before time.Time) ([]ProjectContentImageResponse, error) {
+	datas, err := s.repo.FindUnlinked(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ProjectContentImageResponse
+	for _, p := range datas {
+		result = append(result, ToProjectContentImageResponse(p))
+	}
+	return result, nil
+}
+
+func (s *service) CreateProjectContentImage(ctx context.Context, p CreateProjectContentImageRequest) (ProjectContentImageResponse, error) {
+	imageRes, err := s.storage.Upload(ctx, p.ImageFile, "project")
 	if err != nil {
 		return ProjectContentImageResponse{}, err
 	}
@@ -63,17 +78,17 @@ func (s *service) CreateProjectContentImage(p CreateProjectContentImageRequest)
 		ImageFileName: imageRes.FileName,
 	}
 
-	data, err := s.repo.CreateProjectContentImage(payload)
+	data, err := s.repo.CreateProjectContentImage(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), imageRes.FileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), imageRes.FileName)
 		return ProjectContentImageResponse{}, err
 	}
 	return ToProjectContentImageResponse(data), nil
 }
 
-func (s *service) UpdateProjectContentImage(p UpdateProjectContentImageRequest) error {
+func (s *service) UpdateProjectContentImage(ctx context.Context, p UpdateProjectContentImageRequest) error {
 	//todo: Get Data
-	projectImage, err := s.repo.FindById(p.ID)
+	projectImage, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
@@ -89,7 +104,7 @@ func (s *service) UpdateProjectContentImage(p UpdateProjectContentImageRequest)
 
 	//todo: Upload File
 	if p.ImageFile != nil {
-		imageRes, err := utils.HandlUploadFile(p.ImageFile, "project")
+		imageRes, err := s.storage.Upload(ctx, p.ImageFile, "project")
 		if err != nil {
 			return err
 		}
@@ -108,49 +123,49 @@ func (s *service) UpdateProjectContentImage(p UpdateProjectContentImageRequest)
 		ImageFileName: newFileName,
 	}
 
-	err = s.repo.UpdateProjectContentImage(payload)
+	err = s.repo.UpdateProjectContentImage(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), newFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), newFileName)
 		return err
 	}
 
 	//todo: Delete Old Image
 	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), oldFileName)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteProjectContentImage(id int) (ProjectContentImageResponse, error) {
-	data, err := s.repo.DeleteProjectContentImage(id)
+func (s *service) DeleteProjectContentImage(ctx context.Context, id int) (ProjectContentImageResponse, error) {
+	data, err := s.repo.DeleteProjectContentImage(ctx, id)
 	if err != nil {
 		return ProjectContentImageResponse{}, err
 	}
 
-	_ = utils.DeleteFromMinio(context.Background(), data.ImageFileName)
+	_ = s.storage.Delete(context.WithoutCancel(ctx), data.ImageFileName)
 
 	return ToProjectContentImageResponse(data), nil
 }
 
-func (s *service) CountUnusedProjectImages(ids []string) error {
-	total, err := s.repo.CountUnusedProjectImages(ids)
+func (s *service) CountUnusedProjectImages(ctx context.Context, ids []string) error {
+	total, err := s.repo.CountUnusedProjectImages(ctx, ids)
 	if err != nil {
 		return err
 	}
 
 	if total != len(ids) {
-		err := fmt.Errorf("some project_images not found in database")
+		err := utils.NewError("some project_images not found in database")
 		return err
 	}
 	return nil
 }
 
-func (s *service) BatchUpdateProjectImages(projectImages []string, project_id int, tx *gorm.DB) error {
-	return s.repo.BatchUpdateProjectImages(projectImages, project_id, tx)
+func (s *service) BatchUpdateProjectImages(ctx context.Context, projectImages []string, project_id int, tx *gorm.DB) error {
+	return s.repo.BatchUpdateProjectImages(ctx, projectImages, project_id, tx)
 }
 
-func (s *service) SyncProjectImages(
+func (s *service) SyncProjectImages(ctx context.Context,
 	image_urls []string,
 	project_id int,
 	tx *gorm.DB) (
@@ -158,7 +173,7 @@ func (s *service) SyncProjectImages(
 	err error,
 ) {
 	// 1. Cek apakah ada image baru di konten yang belum ada di database
-	imageExist, err := s.repo.FindImageExist(image_urls, project_id)
+	imageExist, err := s.repo.FindImageExist(ctx, image_urls, project_id)
 	if err != nil {
 		return imageNotExist, err
 	}
@@ -178,13 +193,13 @@ func (s *service) SyncProjectImages(
 	}
 
 	if len(imageIDsToUpdate) > 0 {
-		if err := s.repo.BatchUpdateImagesById(imageIDsToUpdate, project_id, tx); err != nil {
+		if err := s.repo.BatchUpdateImagesById(ctx, imageIDsToUpdate, project_id, tx); err != nil {
 			return imageNotExist, err
 		}
 	}
 
 	// 4. Hapus image lama yang tidak ada di konten lagi
-	imageNotExist, err = s.repo.FindImageNotExist(image_urls, project_id)
+	imageNotExist, err = s.repo.FindImageNotExist(ctx, image_urls, project_id)
 	if err != nil {
 		return imageNotExist, err
 	}
@@ -192,20 +207,24 @@ func (s *service) SyncProjectImages(
 	return imageNotExist, nil
 }
 
-func (s *service) BulkDeleteHardByImageUrls(image_urls []string, tx *gorm.DB) error {
-	err := s.repo.BulkDeleteHardByImageUrls(image_urls, tx)
+func (s *service) BulkDeleteHardByImageUrls(ctx context.Context, image_urls []string, tx *utils.Tx) error {
+	err := s.repo.BulkDeleteHardByImageUrls(ctx, image_urls, tx.DB)
 	if err != nil {
 		return err
 	}
 
-	bucketName := os.Getenv("MINIO_BUCKET")
-	images_key, _ := utils.MinioParseURLToImageKey(image_urls, bucketName)
-	batchSize := 3
+	//? files go only once the rows are gone for good
+	tx.AfterCommit(func(ctx context.Context) error {
+		bucketName := s.storage.Bucket()
+		images_key, _ := utils.MinioParseURLToImageKey(image_urls, bucketName)
+		batchSize := 3
 
-	err = utils.DeleteBulkImagesInBatches(bucketName, images_key, batchSize)
-	if err != nil {
-		log.Fatalf("Failed to delete images: %v", err)
-	}
+		err := s.storage.DeleteBatch(ctx, images_key, batchSize)
+		if err != nil {
+			utils.LoggerFrom(ctx).WithField("images", images_key).Error("failed to delete images: ", err)
+		}
+		return nil
+	})
 
 	return nil
 }
@@ -2,16 +2,13 @@ package project_technology
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	project_technologies := r.Group("/project-technologies")
@@ -23,3 +20,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		project_technologies.POST("/delete", h.DeleteProjectTechnology)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	project_technologies := r.Group("/project-technologies")
+	{
+		project_technologies.GET("", h.GetAll)
+		project_technologies.GET("/:id", h.GetProjectTechnologyById)
+		project_technologies.POST("", h.CreateProjectTechnology)
+		project_technologies.PATCH("/:id", h.PatchProjectTechnology)
+		project_technologies.DELETE("/:id", h.DeleteProjectTechnologyById)
+	}
+}
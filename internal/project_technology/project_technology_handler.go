@@ -9,9 +9,9 @@ import (
 )
 
 func (h *handler) GetAll(c *gin.Context) {
-	data, err := h.service.GetAllProjectTechnologies()
+	data, err := h.service.GetAllProjectTechnologies(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
@@ -23,9 +23,9 @@ func (h *handler) GetProjectTechnologyById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetProjectTechnologyById(id)
+	data, err := h.service.GetProjectTechnologyById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -35,29 +35,54 @@ func (h *handler) CreateProjectTechnology(c *gin.Context) {
 	// Validate the struct using validator
 	var req CreateProjectTechnologyRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CreateProjectTechnology(req)
+	data, err := h.service.CreateProjectTechnology(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateProjectTechnology(c *gin.Context) {
 	var req UpdateProjectTechnologyRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	err := h.service.UpdateProjectTechnology(req)
+	h.updateProjectTechnology(c, req)
+}
+
+// PatchProjectTechnology updates the fields the body names and keeps the others.
+func (h *handler) PatchProjectTechnology(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetProjectTechnologyById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UpdateProjectTechnologyRequest{ID: id, ProjectID: current.ProjectID, TechnologyID: current.TechnologyID}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.updateProjectTechnology(c, req)
+}
+
+func (h *handler) updateProjectTechnology(c *gin.Context, req UpdateProjectTechnologyRequest) {
+	err := h.service.UpdateProjectTechnology(c.Request.Context(), req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -67,15 +92,25 @@ func (h *handler) UpdateProjectTechnology(c *gin.Context) {
 func (h *handler) DeleteProjectTechnology(c *gin.Context) {
 	var req ProjectTechnologyDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteProjectTechnology(c, req.ID)
+}
+
+func (h *handler) DeleteProjectTechnologyById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteProjectTechnology(c, id)
+}
 
-	data, err := h.service.DeleteProjectTechnology(id)
+func (h *handler) deleteProjectTechnology(c *gin.Context, id int) {
+	data, err := h.service.DeleteProjectTechnology(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -1,19 +1,20 @@
 package project_technology
 
 import (
+	"context"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll() ([]ProjectTechnology, error)
-	FindById(id int) (ProjectTechnology, error)
-	CreateProjectTechnology(p CreateProjectTechnologyRequest) (ProjectTechnology, error)
-	UpdateProjectTechnology(p UpdateProjectTechnologyRequest) error
-	DeleteProjectTechnology(id int) (ProjectTechnology, error)
-	CountTechnologiesByIDs(ids []int) (total int, err error)
-	BulkCreateTechnologies(tech_ids []ProjectTechnology, tx *gorm.DB) error
-	FindExistingProjectTechnologies(project_id int) ([]ProjectTechnologyExistingResponse, error)
-	BulkHardDeleteTechnology(tech_ids []int, project_id int, tx *gorm.DB) error
+	FindAll(ctx context.Context) ([]ProjectTechnology, error)
+	FindById(ctx context.Context, id int) (ProjectTechnology, error)
+	CreateProjectTechnology(ctx context.Context, p CreateProjectTechnologyRequest) (ProjectTechnology, error)
+	UpdateProjectTechnology(ctx context.Context, p UpdateProjectTechnologyRequest) error
+	DeleteProjectTechnology(ctx context.Context, id int) (ProjectTechnology, error)
+	CountTechnologiesByIDs(ctx context.Context, ids []int) (total int, err error)
+	BulkCreateTechnologies(ctx context.Context, tech_ids []ProjectTechnology, tx *gorm.DB) error
+	FindExistingProjectTechnologies(ctx context.Context, project_id int) ([]ProjectTechnologyExistingResponse, error)
+	BulkHardDeleteTechnology(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error
 }
 
 type repository struct {
@@ -24,45 +25,45 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll() ([]ProjectTechnology, error) {
+func (r *repository) FindAll(ctx context.Context) ([]ProjectTechnology, error) {
 	var datas []ProjectTechnology
-	err := r.db.Find(&datas).Error
+	err := r.db.WithContext(ctx).Find(&datas).Error
 	return datas, err
 }
 
-func (r *repository) FindById(id int) (ProjectTechnology, error) {
+func (r *repository) FindById(ctx context.Context, id int) (ProjectTechnology, error) {
 	var data ProjectTechnology
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateProjectTechnology(p CreateProjectTechnologyRequest) (ProjectTechnology, error) {
+func (r *repository) CreateProjectTechnology(ctx context.Context, p CreateProjectTechnologyRequest) (ProjectTechnology, error) {
 	data := ProjectTechnology{
 		ProjectID:    p.ProjectID,
 		TechnologyID: p.TechnologyID}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateProjectTechnology(p UpdateProjectTechnologyRequest) error {
+func (r *repository) UpdateProjectTechnology(ctx context.Context, p UpdateProjectTechnologyRequest) error {
 	data := ProjectTechnology{
 		ID:           p.ID,
 		ProjectID:    p.ProjectID,
 		TechnologyID: p.TechnologyID}
-	err := r.db.Updates(&data).Error
+	err := r.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
 
-func (r *repository) DeleteProjectTechnology(id int) (ProjectTechnology, error) {
+func (r *repository) DeleteProjectTechnology(ctx context.Context, id int) (ProjectTechnology, error) {
 	var data ProjectTechnology
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return ProjectTechnology{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return ProjectTechnology{}, err
 	}
 
@@ -70,8 +71,8 @@ func (r *repository) DeleteProjectTechnology(id int) (ProjectTechnology, error)
 	return data, nil
 }
 
-func (r *repository) CountTechnologiesByIDs(ids []int) (total int, err error) {
-	err = r.db.Raw(`
+func (r *repository) CountTechnologiesByIDs(ctx context.Context, ids []int) (total int, err error) {
+	err = r.db.WithContext(ctx).Raw(`
 		SELECT COUNT(*) FROM technologies 
 		WHERE id IN ? AND
 		deleted_at IS NULL
@@ -79,12 +80,12 @@ func (r *repository) CountTechnologiesByIDs(ids []int) (total int, err error) {
 	return total, err
 }
 
-func (r *repository) BulkCreateTechnologies(tech_ids []ProjectTechnology, tx *gorm.DB) error {
+func (r *repository) BulkCreateTechnologies(ctx context.Context, tech_ids []ProjectTechnology, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	if err := db.Create(&tech_ids).Error; err != nil {
@@ -94,21 +95,21 @@ func (r *repository) BulkCreateTechnologies(tech_ids []ProjectTechnology, tx *go
 	return nil
 }
 
-func (r *repository) FindExistingProjectTechnologies(project_id int) ([]ProjectTechnologyExistingResponse, error) {
+func (r *repository) FindExistingProjectTechnologies(ctx context.Context, project_id int) ([]ProjectTechnologyExistingResponse, error) {
 	var data []ProjectTechnologyExistingResponse
-	err := r.db.Table("project_technologies").
+	err := r.db.WithContext(ctx).Table("project_technologies").
 		Where("project_id = ?", project_id).
 		Select("id, project_id, technology_id").
 		Find(&data).Error
 	return data, err
 }
 
-func (r *repository) BulkHardDeleteTechnology(tech_ids []int, project_id int, tx *gorm.DB) error {
+func (r *repository) BulkHardDeleteTechnology(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	// Create a raw SQL query to delete records with IDs in the slice
@@ -1,21 +1,22 @@
 package project_technology
 
 import (
-	"fmt"
+	"context"
 	"slices"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAllProjectTechnologies() ([]ProjectTechnologyResponse, error)
-	GetProjectTechnologyById(id int) (ProjectTechnologyResponse, error)
-	CreateProjectTechnology(p CreateProjectTechnologyRequest) (ProjectTechnologyResponse, error)
-	UpdateProjectTechnology(p UpdateProjectTechnologyRequest) error
-	DeleteProjectTechnology(id int) (ProjectTechnology, error)
-	CountTechnologiesByIDs(ids []int) error
-	BulkCreateTechnologies(tech_ids []int, project_id int, tx *gorm.DB) error
-	BatchUpdateTechnologies(tech_ids []int, project_id int, tx *gorm.DB) error
+	GetAllProjectTechnologies(ctx context.Context) ([]ProjectTechnologyResponse, error)
+	GetProjectTechnologyById(ctx context.Context, id int) (ProjectTechnologyResponse, error)
+	CreateProjectTechnology(ctx context.Context, p CreateProjectTechnologyRequest) (ProjectTechnologyResponse, error)
+	UpdateProjectTechnology(ctx context.Context, p UpdateProjectTechnologyRequest) error
+	DeleteProjectTechnology(ctx context.Context, id int) (ProjectTechnology, error)
+	CountTechnologiesByIDs(ctx context.Context, ids []int) error
+	BulkCreateTechnologies(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error
+	BatchUpdateTechnologies(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error
 }
 
 type service struct {
@@ -26,8 +27,8 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) GetAllProjectTechnologies() ([]ProjectTechnologyResponse, error) {
-	datas, err := s.repo.FindAll()
+func (s *service) GetAllProjectTechnologies(ctx context.Context) ([]ProjectTechnologyResponse, error) {
+	datas, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -39,29 +40,29 @@ func (s *service) GetAllProjectTechnologies() ([]ProjectTechnologyResponse, erro
 	return result, nil
 }
 
-func (s *service) GetProjectTechnologyById(id int) (ProjectTechnologyResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetProjectTechnologyById(ctx context.Context, id int) (ProjectTechnologyResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return ProjectTechnologyResponse{}, err
 	}
 	return ToProjectTechnologyResponse(data), nil
 }
 
-func (s *service) CreateProjectTechnology(p CreateProjectTechnologyRequest) (ProjectTechnologyResponse, error) {
-	data, err := s.repo.CreateProjectTechnology(p)
+func (s *service) CreateProjectTechnology(ctx context.Context, p CreateProjectTechnologyRequest) (ProjectTechnologyResponse, error) {
+	data, err := s.repo.CreateProjectTechnology(ctx, p)
 	if err != nil {
 		return ProjectTechnologyResponse{}, err
 	}
 	return ToProjectTechnologyResponse(data), nil
 }
 
-func (s *service) UpdateProjectTechnology(p UpdateProjectTechnologyRequest) error {
-	_, err := s.repo.FindById(p.ID)
+func (s *service) UpdateProjectTechnology(ctx context.Context, p UpdateProjectTechnologyRequest) error {
+	_, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.UpdateProjectTechnology(p)
+	err = s.repo.UpdateProjectTechnology(ctx, p)
 	if err != nil {
 		return err
 	}
@@ -69,28 +70,28 @@ func (s *service) UpdateProjectTechnology(p UpdateProjectTechnologyRequest) erro
 	return nil
 }
 
-func (s *service) DeleteProjectTechnology(id int) (ProjectTechnology, error) {
-	data, err := s.repo.DeleteProjectTechnology(id)
+func (s *service) DeleteProjectTechnology(ctx context.Context, id int) (ProjectTechnology, error) {
+	data, err := s.repo.DeleteProjectTechnology(ctx, id)
 	if err != nil {
 		return ProjectTechnology{}, err
 	}
 	return data, nil
 }
 
-func (s *service) CountTechnologiesByIDs(ids []int) error {
-	total, err := s.repo.CountTechnologiesByIDs(ids)
+func (s *service) CountTechnologiesByIDs(ctx context.Context, ids []int) error {
+	total, err := s.repo.CountTechnologiesByIDs(ctx, ids)
 	if err != nil {
 		return err
 	}
 
 	if total != len(ids) {
-		err := fmt.Errorf("some technology_ids not found in database")
+		err := utils.NewError("some technology_ids not found in database")
 		return err
 	}
 	return nil
 }
 
-func (s *service) BulkCreateTechnologies(tech_ids []int, project_id int, tx *gorm.DB) error {
+func (s *service) BulkCreateTechnologies(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error {
 	var technologies []ProjectTechnology
 
 	for _, technology_id := range tech_ids {
@@ -100,11 +101,11 @@ func (s *service) BulkCreateTechnologies(tech_ids []int, project_id int, tx *gor
 		})
 	}
 
-	return s.repo.BulkCreateTechnologies(technologies, tx)
+	return s.repo.BulkCreateTechnologies(ctx, technologies, tx)
 }
 
-func (s *service) BatchUpdateTechnologies(tech_ids []int, project_id int, tx *gorm.DB) error {
-	existingProjectTechs, err := s.repo.FindExistingProjectTechnologies(project_id)
+func (s *service) BatchUpdateTechnologies(ctx context.Context, tech_ids []int, project_id int, tx *gorm.DB) error {
+	existingProjectTechs, err := s.repo.FindExistingProjectTechnologies(ctx, project_id)
 	if err != nil {
 		return err
 	}
@@ -122,7 +123,7 @@ func (s *service) BatchUpdateTechnologies(tech_ids []int, project_id int, tx *go
 		return nil
 	}
 
-	err = s.repo.BulkHardDeleteTechnology(existing_tech_ids, project_id, tx)
+	err = s.repo.BulkHardDeleteTechnology(ctx, existing_tech_ids, project_id, tx)
 	if err != nil {
 		return err
 	}
@@ -135,7 +136,7 @@ func (s *service) BatchUpdateTechnologies(tech_ids []int, project_id int, tx *go
 		})
 	}
 
-	err = s.repo.BulkCreateTechnologies(newTechIds, tx)
+	err = s.repo.BulkCreateTechnologies(ctx, newTechIds, tx)
 	if err != nil {
 		return err
 	}
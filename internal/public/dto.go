@@ -420,3 +420,13 @@ type BlogByIdResponse struct {
 	PublishedAt    *string `json:"published_at"`
 	CreatedAt      string  `json:"created_at"`
 }
+
+// HighlightOnly reports whether only highlighted blogs were requested.
+func (p BlogPublicParams) HighlightOnly() bool {
+	return p.isHighlightParam == "Y"
+}
+
+// HighlightOnly reports whether only highlighted projects were requested.
+func (p ProjectPublicParams) HighlightOnly() bool {
+	return p.isHighlightParam == "Y"
+}
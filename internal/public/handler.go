@@ -2,20 +2,13 @@ package public
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	//* Create author repo & service
-	// authorRepo := author.NewRepository(db)
-	// authorService := author.NewService(authorRepo)
-
-	publicRepo := NewRepository(db)
-	service := NewService(publicRepo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	r.GET("/profile", h.GetProfile)
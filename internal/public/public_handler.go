@@ -10,9 +10,9 @@ import (
 )
 
 func (h *handler) GetProfile(c *gin.Context) {
-	data, err := h.service.GetProfile()
+	data, err := h.service.GetProfile(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -62,9 +62,9 @@ func (h *handler) GetPublicBlogs(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetPublicBlogs(params)
+	data, total_records, err := h.service.GetPublicBlogs(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -72,27 +72,27 @@ func (h *handler) GetPublicBlogs(c *gin.Context) {
 
 func (h *handler) GetPublicBlogBySlug(c *gin.Context) {
 	slug := c.Param("slug")
-	data, err := h.service.GetPublicBlogBySlug(slug)
+	data, err := h.service.GetPublicBlogBySlug(c.Request.Context(), slug)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
 }
 
 func (h *handler) GetPublicTestimonials(c *gin.Context) {
-	data, err := h.service.GetPublicTestimonials()
+	data, err := h.service.GetPublicTestimonials(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
 }
 
 func (h *handler) GetPublicTopics(c *gin.Context) {
-	data, err := h.service.GetPublicTopics()
+	data, err := h.service.GetPublicTopics(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
@@ -122,9 +122,9 @@ func (h *handler) GetPublicProjects(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetPublicProjects(params)
+	data, total_records, err := h.service.GetPublicProjects(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -132,36 +132,36 @@ func (h *handler) GetPublicProjects(c *gin.Context) {
 
 func (h *handler) GetPublicProjectBySlug(c *gin.Context) {
 	slug := c.Param("slug")
-	data, err := h.service.GetPublicProjectBySlug(slug)
+	data, err := h.service.GetPublicProjectBySlug(c.Request.Context(), slug)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
 }
 
 func (h *handler) GetPublicTechnologies(c *gin.Context) {
-	data, err := h.service.GetPublicTechnologies()
+	data, err := h.service.GetPublicTechnologies(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
 }
 
 func (h *handler) GetPublicAuthors(c *gin.Context) {
-	data, err := h.service.GetPublicAuthors()
+	data, err := h.service.GetPublicAuthors(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
 }
 
 func (h *handler) GetPublicExperiences(c *gin.Context) {
-	data, err := h.service.GetPublicExperiences()
+	data, err := h.service.GetPublicExperiences(c.Request.Context())
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get all data", data)
@@ -170,13 +170,13 @@ func (h *handler) GetPublicExperiences(c *gin.Context) {
 func (h *handler) UpdatePublicProjectStatistic(c *gin.Context) {
 	var req ProjectStatisticUpdatePublicRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.UpdatePublicProjectStatistic(req)
+	data, err := h.service.UpdatePublicProjectStatistic(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -186,13 +186,13 @@ func (h *handler) UpdatePublicProjectStatistic(c *gin.Context) {
 func (h *handler) UpdatePublicBlogStatistic(c *gin.Context) {
 	var req BlogStatisticUpdatePublicRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.UpdatePublicBlogStatistic(req)
+	data, err := h.service.UpdatePublicBlogStatistic(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -1,45 +1,60 @@
 package public
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	GetTechnologiesPublic() ([]TechnologyProfilePublicResponse, error)
-	GetAboutPublic() (AboutPublicResponse, error)
-	GetCurrentWork() (CurrentWorkPublicResponse, error)
-	GetExperiencesPublic() ([]ExperiencesPublicResponse, error)
-	GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogPaginatePublicRaw, int, error)
-	GetRawPublicBlogs(params BlogPublicParams, uniquePaginateBlogIDs []int) ([]BlogPublicRaw, error)
-	GetPublicBlogBySlug(slug string) ([]SingleBlogPublicRaw, error)
-	GetRawPublicBlogTopics(params BlogPublicParams, uniqueBlogIDs []int) ([]BlogTopicPublicRaw, error)
-	GetPublicTestimonials() ([]TestimonialPublicResponse, error)
-	GetPublicTopics() ([]TopicPublicResponse, error)
-	GetRawPublicPaginateProjects(params ProjectPublicParams) ([]ProjectPaginatePublicRaw, int, error)
-	GetRawPublicProjectTechnologies(params ProjectPublicParams, uniqueProjectIDs []int) ([]ProjectTechnologyPublicRaw, error)
-	GetPublicProjectBySlug(slug string) ([]SingleProjectPublicRaw, error)
-	GetPublicTechnologies() ([]TechnologyPublicResponse, error)
-	GetPublicAuthors() ([]AuthorPublicResponse, error)
-	FindProjectById(id int) (ProjectByIdResponse, error)
-	UpdatePublicProjectStatistic(p ProjectStatisticUpdatePublicDTO) (ProjectStatisticUpdatePubblicResponse, error)
-	FindBlogById(id int) (BlogByIdResponse, error)
-	UpdatePublicBlogStatistic(p BlogStatisticUpdatePublicDTO) (BlogStatisticUpdatePubblicResponse, error)
+	GetTechnologiesPublic(ctx context.Context) ([]TechnologyProfilePublicResponse, error)
+	GetAboutPublic(ctx context.Context) (AboutPublicResponse, error)
+	GetCurrentWork(ctx context.Context) (CurrentWorkPublicResponse, error)
+	GetExperiencesPublic(ctx context.Context) ([]ExperiencesPublicResponse, error)
+	GetRawPublicPaginateBlogs(ctx context.Context, params BlogPublicParams) ([]BlogPaginatePublicRaw, int, error)
+	GetRawPublicBlogs(ctx context.Context, params BlogPublicParams, uniquePaginateBlogIDs []int) ([]BlogPublicRaw, error)
+	GetPublicBlogBySlug(ctx context.Context, slug string) ([]SingleBlogPublicRaw, error)
+	GetRawPublicBlogTopics(ctx context.Context, params BlogPublicParams, uniqueBlogIDs []int) ([]BlogTopicPublicRaw, error)
+	GetPublicTestimonials(ctx context.Context) ([]TestimonialPublicResponse, error)
+	GetPublicTopics(ctx context.Context) ([]TopicPublicResponse, error)
+	GetRawPublicPaginateProjects(ctx context.Context, params ProjectPublicParams) ([]ProjectPaginatePublicRaw, int, error)
+	GetRawPublicProjectTechnologies(ctx context.Context, params ProjectPublicParams, uniqueProjectIDs []int) ([]ProjectTechnologyPublicRaw, error)
+	GetPublicProjectBySlug(ctx context.Context, slug string) ([]SingleProjectPublicRaw, error)
+	GetPublicTechnologies(ctx context.Context) ([]TechnologyPublicResponse, error)
+	GetPublicAuthors(ctx context.Context) ([]AuthorPublicResponse, error)
+	FindProjectById(ctx context.Context, id int) (ProjectByIdResponse, error)
+	UpdatePublicProjectStatistic(ctx context.Context, p ProjectStatisticUpdatePublicDTO) (ProjectStatisticUpdatePubblicResponse, error)
+	FindBlogById(ctx context.Context, id int) (BlogByIdResponse, error)
+	UpdatePublicBlogStatistic(ctx context.Context, p BlogStatisticUpdatePublicDTO) (BlogStatisticUpdatePubblicResponse, error)
+	FindStatisticById(ctx context.Context, id int) (statistic.Statistic, error)
 }
 
 type repository struct {
 	db *gorm.DB
+	// readDB serves the published content reads and may be a replica.
+	// Statistic updates and the lookups around them stay on db.
+	readDB *gorm.DB
 }
 
-func NewRepository(db *gorm.DB) Repository {
-	return &repository{db: db}
+func NewRepository(db, readDB *gorm.DB) Repository {
+	return &repository{db: db, readDB: readDB}
 }
 
-func (r *repository) GetTechnologiesPublic() ([]TechnologyProfilePublicResponse, error) {
+// blogOrderColumn qualifies the sort field; views live on the joined statistics row.
+func blogOrderColumn(order string) string {
+	if order == "views" {
+		return "s.views"
+	}
+	return "b." + order
+}
+
+func (r *repository) GetTechnologiesPublic(ctx context.Context) ([]TechnologyProfilePublicResponse, error) {
 	var data []TechnologyProfilePublicResponse
 
 	rawQuery := `
@@ -47,7 +62,7 @@ func (r *repository) GetTechnologiesPublic() ([]TechnologyProfilePublicResponse,
 		FROM technologies
 		WHERE is_major = ? AND deleted_at IS NULL
 	`
-	err := r.db.Raw(rawQuery, 1).Scan(&data).Error
+	err := r.readDB.WithContext(ctx).Raw(rawQuery, true).Scan(&data).Error
 
 	if err != nil {
 		return []TechnologyProfilePublicResponse{}, err
@@ -55,10 +70,10 @@ func (r *repository) GetTechnologiesPublic() ([]TechnologyProfilePublicResponse,
 	return data, nil
 }
 
-func (r *repository) GetAboutPublic() (AboutPublicResponse, error) {
+func (r *repository) GetAboutPublic(ctx context.Context) (AboutPublicResponse, error) {
 	var data AboutPublicResponse
 
-	err := r.db.Table("abouts").Where("is_used = ? AND deleted_at IS NULL", 1).First(&data).Error
+	err := r.readDB.WithContext(ctx).Table("abouts").Where("is_used = ? AND deleted_at IS NULL", true).First(&data).Error
 
 	if err != nil {
 		return AboutPublicResponse{}, err
@@ -66,7 +81,7 @@ func (r *repository) GetAboutPublic() (AboutPublicResponse, error) {
 	return data, nil
 }
 
-func (r *repository) GetCurrentWork() (CurrentWorkPublicResponse, error) {
+func (r *repository) GetCurrentWork(ctx context.Context) (CurrentWorkPublicResponse, error) {
 	var data CurrentWorkPublicResponse
 
 	rawQuery := `
@@ -75,7 +90,7 @@ func (r *repository) GetCurrentWork() (CurrentWorkPublicResponse, error) {
 		WHERE is_current = ? AND deleted_at IS NULL
 		LIMIT 1
 	`
-	err := r.db.Raw(rawQuery, 1).Scan(&data).Error
+	err := r.readDB.WithContext(ctx).Raw(rawQuery, true).Scan(&data).Error
 	if err != nil {
 		return CurrentWorkPublicResponse{}, err
 	}
@@ -83,11 +98,11 @@ func (r *repository) GetCurrentWork() (CurrentWorkPublicResponse, error) {
 	return data, nil
 }
 
-func (r *repository) GetExperiencesPublic() ([]ExperiencesPublicResponse, error) {
+func (r *repository) GetExperiencesPublic(ctx context.Context) ([]ExperiencesPublicResponse, error) {
 	var data []ExperiencesPublicResponse
 
 	// Build the query
-	query := r.db.Table("experiences").Where("deleted_at IS NULL")
+	query := r.readDB.WithContext(ctx).Table("experiences").Where("deleted_at IS NULL")
 
 	sort := "DESC"
 	order := "from_date"
@@ -106,7 +121,10 @@ func (r *repository) GetExperiencesPublic() ([]ExperiencesPublicResponse, error)
 	return data, nil
 }
 
-func (r *repository) GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogPaginatePublicRaw, int, error) {
+func (r *repository) GetRawPublicPaginateBlogs(ctx context.Context, params BlogPublicParams) ([]BlogPaginatePublicRaw, int, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetRawPublicPaginateBlogs")
+	defer span.End()
+
 	var datas []BlogPaginatePublicRaw
 	var totalCount int
 
@@ -136,18 +154,18 @@ func (r *repository) GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogP
 
 	//? field "status"
 	whereClauses = append(whereClauses, "b.status = ?")
-	queryArgs = append(queryArgs, "Published")
+	queryArgs = append(queryArgs, string(editorial.StatusPublished))
 
 	//? field "search"
 	if params.Search != "" {
-		whereClauses = append(whereClauses, "(b.title LIKE ? OR b.summary LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Search+"%", "%"+params.Search+"%")
+		whereClauses = append(whereClauses, "(LOWER(b.title) LIKE ? OR LOWER(b.summary) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Search), utils.ContainsPattern(params.Search))
 	}
 
 	//? field "is_highlight"
 	if params.isHighlightParam == "Y" {
 		whereClauses = append(whereClauses, "b.is_highlight = ?")
-		queryArgs = append(queryArgs, 1)
+		queryArgs = append(queryArgs, true)
 	}
 
 	//? Construct the WHERE clause
@@ -161,7 +179,7 @@ func (r *repository) GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogP
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.readDB.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -174,7 +192,7 @@ func (r *repository) GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogP
 	//! Build Query Paginate
 
 	//? Construct the ORDER BY clause
-	orderBySQL := fmt.Sprintf("ORDER BY b.%s %s", params.Order, params.Sort)
+	orderBySQL := fmt.Sprintf("ORDER BY %s %s", blogOrderColumn(params.Order), params.Sort)
 
 	// Construct the final SQL query with LIMIT and OFFSET
 	finalSQL := fmt.Sprintf(`
@@ -188,7 +206,7 @@ func (r *repository) GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogP
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&datas).Error
+	err = r.readDB.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&datas).Error
 
 	if err != nil {
 		return []BlogPaginatePublicRaw{}, 0, err
@@ -197,7 +215,10 @@ func (r *repository) GetRawPublicPaginateBlogs(params BlogPublicParams) ([]BlogP
 	return datas, totalCount, nil
 }
 
-func (r *repository) GetRawPublicBlogs(params BlogPublicParams, uniquePaginateBlogIDs []int) ([]BlogPublicRaw, error) {
+func (r *repository) GetRawPublicBlogs(ctx context.Context, params BlogPublicParams, uniquePaginateBlogIDs []int) ([]BlogPublicRaw, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetRawPublicBlogs")
+	defer span.End()
+
 	var datas []BlogPublicRaw
 
 	rawTopicSQL := `
@@ -254,7 +275,7 @@ func (r *repository) GetRawPublicBlogs(params BlogPublicParams, uniquePaginateBl
 	}
 
 	//? Construct the ORDER BY clause
-	orderBySQL := fmt.Sprintf("ORDER BY b.%s %s", params.Order, params.Sort)
+	orderBySQL := fmt.Sprintf("ORDER BY %s %s", blogOrderColumn(params.Order), params.Sort)
 
 	// Construct the final SQL query with LIMIT and OFFSET
 	finalSQL := fmt.Sprintf(`
@@ -262,7 +283,7 @@ func (r *repository) GetRawPublicBlogs(params BlogPublicParams, uniquePaginateBl
 		%s
 		%s`, rawTopicSQL, whereSQL, orderBySQL)
 
-	err := r.db.Raw(finalSQL, queryArgs...).Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&datas).Error
 	if err != nil {
 		return []BlogPublicRaw{}, err
 	}
@@ -270,7 +291,10 @@ func (r *repository) GetRawPublicBlogs(params BlogPublicParams, uniquePaginateBl
 	return datas, nil
 }
 
-func (r *repository) GetRawPublicBlogTopics(params BlogPublicParams, uniqueBlogIDs []int) ([]BlogTopicPublicRaw, error) {
+func (r *repository) GetRawPublicBlogTopics(ctx context.Context, params BlogPublicParams, uniqueBlogIDs []int) ([]BlogTopicPublicRaw, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetRawPublicBlogTopics")
+	defer span.End()
+
 	var datas []BlogTopicPublicRaw
 
 	rawTopicSQL := `
@@ -299,7 +323,7 @@ func (r *repository) GetRawPublicBlogTopics(params BlogPublicParams, uniqueBlogI
 	}
 
 	//? Construct the ORDER BY clause
-	orderBySQL := fmt.Sprintf("ORDER BY b.%s %s", params.Order, params.Sort)
+	orderBySQL := fmt.Sprintf("ORDER BY %s %s", blogOrderColumn(params.Order), params.Sort)
 
 	// Construct the final SQL query with LIMIT and OFFSET
 	finalSQL := fmt.Sprintf(`
@@ -307,7 +331,7 @@ func (r *repository) GetRawPublicBlogTopics(params BlogPublicParams, uniqueBlogI
 		%s
 		%s`, rawTopicSQL, whereSQL, orderBySQL)
 
-	err := r.db.Raw(finalSQL, queryArgs...).Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&datas).Error
 	if err != nil {
 		return []BlogTopicPublicRaw{}, err
 	}
@@ -315,7 +339,10 @@ func (r *repository) GetRawPublicBlogTopics(params BlogPublicParams, uniqueBlogI
 	return datas, nil
 }
 
-func (r *repository) GetPublicBlogBySlug(slug string) ([]SingleBlogPublicRaw, error) {
+func (r *repository) GetPublicBlogBySlug(ctx context.Context, slug string) ([]SingleBlogPublicRaw, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetPublicBlogBySlug")
+	defer span.End()
+
 	var datas []SingleBlogPublicRaw
 
 	// Build the raw SQL query
@@ -362,7 +389,7 @@ func (r *repository) GetPublicBlogBySlug(slug string) ([]SingleBlogPublicRaw, er
 	`
 
 	// Execute the raw SQL query
-	err := r.db.Raw(rawSQL, slug, "Published").Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Raw(rawSQL, slug, string(editorial.StatusPublished)).Scan(&datas).Error
 
 	if err != nil {
 		return []SingleBlogPublicRaw{}, err
@@ -375,19 +402,22 @@ func (r *repository) GetPublicBlogBySlug(slug string) ([]SingleBlogPublicRaw, er
 	return datas, nil
 }
 
-func (r *repository) GetPublicTestimonials() ([]TestimonialPublicResponse, error) {
+func (r *repository) GetPublicTestimonials(ctx context.Context) ([]TestimonialPublicResponse, error) {
 	var datas []TestimonialPublicResponse
-	err := r.db.Table("testimonials").Where("deleted_at IS NULL AND is_used = ?", 1).Order("updated_at DESC").Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Table("testimonials").Where("deleted_at IS NULL AND is_used = ?", true).Order("updated_at DESC").Scan(&datas).Error
 	return datas, err
 }
 
-func (r *repository) GetPublicTopics() ([]TopicPublicResponse, error) {
+func (r *repository) GetPublicTopics(ctx context.Context) ([]TopicPublicResponse, error) {
 	var datas []TopicPublicResponse
-	err := r.db.Table("topics").Where("deleted_at IS NULL").Order("created_at DESC").Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Table("topics").Where("deleted_at IS NULL").Order("created_at DESC").Scan(&datas).Error
 	return datas, err
 }
 
-func (r *repository) GetRawPublicPaginateProjects(params ProjectPublicParams) ([]ProjectPaginatePublicRaw, int, error) {
+func (r *repository) GetRawPublicPaginateProjects(ctx context.Context, params ProjectPublicParams) ([]ProjectPaginatePublicRaw, int, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetRawPublicPaginateProjects")
+	defer span.End()
+
 	var datas []ProjectPaginatePublicRaw
 	var totalCount int
 
@@ -419,18 +449,18 @@ func (r *repository) GetRawPublicPaginateProjects(params ProjectPublicParams) ([
 
 	//? field "status"
 	whereClauses = append(whereClauses, "p.status = ?")
-	queryArgs = append(queryArgs, "Published")
+	queryArgs = append(queryArgs, string(editorial.StatusPublished))
 
 	//? field "search"
 	if params.Search != "" {
-		whereClauses = append(whereClauses, "(p.title LIKE ? OR p.summary LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Search+"%", "%"+params.Search+"%")
+		whereClauses = append(whereClauses, "(LOWER(p.title) LIKE ? OR LOWER(p.summary) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Search), utils.ContainsPattern(params.Search))
 	}
 
 	//? field "is_highlight"
 	if params.isHighlightParam == "Y" {
 		whereClauses = append(whereClauses, "p.is_highlight = ?")
-		queryArgs = append(queryArgs, 1)
+		queryArgs = append(queryArgs, true)
 	}
 
 	//? Construct the WHERE clause
@@ -444,7 +474,7 @@ func (r *repository) GetRawPublicPaginateProjects(params ProjectPublicParams) ([
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.readDB.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -471,7 +501,7 @@ func (r *repository) GetRawPublicPaginateProjects(params ProjectPublicParams) ([
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&datas).Error
+	err = r.readDB.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&datas).Error
 
 	if err != nil {
 		return []ProjectPaginatePublicRaw{}, 0, err
@@ -480,7 +510,10 @@ func (r *repository) GetRawPublicPaginateProjects(params ProjectPublicParams) ([
 	return datas, totalCount, nil
 }
 
-func (r *repository) GetRawPublicProjectTechnologies(params ProjectPublicParams, uniqueProjectIDs []int) ([]ProjectTechnologyPublicRaw, error) {
+func (r *repository) GetRawPublicProjectTechnologies(ctx context.Context, params ProjectPublicParams, uniqueProjectIDs []int) ([]ProjectTechnologyPublicRaw, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetRawPublicProjectTechnologies")
+	defer span.End()
+
 	var datas []ProjectTechnologyPublicRaw
 
 	rawSQL := `
@@ -519,7 +552,7 @@ func (r *repository) GetRawPublicProjectTechnologies(params ProjectPublicParams,
 		%s
 		%s`, rawSQL, whereSQL, orderBySQL)
 
-	err := r.db.Raw(finalSQL, queryArgs...).Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&datas).Error
 	if err != nil {
 		return []ProjectTechnologyPublicRaw{}, err
 	}
@@ -527,7 +560,10 @@ func (r *repository) GetRawPublicProjectTechnologies(params ProjectPublicParams,
 	return datas, nil
 }
 
-func (r *repository) GetPublicProjectBySlug(slug string) ([]SingleProjectPublicRaw, error) {
+func (r *repository) GetPublicProjectBySlug(ctx context.Context, slug string) ([]SingleProjectPublicRaw, error) {
+	ctx, span := utils.StartSpan(ctx, "public.GetPublicProjectBySlug")
+	defer span.End()
+
 	var datas []SingleProjectPublicRaw
 
 	// Build the raw SQL query
@@ -567,7 +603,7 @@ func (r *repository) GetPublicProjectBySlug(slug string) ([]SingleProjectPublicR
 	`
 
 	// Execute the raw SQL query
-	err := r.db.Raw(rawSQL, slug, "Published").Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Raw(rawSQL, slug, string(editorial.StatusPublished)).Scan(&datas).Error
 
 	if err != nil {
 		return []SingleProjectPublicRaw{}, err
@@ -580,35 +616,35 @@ func (r *repository) GetPublicProjectBySlug(slug string) ([]SingleProjectPublicR
 	return datas, nil
 }
 
-func (r *repository) GetPublicTechnologies() ([]TechnologyPublicResponse, error) {
+func (r *repository) GetPublicTechnologies(ctx context.Context) ([]TechnologyPublicResponse, error) {
 	var datas []TechnologyPublicResponse
-	err := r.db.Table("technologies").Where("deleted_at IS NULL").Order("updated_at DESC").Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Table("technologies").Where("deleted_at IS NULL").Order("updated_at DESC").Scan(&datas).Error
 	return datas, err
 }
 
-func (r *repository) GetPublicAuthors() ([]AuthorPublicResponse, error) {
+func (r *repository) GetPublicAuthors(ctx context.Context) ([]AuthorPublicResponse, error) {
 	var datas []AuthorPublicResponse
-	err := r.db.Table("authors").Where("deleted_at IS NULL").Order("updated_at DESC").Scan(&datas).Error
+	err := r.readDB.WithContext(ctx).Table("authors").Where("deleted_at IS NULL").Order("updated_at DESC").Scan(&datas).Error
 	return datas, err
 }
 
-func (r *repository) FindProjectById(id int) (ProjectByIdResponse, error) {
+func (r *repository) FindProjectById(ctx context.Context, id int) (ProjectByIdResponse, error) {
 	var data ProjectByIdResponse
-	err := r.db.Table("projects").Where("id = ?", id).Scan(&data).Error
+	err := r.db.WithContext(ctx).Table("projects").Where("id = ?", id).Scan(&data).Error
 	if data.ID == 0 {
 		return ProjectByIdResponse{}, gorm.ErrRecordNotFound
 	}
 	return data, err
 }
 
-func (r *repository) UpdatePublicProjectStatistic(p ProjectStatisticUpdatePublicDTO) (ProjectStatisticUpdatePubblicResponse, error) {
+func (r *repository) UpdatePublicProjectStatistic(ctx context.Context, p ProjectStatisticUpdatePublicDTO) (ProjectStatisticUpdatePubblicResponse, error) {
 	data := statistic.Statistic{
 		ID:    p.StatisticID,
 		Likes: p.Likes,
 		Views: p.Views,
 		Type:  p.Type,
 	}
-	err := r.db.Where("ID = ?", p.StatisticID).Updates(&data).Error
+	err := r.db.WithContext(ctx).Where("ID = ?", p.StatisticID).Updates(&data).Error
 	if err != nil {
 		return ProjectStatisticUpdatePubblicResponse{}, err
 	}
@@ -625,23 +661,23 @@ func (r *repository) UpdatePublicProjectStatistic(p ProjectStatisticUpdatePublic
 	return res, nil
 }
 
-func (r *repository) FindBlogById(id int) (BlogByIdResponse, error) {
+func (r *repository) FindBlogById(ctx context.Context, id int) (BlogByIdResponse, error) {
 	var data BlogByIdResponse
-	err := r.db.Table("blogs").Where("id = ?", id).Scan(&data).Error
+	err := r.db.WithContext(ctx).Table("blogs").Where("id = ?", id).Scan(&data).Error
 	if data.ID == 0 {
 		return BlogByIdResponse{}, gorm.ErrRecordNotFound
 	}
 	return data, err
 }
 
-func (r *repository) UpdatePublicBlogStatistic(p BlogStatisticUpdatePublicDTO) (BlogStatisticUpdatePubblicResponse, error) {
+func (r *repository) UpdatePublicBlogStatistic(ctx context.Context, p BlogStatisticUpdatePublicDTO) (BlogStatisticUpdatePubblicResponse, error) {
 	data := statistic.Statistic{
 		ID:    p.StatisticID,
 		Likes: p.Likes,
 		Views: p.Views,
 		Type:  p.Type,
 	}
-	err := r.db.Where("ID = ?", p.StatisticID).Updates(&data).Error
+	err := r.db.WithContext(ctx).Where("ID = ?", p.StatisticID).Updates(&data).Error
 	if err != nil {
 		return BlogStatisticUpdatePubblicResponse{}, err
 	}
@@ -657,3 +693,11 @@ func (r *repository) UpdatePublicBlogStatistic(p BlogStatisticUpdatePublicDTO) (
 
 	return res, nil
 }
+
+func (r *repository) FindStatisticById(ctx context.Context, id int) (statistic.Statistic, error) {
+	var data statistic.Statistic
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
+		return statistic.Statistic{}, err
+	}
+	return data, nil
+}
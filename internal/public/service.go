@@ -5,23 +5,25 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/statistic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 )
 
 type Service interface {
-	GetProfile() (ProfilePublicResponse, error)
-	GetPublicBlogs(params BlogPublicParams) ([]BlogPublicResponse, int, error)
-	GetPublicBlogBySlug(slug string) (SingleBlogPublicResponse, error)
-	GetPublicTestimonials() ([]TestimonialPublicResponse, error)
-	GetPublicTopics() ([]TopicPublicResponse, error)
-	GetPublicProjects(params ProjectPublicParams) ([]ProjectPublicResponse, int, error)
-	GetPublicProjectBySlug(slug string) (SingleProjectPublicResponse, error)
-	GetPublicTechnologies() ([]TechnologyPublicResponse, error)
-	GetPublicAuthors() ([]AuthorPublicResponse, error)
-	GetPublicExperiences() ([]ExperiencesPublicResponse, error)
-	UpdatePublicProjectStatistic(p ProjectStatisticUpdatePublicRequest) (ProjectStatisticUpdatePubblicResponse, error)
-	UpdatePublicBlogStatistic(p BlogStatisticUpdatePublicRequest) (BlogStatisticUpdatePubblicResponse, error)
+	GetProfile(ctx context.Context) (ProfilePublicResponse, error)
+	GetPublicBlogs(ctx context.Context, params BlogPublicParams) ([]BlogPublicResponse, int, error)
+	GetPublicBlogBySlug(ctx context.Context, slug string) (SingleBlogPublicResponse, error)
+	GetPublicTestimonials(ctx context.Context) ([]TestimonialPublicResponse, error)
+	GetPublicTopics(ctx context.Context) ([]TopicPublicResponse, error)
+	GetPublicProjects(ctx context.Context, params ProjectPublicParams) ([]ProjectPublicResponse, int, error)
+	GetPublicProjectBySlug(ctx context.Context, slug string) (SingleProjectPublicResponse, error)
+	GetPublicTechnologies(ctx context.Context) ([]TechnologyPublicResponse, error)
+	GetPublicAuthors(ctx context.Context) ([]AuthorPublicResponse, error)
+	GetPublicExperiences(ctx context.Context) ([]ExperiencesPublicResponse, error)
+	UpdatePublicProjectStatistic(ctx context.Context, p ProjectStatisticUpdatePublicRequest) (ProjectStatisticUpdatePubblicResponse, error)
+	UpdatePublicBlogStatistic(ctx context.Context, p BlogStatisticUpdatePublicRequest) (BlogStatisticUpdatePubblicResponse, error)
 }
 
 type service struct {
@@ -34,10 +36,7 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) GetProfile() (ProfilePublicResponse, error) {
-	// base context
-	ctx := context.Background()
-
+func (s *service) GetProfile(ctx context.Context) (ProfilePublicResponse, error) {
 	// errgroup to collect errors and wait
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -73,22 +72,22 @@ func (s *service) GetProfile() (ProfilePublicResponse, error) {
 
 	// schedule all calls
 	run("GetAboutPublic",
-		func() (interface{}, error) { return s.repo.GetAboutPublic() },
+		func() (interface{}, error) { return s.repo.GetAboutPublic(ctx) },
 		func(r interface{}) { about = r.(AboutPublicResponse) },
 	)
 
 	run("GetTechnologiesPublic",
-		func() (interface{}, error) { return s.repo.GetTechnologiesPublic() },
+		func() (interface{}, error) { return s.repo.GetTechnologiesPublic(ctx) },
 		func(r interface{}) { technologies = r.([]TechnologyProfilePublicResponse) },
 	)
 
 	run("GetCurrentWork",
-		func() (interface{}, error) { return s.repo.GetCurrentWork() },
+		func() (interface{}, error) { return s.repo.GetCurrentWork(ctx) },
 		func(r interface{}) { currentWork = r.(CurrentWorkPublicResponse) },
 	)
 
 	run("GetExperiencesPublic",
-		func() (interface{}, error) { return s.repo.GetExperiencesPublic() },
+		func() (interface{}, error) { return s.repo.GetExperiencesPublic(ctx) },
 		func(r interface{}) { experiences = r.([]ExperiencesPublicResponse) },
 	)
 
@@ -125,8 +124,8 @@ func (s *service) GetProfile() (ProfilePublicResponse, error) {
 	}, nil
 }
 
-func (s *service) GetPublicExperiences() ([]ExperiencesPublicResponse, error) {
-	datas, err := s.repo.GetExperiencesPublic()
+func (s *service) GetPublicExperiences(ctx context.Context) ([]ExperiencesPublicResponse, error) {
+	datas, err := s.repo.GetExperiencesPublic(ctx)
 	if err != nil {
 		return []ExperiencesPublicResponse{}, err
 	}
@@ -152,9 +151,9 @@ func (s *service) GetPublicExperiences() ([]ExperiencesPublicResponse, error) {
 	return experiences_formatted, nil
 }
 
-func (s *service) GetPublicBlogs(params BlogPublicParams) ([]BlogPublicResponse, int, error) {
+func (s *service) GetPublicBlogs(ctx context.Context, params BlogPublicParams) ([]BlogPublicResponse, int, error) {
 	//todo: Get Raw Paginate Blogs
-	rawPaginateBlogs, total_records, err := s.repo.GetRawPublicPaginateBlogs(params)
+	rawPaginateBlogs, total_records, err := s.repo.GetRawPublicPaginateBlogs(ctx, params)
 
 	if err != nil {
 		return []BlogPublicResponse{}, 0, err
@@ -174,7 +173,7 @@ func (s *service) GetPublicBlogs(params BlogPublicParams) ([]BlogPublicResponse,
 	}
 
 	//todo: Get Raw Blogs
-	rawBlogs, err := s.repo.GetRawPublicBlogs(params, uniquePaginateBlogIDs)
+	rawBlogs, err := s.repo.GetRawPublicBlogs(ctx, params, uniquePaginateBlogIDs)
 
 	if err != nil {
 		return []BlogPublicResponse{}, 0, err
@@ -190,7 +189,7 @@ func (s *service) GetPublicBlogs(params BlogPublicParams) ([]BlogPublicResponse,
 	}
 
 	//todo: Get Raw Blog Topics
-	rawBlogTopics, err := s.repo.GetRawPublicBlogTopics(params, uniqueBlogIDs)
+	rawBlogTopics, err := s.repo.GetRawPublicBlogTopics(ctx, params, uniqueBlogIDs)
 
 	if err != nil {
 		return []BlogPublicResponse{}, 0, err
@@ -300,8 +299,8 @@ func (s *service) MapBlogRawToResponse(raw BlogPublicRaw, blogTopics []BlogTopic
 	return blogResponse
 }
 
-func (s *service) GetPublicBlogBySlug(slug string) (SingleBlogPublicResponse, error) {
-	rawData, err := s.repo.GetPublicBlogBySlug(slug)
+func (s *service) GetPublicBlogBySlug(ctx context.Context, slug string) (SingleBlogPublicResponse, error) {
+	rawData, err := s.repo.GetPublicBlogBySlug(ctx, slug)
 
 	datas := s.MapSingleBlogRawToResponse(rawData)
 
@@ -416,8 +415,8 @@ func (s *service) MapSingleBlogRawToResponse(rawData []SingleBlogPublicRaw) Sing
 	return result
 }
 
-func (s *service) GetPublicTestimonials() ([]TestimonialPublicResponse, error) {
-	datas, err := s.repo.GetPublicTestimonials()
+func (s *service) GetPublicTestimonials(ctx context.Context) ([]TestimonialPublicResponse, error) {
+	datas, err := s.repo.GetPublicTestimonials(ctx)
 	if err != nil {
 		return []TestimonialPublicResponse{}, err
 	}
@@ -425,8 +424,8 @@ func (s *service) GetPublicTestimonials() ([]TestimonialPublicResponse, error) {
 	return datas, nil
 }
 
-func (s *service) GetPublicTopics() ([]TopicPublicResponse, error) {
-	datas, err := s.repo.GetPublicTopics()
+func (s *service) GetPublicTopics(ctx context.Context) ([]TopicPublicResponse, error) {
+	datas, err := s.repo.GetPublicTopics(ctx)
 	if err != nil {
 		return []TopicPublicResponse{}, err
 	}
@@ -434,9 +433,9 @@ func (s *service) GetPublicTopics() ([]TopicPublicResponse, error) {
 	return datas, nil
 }
 
-func (s *service) GetPublicProjects(params ProjectPublicParams) ([]ProjectPublicResponse, int, error) {
+func (s *service) GetPublicProjects(ctx context.Context, params ProjectPublicParams) ([]ProjectPublicResponse, int, error) {
 	//todo: Get Raw Paginate Project
-	rawPaginateProjects, total_record, err := s.repo.GetRawPublicPaginateProjects(params)
+	rawPaginateProjects, total_record, err := s.repo.GetRawPublicPaginateProjects(ctx, params)
 
 	if err != nil {
 		return []ProjectPublicResponse{}, 0, err
@@ -456,7 +455,7 @@ func (s *service) GetPublicProjects(params ProjectPublicParams) ([]ProjectPublic
 	}
 
 	//todo: Get Raw Project Technlogies
-	rawProjectTechnologies, err := s.repo.GetRawPublicProjectTechnologies(params, uniquePaginateProjectIDs)
+	rawProjectTechnologies, err := s.repo.GetRawPublicProjectTechnologies(ctx, params, uniquePaginateProjectIDs)
 
 	if err != nil {
 		return []ProjectPublicResponse{}, 0, err
@@ -514,8 +513,8 @@ func (s *service) MapProjectRawToResponse(raw ProjectPaginatePublicRaw, projectT
 	return projectResponse
 }
 
-func (s *service) GetPublicProjectBySlug(slug string) (SingleProjectPublicResponse, error) {
-	rawData, err := s.repo.GetPublicProjectBySlug(slug)
+func (s *service) GetPublicProjectBySlug(ctx context.Context, slug string) (SingleProjectPublicResponse, error) {
+	rawData, err := s.repo.GetPublicProjectBySlug(ctx, slug)
 
 	datas := s.MapSingleProjectRawToResponse(rawData)
 
@@ -611,8 +610,8 @@ func (s *service) MapSingleProjectRawToResponse(rawData []SingleProjectPublicRaw
 	return result
 }
 
-func (s *service) GetPublicTechnologies() ([]TechnologyPublicResponse, error) {
-	datas, err := s.repo.GetPublicTechnologies()
+func (s *service) GetPublicTechnologies(ctx context.Context) ([]TechnologyPublicResponse, error) {
+	datas, err := s.repo.GetPublicTechnologies(ctx)
 	if err != nil {
 		return []TechnologyPublicResponse{}, err
 	}
@@ -628,8 +627,8 @@ func (s *service) GetPublicTechnologies() ([]TechnologyPublicResponse, error) {
 	return datas, nil
 }
 
-func (s *service) GetPublicAuthors() ([]AuthorPublicResponse, error) {
-	datas, err := s.repo.GetPublicAuthors()
+func (s *service) GetPublicAuthors(ctx context.Context) ([]AuthorPublicResponse, error) {
+	datas, err := s.repo.GetPublicAuthors(ctx)
 	if err != nil {
 		return []AuthorPublicResponse{}, err
 	}
@@ -637,16 +636,16 @@ func (s *service) GetPublicAuthors() ([]AuthorPublicResponse, error) {
 	return datas, nil
 }
 
-func (s *service) GetProjectById(id int) (ProjectByIdResponse, error) {
-	data, err := s.repo.FindProjectById(id)
+func (s *service) GetProjectById(ctx context.Context, id int) (ProjectByIdResponse, error) {
+	data, err := s.repo.FindProjectById(ctx, id)
 	if err != nil {
 		return ProjectByIdResponse{}, err
 	}
 	return data, nil
 }
 
-func (s *service) UpdatePublicProjectStatistic(p ProjectStatisticUpdatePublicRequest) (ProjectStatisticUpdatePubblicResponse, error) {
-	project, err := s.GetProjectById(p.ProjectID)
+func (s *service) UpdatePublicProjectStatistic(ctx context.Context, p ProjectStatisticUpdatePublicRequest) (ProjectStatisticUpdatePubblicResponse, error) {
+	project, err := s.GetProjectById(ctx, p.ProjectID)
 	if err != nil {
 		return ProjectStatisticUpdatePubblicResponse{}, err
 	}
@@ -660,23 +659,30 @@ func (s *service) UpdatePublicProjectStatistic(p ProjectStatisticUpdatePublicReq
 		Type:         p.Type,
 	}
 
-	data, err := s.repo.UpdatePublicProjectStatistic(payload)
+	previous, err := s.repo.FindStatisticById(ctx, p.StatisticID)
+	if err != nil {
+		return ProjectStatisticUpdatePubblicResponse{}, err
+	}
+
+	data, err := s.repo.UpdatePublicProjectStatistic(ctx, payload)
 	if err != nil {
 		return ProjectStatisticUpdatePubblicResponse{}, err
 	}
+
+	recordStatisticDelta("Project", previous, p.Likes, p.Views)
 	return data, nil
 }
 
-func (s *service) GetBlogById(id int) (BlogByIdResponse, error) {
-	data, err := s.repo.FindBlogById(id)
+func (s *service) GetBlogById(ctx context.Context, id int) (BlogByIdResponse, error) {
+	data, err := s.repo.FindBlogById(ctx, id)
 	if err != nil {
 		return BlogByIdResponse{}, err
 	}
 	return data, nil
 }
 
-func (s *service) UpdatePublicBlogStatistic(p BlogStatisticUpdatePublicRequest) (BlogStatisticUpdatePubblicResponse, error) {
-	blog, err := s.GetBlogById(p.BlogID)
+func (s *service) UpdatePublicBlogStatistic(ctx context.Context, p BlogStatisticUpdatePublicRequest) (BlogStatisticUpdatePubblicResponse, error) {
+	blog, err := s.GetBlogById(ctx, p.BlogID)
 	if err != nil {
 		return BlogStatisticUpdatePubblicResponse{}, err
 	}
@@ -690,9 +696,34 @@ func (s *service) UpdatePublicBlogStatistic(p BlogStatisticUpdatePublicRequest)
 		Type:        p.Type,
 	}
 
-	data, err := s.repo.UpdatePublicBlogStatistic(payload)
+	previous, err := s.repo.FindStatisticById(ctx, p.StatisticID)
+	if err != nil {
+		return BlogStatisticUpdatePubblicResponse{}, err
+	}
+
+	data, err := s.repo.UpdatePublicBlogStatistic(ctx, payload)
 	if err != nil {
 		return BlogStatisticUpdatePubblicResponse{}, err
 	}
+
+	recordStatisticDelta("Blog", previous, p.Likes, p.Views)
 	return data, nil
 }
+
+// recordStatisticDelta feeds the business counters. Clients send absolute
+// totals, so only the increase over the stored value is counted.
+func recordStatisticDelta(contentType string, previous statistic.Statistic, likes, views *int) {
+	if likes != nil {
+		utils.RecordContentLikes(contentType, *likes-derefInt(previous.Likes))
+	}
+	if views != nil {
+		utils.RecordContentViews(contentType, *views-derefInt(previous.Views))
+	}
+}
+
+func derefInt(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
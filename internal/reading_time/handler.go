@@ -2,16 +2,13 @@ package reading_time
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	reading_time := r.Group("/reading-times")
@@ -23,3 +20,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		reading_time.POST("/delete", h.DeleteReadingTime)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	reading_time := r.Group("/reading-times")
+	{
+		reading_time.GET("", h.GetAll)
+		reading_time.GET("/:id", h.GetReadingTimeById)
+		reading_time.POST("", h.CreateReadingTime)
+		reading_time.PATCH("/:id", h.PatchReadingTime)
+		reading_time.DELETE("/:id", h.DeleteReadingTimeById)
+	}
+}
@@ -46,9 +46,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllReadingTimes(params)
+	data, total_records, err := h.service.GetAllReadingTimes(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -60,9 +60,9 @@ func (h *handler) GetReadingTimeById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetReadingTimeById(id)
+	data, err := h.service.GetReadingTimeById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -72,29 +72,61 @@ func (h *handler) CreateReadingTime(c *gin.Context) {
 	// Validate the struct using validator
 	var req CreateReadingTimeRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CreateReadingTime(req, nil)
+	data, err := h.service.CreateReadingTime(c.Request.Context(), req, nil)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateReadingTime(c *gin.Context) {
 	var req UpdateReadingTimeRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.updateReadingTime(c, req)
+}
+
+// PatchReadingTime updates the fields the body names and keeps the others.
+func (h *handler) PatchReadingTime(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetReadingTimeById(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UpdateReadingTimeRequest{
+		ID:               id,
+		Minutes:          current.Minutes,
+		TextLength:       current.TextLength,
+		EstimatedSeconds: current.EstimatedSeconds,
+		WordCount:        current.WordCount,
+		Type:             current.Type,
+	}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
+	req.ID = id
+
+	h.updateReadingTime(c, req)
+}
 
-	err := h.service.UpdateReadingTime(req, nil)
+func (h *handler) updateReadingTime(c *gin.Context, req UpdateReadingTimeRequest) {
+	err := h.service.UpdateReadingTime(c.Request.Context(), req, nil)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -104,15 +136,25 @@ func (h *handler) UpdateReadingTime(c *gin.Context) {
 func (h *handler) DeleteReadingTime(c *gin.Context) {
 	var req ReadingTimeDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteReadingTime(c, req.ID)
+}
+
+func (h *handler) DeleteReadingTimeById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteReadingTime(c, id)
+}
 
-	data, err := h.service.DeleteReadingTime(id)
+func (h *handler) deleteReadingTime(c *gin.Context, id int) {
+	data, err := h.service.DeleteReadingTime(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
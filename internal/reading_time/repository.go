@@ -1,19 +1,20 @@
 package reading_time
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllReadingTimeParams) ([]ReadingTime, int, error)
-	FindById(id int) (ReadingTime, error)
-	CreateReadingTime(p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTime, error)
-	UpdateReadingTime(p UpdateReadingTimeRequest, tx *gorm.DB) error
-	DeleteReadingTime(id int) (ReadingTime, error)
+	FindAll(ctx context.Context, params GetAllReadingTimeParams) ([]ReadingTime, int, error)
+	FindById(ctx context.Context, id int) (ReadingTime, error)
+	CreateReadingTime(ctx context.Context, p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTime, error)
+	UpdateReadingTime(ctx context.Context, p UpdateReadingTimeRequest, tx *gorm.DB) error
+	DeleteReadingTime(ctx context.Context, id int) (ReadingTime, error)
 }
 
 type repository struct {
@@ -24,7 +25,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllReadingTimeParams) ([]ReadingTime, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllReadingTimeParams) ([]ReadingTime, int, error) {
 	var reading_time []ReadingTime
 	var totalCount int
 
@@ -64,24 +65,13 @@ func (r *repository) FindAll(params GetAllReadingTimeParams) ([]ReadingTime, int
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -95,7 +85,7 @@ func (r *repository) FindAll(params GetAllReadingTimeParams) ([]ReadingTime, int
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -129,7 +119,7 @@ func (r *repository) FindAll(params GetAllReadingTimeParams) ([]ReadingTime, int
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&reading_time).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&reading_time).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -138,18 +128,18 @@ func (r *repository) FindAll(params GetAllReadingTimeParams) ([]ReadingTime, int
 	return reading_time, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (ReadingTime, error) {
+func (r *repository) FindById(ctx context.Context, id int) (ReadingTime, error) {
 	var data ReadingTime
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateReadingTime(p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTime, error) {
+func (r *repository) CreateReadingTime(ctx context.Context, p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTime, error) {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	data := ReadingTime{
@@ -162,12 +152,12 @@ func (r *repository) CreateReadingTime(p CreateReadingTimeRequest, tx *gorm.DB)
 	return data, err
 }
 
-func (r *repository) UpdateReadingTime(p UpdateReadingTimeRequest, tx *gorm.DB) error {
+func (r *repository) UpdateReadingTime(ctx context.Context, p UpdateReadingTimeRequest, tx *gorm.DB) error {
 	var db *gorm.DB
 	if tx != nil {
-		db = tx
+		db = tx.WithContext(ctx)
 	} else {
-		db = r.db
+		db = r.db.WithContext(ctx)
 	}
 
 	data := ReadingTime{
@@ -181,16 +171,16 @@ func (r *repository) UpdateReadingTime(p UpdateReadingTimeRequest, tx *gorm.DB)
 	return err
 }
 
-func (r *repository) DeleteReadingTime(id int) (ReadingTime, error) {
+func (r *repository) DeleteReadingTime(ctx context.Context, id int) (ReadingTime, error) {
 	var data ReadingTime
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return ReadingTime{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return ReadingTime{}, err
 	}
 
@@ -1,13 +1,17 @@
 package reading_time
 
-import "gorm.io/gorm"
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
 
 type Service interface {
-	GetAllReadingTimes(params GetAllReadingTimeParams) ([]ReadingTimeResponse, int, error)
-	GetReadingTimeById(id int) (ReadingTimeResponse, error)
-	CreateReadingTime(p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTimeResponse, error)
-	UpdateReadingTime(p UpdateReadingTimeRequest, tx *gorm.DB) error
-	DeleteReadingTime(id int) (ReadingTime, error)
+	GetAllReadingTimes(ctx context.Context, params GetAllReadingTimeParams) ([]ReadingTimeResponse, int, error)
+	GetReadingTimeById(ctx context.Context, id int) (ReadingTimeResponse, error)
+	CreateReadingTime(ctx context.Context, p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTimeResponse, error)
+	UpdateReadingTime(ctx context.Context, p UpdateReadingTimeRequest, tx *gorm.DB) error
+	DeleteReadingTime(ctx context.Context, id int) (ReadingTime, error)
 }
 
 type service struct {
@@ -18,8 +22,8 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) GetAllReadingTimes(params GetAllReadingTimeParams) ([]ReadingTimeResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllReadingTimes(ctx context.Context, params GetAllReadingTimeParams) ([]ReadingTimeResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -31,29 +35,29 @@ func (s *service) GetAllReadingTimes(params GetAllReadingTimeParams) ([]ReadingT
 	return result, total, nil
 }
 
-func (s *service) GetReadingTimeById(id int) (ReadingTimeResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetReadingTimeById(ctx context.Context, id int) (ReadingTimeResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return ReadingTimeResponse{}, err
 	}
 	return ToReadingTimeResponse(data), nil
 }
 
-func (s *service) CreateReadingTime(p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTimeResponse, error) {
-	data, err := s.repo.CreateReadingTime(p, tx)
+func (s *service) CreateReadingTime(ctx context.Context, p CreateReadingTimeRequest, tx *gorm.DB) (ReadingTimeResponse, error) {
+	data, err := s.repo.CreateReadingTime(ctx, p, tx)
 	if err != nil {
 		return ReadingTimeResponse{}, err
 	}
 	return ToReadingTimeResponse(data), nil
 }
 
-func (s *service) UpdateReadingTime(p UpdateReadingTimeRequest, tx *gorm.DB) error {
-	_, err := s.repo.FindById(p.ID)
+func (s *service) UpdateReadingTime(ctx context.Context, p UpdateReadingTimeRequest, tx *gorm.DB) error {
+	_, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.UpdateReadingTime(p, tx)
+	err = s.repo.UpdateReadingTime(ctx, p, tx)
 	if err != nil {
 		return err
 	}
@@ -61,8 +65,8 @@ func (s *service) UpdateReadingTime(p UpdateReadingTimeRequest, tx *gorm.DB) err
 	return nil
 }
 
-func (s *service) DeleteReadingTime(id int) (ReadingTime, error) {
-	data, err := s.repo.DeleteReadingTime(id)
+func (s *service) DeleteReadingTime(ctx context.Context, id int) (ReadingTime, error) {
+	data, err := s.repo.DeleteReadingTime(ctx, id)
 	if err != nil {
 		return ReadingTime{}, err
 	}
@@ -0,0 +1,146 @@
+// Package seed loads portfolio content from fixture files through the
+// regular services, so seeded rows go through the same validation, slugging,
+// reading time and statistic bookkeeping as content created over the API.
+//
+// Every record has a natural key (a name, a title or a slug). Running the same
+// fixtures twice updates the existing rows instead of duplicating them.
+package seed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixtures is the content of one fixture file. Records refer to each other by
+// name: blogs name their author and topics, projects their technologies.
+type Fixtures struct {
+	Abouts       []AboutFixture       `yaml:"abouts" json:"abouts"`
+	Authors      []AuthorFixture      `yaml:"authors" json:"authors"`
+	Topics       []TopicFixture       `yaml:"topics" json:"topics"`
+	Technologies []TechnologyFixture  `yaml:"technologies" json:"technologies"`
+	Experiences  []ExperienceFixture  `yaml:"experiences" json:"experiences"`
+	Testimonials []TestimonialFixture `yaml:"testimonials" json:"testimonials"`
+	Blogs        []BlogFixture        `yaml:"blogs" json:"blogs"`
+	Projects     []ProjectFixture     `yaml:"projects" json:"projects"`
+
+	// dir resolves the relative image paths.
+	dir string
+}
+
+// AboutFixture is keyed by title.
+type AboutFixture struct {
+	Title           string `yaml:"title" json:"title"`
+	DescriptionHTML string `yaml:"description_html" json:"description_html"`
+	IsUsed          bool   `yaml:"is_used" json:"is_used"`
+	Image           string `yaml:"image" json:"image"`
+}
+
+// AuthorFixture is keyed by name.
+type AuthorFixture struct {
+	Name  string `yaml:"name" json:"name"`
+	Image string `yaml:"image" json:"image"`
+}
+
+// TopicFixture is keyed by name.
+type TopicFixture struct {
+	Name string `yaml:"name" json:"name"`
+}
+
+// TechnologyFixture is keyed by name.
+type TechnologyFixture struct {
+	Name            string  `yaml:"name" json:"name"`
+	DescriptionHTML string  `yaml:"description_html" json:"description_html"`
+	IsMajor         bool    `yaml:"is_major" json:"is_major"`
+	Link            *string `yaml:"link" json:"link"`
+	Image           string  `yaml:"image" json:"image"`
+}
+
+// ExperienceFixture is keyed by company name and position. Dates use
+// 2006-01-02.
+type ExperienceFixture struct {
+	Position       string  `yaml:"position" json:"position"`
+	CompanyName    string  `yaml:"company_name" json:"company_name"`
+	WorkType       string  `yaml:"work_type" json:"work_type"`
+	Country        string  `yaml:"country" json:"country"`
+	City           *string `yaml:"city" json:"city"`
+	SummaryHTML    string  `yaml:"summary_html" json:"summary_html"`
+	FromDate       string  `yaml:"from_date" json:"from_date"`
+	ToDate         *string `yaml:"to_date" json:"to_date"`
+	CompWebsiteUrl string  `yaml:"comp_website_url" json:"comp_website_url"`
+	IsCurrent      bool    `yaml:"is_current" json:"is_current"`
+	Image          string  `yaml:"image" json:"image"`
+}
+
+// TestimonialFixture is keyed by name.
+type TestimonialFixture struct {
+	Name       string  `yaml:"name" json:"name"`
+	Via        *string `yaml:"via" json:"via"`
+	Role       *string `yaml:"role" json:"role"`
+	Message    *string `yaml:"message" json:"message"`
+	WorkingAt  *string `yaml:"working_at" json:"working_at"`
+	CompanyURL *string `yaml:"company_url" json:"company_url"`
+	IsUsed     bool    `yaml:"is_used" json:"is_used"`
+}
+
+// BlogFixture is keyed by slug. Status defaults to Published.
+type BlogFixture struct {
+	Slug            string   `yaml:"slug" json:"slug"`
+	Title           string   `yaml:"title" json:"title"`
+	Summary         string   `yaml:"summary" json:"summary"`
+	DescriptionHTML string   `yaml:"description_html" json:"description_html"`
+	Author          string   `yaml:"author" json:"author"`
+	Topics          []string `yaml:"topics" json:"topics"`
+	IsHighlight     bool     `yaml:"is_highlight" json:"is_highlight"`
+	Status          string   `yaml:"status" json:"status"`
+	Image           string   `yaml:"image" json:"image"`
+}
+
+// ProjectFixture is keyed by slug. Status defaults to Published.
+type ProjectFixture struct {
+	Slug          string   `yaml:"slug" json:"slug"`
+	Title         string   `yaml:"title" json:"title"`
+	Summary       string   `yaml:"summary" json:"summary"`
+	Description   string   `yaml:"description" json:"description"`
+	RepositoryUrl *string  `yaml:"repository_url" json:"repository_url"`
+	Technologies  []string `yaml:"technologies" json:"technologies"`
+	IsHighlight   bool     `yaml:"is_highlight" json:"is_highlight"`
+	Status        string   `yaml:"status" json:"status"`
+	Image         string   `yaml:"image" json:"image"`
+}
+
+// Load reads a fixture file. Files ending in .json are decoded as JSON, all
+// others as YAML. Unknown fields are rejected so typos do not pass silently.
+func Load(path string) (Fixtures, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("read fixtures: %w", err)
+	}
+
+	var f Fixtures
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(raw))
+		dec.KnownFields(true)
+		//? an empty YAML file is an empty set of fixtures
+		if err = dec.Decode(&f); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("parse fixtures %s: %w", path, err)
+	}
+
+	f.dir = filepath.Dir(path)
+	return f, nil
+}
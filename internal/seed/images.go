@@ -0,0 +1,106 @@
+package seed
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// ImageOptions controls which images are uploaded with the records.
+type ImageOptions struct {
+	// Placeholders uploads a generated image for records whose fixture
+	// names none. Without it such records are created without an image.
+	Placeholders bool
+	// Extensions and MaxSize apply the upload limits the handlers enforce
+	// to the image files named in the fixtures.
+	Extensions []string
+	MaxSize    int64
+}
+
+// image returns the upload for a record: the fixture's file, a placeholder
+// labelled with key, or nil when neither applies.
+func (s *Seeder) image(path, key string) (*multipart.FileHeader, error) {
+	if path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(s.fixtures.dir, path)
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read image: %w", err)
+		}
+		if errs := utils.ValidateExtension(path, s.images.Extensions); errs != nil {
+			return nil, fmt.Errorf("image %s: %s", path, errs[0].Message)
+		}
+		if s.images.MaxSize > 0 && int64(len(raw)) > s.images.MaxSize {
+			return nil, fmt.Errorf("image %s exceeds max size", path)
+		}
+		return fileHeader(filepath.Base(path), raw)
+	}
+
+	if !s.images.Placeholders {
+		return nil, nil
+	}
+	raw, err := placeholder(key)
+	if err != nil {
+		return nil, err
+	}
+	return fileHeader(utils.StringToSlug(key)+".png", raw)
+}
+
+// placeholder draws a flat 640x360 PNG whose colour is derived from key, so
+// each record gets a stable, distinguishable image.
+func placeholder(key string) ([]byte, error) {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	sum := h.Sum32()
+	fill := color.RGBA{R: uint8(sum>>16) | 0x40, G: uint8(sum>>8) | 0x40, B: uint8(sum) | 0x40, A: 0xff}
+
+	img := image.NewRGBA(image.Rect(0, 0, 640, 360))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = fill.R, fill.G, fill.B, fill.A
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fileHeader wraps raw in the multipart form file the services expect from
+// the handlers.
+func fileHeader(name string, raw []byte) (*multipart.FileHeader, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, name))
+	header.Set("Content-Type", mime.TypeByExtension(filepath.Ext(name)))
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	//? a limit above the file size keeps the part in memory instead of a temp file
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(int64(body.Len()) + 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	return form.File["file"][0], nil
+}
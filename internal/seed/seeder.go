@@ -0,0 +1,664 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/about"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/app/container"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/blog"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/editorial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/experience"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/project"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/technology"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/testimonial"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/topic"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// lookupLimit bounds the list queries used to find a record by its key.
+const lookupLimit = 1000
+
+// Count is how many records of one kind were created and updated.
+type Count struct {
+	Kind    string
+	Created int
+	Updated int
+}
+
+// Seeder upserts fixtures through the services, acting as actor.
+type Seeder struct {
+	svc    container.Services
+	actor  user.Actor
+	images ImageOptions
+
+	fixtures     Fixtures
+	authors      map[string]int
+	topics       map[string]int
+	technologies map[string]int
+	report       []Count
+}
+
+// New returns a seeder. actor owns the created blogs and performs the status
+// transitions, so it should be an admin.
+func New(svc container.Services, actor user.Actor, images ImageOptions) *Seeder {
+	return &Seeder{svc: svc, actor: actor, images: images}
+}
+
+// Run upserts every record of f, dependencies first, and reports what it did.
+// It stops at the first record that fails; the ones before it stay applied.
+func (s *Seeder) Run(ctx context.Context, f Fixtures) ([]Count, error) {
+	s.fixtures = f
+	s.authors = map[string]int{}
+	s.topics = map[string]int{}
+	s.technologies = map[string]int{}
+	s.report = nil
+
+	steps := []struct {
+		kind string
+		run  func(context.Context) error
+	}{
+		{"abouts", s.seedAbouts},
+		{"authors", s.seedAuthors},
+		{"topics", s.seedTopics},
+		{"technologies", s.seedTechnologies},
+		{"experiences", s.seedExperiences},
+		{"testimonials", s.seedTestimonials},
+		{"blogs", s.seedBlogs},
+		{"projects", s.seedProjects},
+	}
+	for _, step := range steps {
+		s.report = append(s.report, Count{Kind: step.kind})
+		if err := step.run(ctx); err != nil {
+			return s.report, err
+		}
+	}
+	return s.report, nil
+}
+
+func (s *Seeder) count(created bool) {
+	c := &s.report[len(s.report)-1]
+	if created {
+		c.Created++
+	} else {
+		c.Updated++
+	}
+}
+
+// validate applies the request's validate tags, like the handlers do.
+func validate(req any) error {
+	if errs := utils.ValidateRequest(req); len(errs) > 0 {
+		return fmt.Errorf("%s: %s", errs[0].Field, errs[0].Message)
+	}
+	return nil
+}
+
+func (s *Seeder) seedAbouts(ctx context.Context) error {
+	existing, err := s.svc.About.GetAllAbouts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range s.fixtures.Abouts {
+		id, version, imageFileName := 0, 0, ""
+		for _, a := range existing {
+			if a.Title == f.Title {
+				id, version, imageFileName = a.ID, a.Version, a.AvatarFileName
+				break
+			}
+		}
+
+		created := id == 0
+		if created {
+			file, err := s.image(f.Image, "about "+f.Title)
+			if err != nil {
+				return fmt.Errorf("about %q: %w", f.Title, err)
+			}
+			req := about.CreateAboutRequest{Title: f.Title, DescriptionHTML: f.DescriptionHTML, AvatarFile: file}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("about %q: %w", f.Title, err)
+			}
+			res, err := s.svc.About.CreateAbout(ctx, req)
+			if err != nil {
+				return fmt.Errorf("about %q: %w", f.Title, err)
+			}
+			//? a new about is never in use, is_used is applied by the update below
+			id, version, imageFileName = res.ID, res.Version, res.AvatarFileName
+		}
+
+		var file *multipart.FileHeader
+		if imageFileName == "" {
+			if file, err = s.image(f.Image, "about "+f.Title); err != nil {
+				return fmt.Errorf("about %q: %w", f.Title, err)
+			}
+		}
+		req := about.UpdateAboutRequest{
+			ID:              id,
+			Title:           f.Title,
+			DescriptionHTML: f.DescriptionHTML,
+			AvatarFile:      file,
+			IsUsed:          utils.BoolToYN(f.IsUsed),
+			Version:         version,
+		}
+		if err := validate(&req); err != nil {
+			return fmt.Errorf("about %q: %w", f.Title, err)
+		}
+		if err := s.svc.About.UpdateAbout(ctx, req); err != nil {
+			return fmt.Errorf("about %q: %w", f.Title, err)
+		}
+		s.count(created)
+	}
+	return nil
+}
+
+func (s *Seeder) seedAuthors(ctx context.Context) error {
+	for _, f := range s.fixtures.Authors {
+		existing, _, err := s.svc.Author.GetAllAuthors(ctx, author.GetAllAuthorParams{Page: 1, Limit: lookupLimit, Order: "id", Sort: "ASC", Name: f.Name})
+		if err != nil {
+			return err
+		}
+
+		var found *author.AuthorResponse
+		for i := range existing {
+			if existing[i].Name == f.Name {
+				found = &existing[i]
+				break
+			}
+		}
+
+		if found == nil {
+			file, err := s.image(f.Image, "author "+f.Name)
+			if err != nil {
+				return fmt.Errorf("author %q: %w", f.Name, err)
+			}
+			req := author.CreateAuthorRequest{Name: f.Name, AvatarFile: file}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("author %q: %w", f.Name, err)
+			}
+			res, err := s.svc.Author.CreateAuthor(ctx, req)
+			if err != nil {
+				return fmt.Errorf("author %q: %w", f.Name, err)
+			}
+			s.authors[f.Name] = res.ID
+			s.count(true)
+			continue
+		}
+
+		//? images are uploaded once; an existing image is kept
+		var file *multipart.FileHeader
+		if found.AvatarFileName == "" {
+			if file, err = s.image(f.Image, "author "+f.Name); err != nil {
+				return fmt.Errorf("author %q: %w", f.Name, err)
+			}
+		}
+		req := author.UpdateAuthorRequest{ID: found.ID, Name: f.Name, AvatarFile: file}
+		if err := s.svc.Author.UpdateAuthor(ctx, req); err != nil {
+			return fmt.Errorf("author %q: %w", f.Name, err)
+		}
+		s.authors[f.Name] = found.ID
+		s.count(false)
+	}
+	return nil
+}
+
+func (s *Seeder) seedTopics(ctx context.Context) error {
+	for _, f := range s.fixtures.Topics {
+		existing, _, err := s.svc.Topic.GetAllTopics(ctx, topic.GetAllTopicParams{Page: 1, Limit: lookupLimit, Order: "id", Sort: "ASC", Name: f.Name})
+		if err != nil {
+			return err
+		}
+
+		id := 0
+		for _, t := range existing {
+			if t.Name == f.Name {
+				id = t.ID
+				break
+			}
+		}
+
+		//? the name is the key, so an existing topic has nothing to update
+		created := id == 0
+		if created {
+			if f.Name == "" {
+				return fmt.Errorf("topic: name is required")
+			}
+			res, err := s.svc.Topic.CreateTopic(ctx, topic.CreateTopicRequest{Name: f.Name})
+			if err != nil {
+				return fmt.Errorf("topic %q: %w", f.Name, err)
+			}
+			id = res.ID
+		}
+		s.topics[f.Name] = id
+		s.count(created)
+	}
+	return nil
+}
+
+func (s *Seeder) seedTechnologies(ctx context.Context) error {
+	for _, f := range s.fixtures.Technologies {
+		existing, _, err := s.svc.Technology.GetAllTechnologies(ctx, technology.GetAllTechnologyParams{Page: 1, Limit: lookupLimit, Order: "id", Sort: "ASC", Name: f.Name})
+		if err != nil {
+			return err
+		}
+
+		var found *technology.TechnologyResponse
+		for i := range existing {
+			if existing[i].Name == f.Name {
+				found = &existing[i]
+				break
+			}
+		}
+
+		if found == nil {
+			file, err := s.image(f.Image, "technology "+f.Name)
+			if err != nil {
+				return fmt.Errorf("technology %q: %w", f.Name, err)
+			}
+			req := technology.CreateTechnologyRequest{
+				Name:            f.Name,
+				DescriptionHTML: f.DescriptionHTML,
+				LogoFile:        file,
+				IsMajor:         utils.BoolToYN(f.IsMajor),
+				Link:            f.Link,
+			}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("technology %q: %w", f.Name, err)
+			}
+			res, err := s.svc.Technology.CreateTechnology(ctx, req)
+			if err != nil {
+				return fmt.Errorf("technology %q: %w", f.Name, err)
+			}
+			s.technologies[f.Name] = res.ID
+			s.count(true)
+			continue
+		}
+
+		var file *multipart.FileHeader
+		if found.LogoFileName == "" {
+			if file, err = s.image(f.Image, "technology "+f.Name); err != nil {
+				return fmt.Errorf("technology %q: %w", f.Name, err)
+			}
+		}
+		req := technology.UpdateTechnologyRequest{
+			ID:              found.ID,
+			Name:            f.Name,
+			DescriptionHTML: f.DescriptionHTML,
+			LogoFile:        file,
+			IsMajor:         utils.BoolToYN(f.IsMajor),
+			Link:            f.Link,
+			Version:         found.Version,
+		}
+		if err := validate(&req); err != nil {
+			return fmt.Errorf("technology %q: %w", f.Name, err)
+		}
+		if err := s.svc.Technology.UpdateTechnology(ctx, req); err != nil {
+			return fmt.Errorf("technology %q: %w", f.Name, err)
+		}
+		s.technologies[f.Name] = found.ID
+		s.count(false)
+	}
+	return nil
+}
+
+func (s *Seeder) seedExperiences(ctx context.Context) error {
+	for _, f := range s.fixtures.Experiences {
+		key := f.Position + " at " + f.CompanyName
+		existing, _, err := s.svc.Experience.GetAllExperiences(ctx, experience.GetAllExperienceParams{
+			Page: 1, Limit: lookupLimit, Order: "id", Sort: "ASC",
+			Position: f.Position, CompanyName: f.CompanyName,
+		})
+		if err != nil {
+			return err
+		}
+
+		var found *experience.ExperienceResponse
+		for i := range existing {
+			if existing[i].Position == f.Position && existing[i].CompanyName == f.CompanyName {
+				found = &existing[i]
+				break
+			}
+		}
+
+		if found == nil {
+			file, err := s.image(f.Image, "experience "+key)
+			if err != nil {
+				return fmt.Errorf("experience %q: %w", key, err)
+			}
+			req := experience.CreateExperienceRequest{
+				Position:       f.Position,
+				CompanyName:    f.CompanyName,
+				WorkType:       f.WorkType,
+				Country:        f.Country,
+				City:           f.City,
+				SummaryHTML:    f.SummaryHTML,
+				FromDate:       f.FromDate,
+				ToDate:         f.ToDate,
+				CompImageFile:  file,
+				CompWebsiteUrl: f.CompWebsiteUrl,
+				IsCurrent:      utils.BoolToYN(f.IsCurrent),
+			}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("experience %q: %w", key, err)
+			}
+			if _, err := s.svc.Experience.CreateExperience(ctx, req); err != nil {
+				return fmt.Errorf("experience %q: %w", key, err)
+			}
+			s.count(true)
+			continue
+		}
+
+		var file *multipart.FileHeader
+		if found.CompImageFileName == "" {
+			if file, err = s.image(f.Image, "experience "+key); err != nil {
+				return fmt.Errorf("experience %q: %w", key, err)
+			}
+		}
+		req := experience.UpdateExperienceRequest{
+			ID:             found.ID,
+			Position:       f.Position,
+			CompanyName:    f.CompanyName,
+			WorkType:       f.WorkType,
+			Country:        f.Country,
+			City:           f.City,
+			SummaryHTML:    f.SummaryHTML,
+			FromDate:       f.FromDate,
+			ToDate:         f.ToDate,
+			CompImageFile:  file,
+			CompWebsiteUrl: f.CompWebsiteUrl,
+			IsCurrent:      utils.BoolToYN(f.IsCurrent),
+			Version:        found.Version,
+		}
+		if err := validate(&req); err != nil {
+			return fmt.Errorf("experience %q: %w", key, err)
+		}
+		if err := s.svc.Experience.UpdateExperience(ctx, req); err != nil {
+			return fmt.Errorf("experience %q: %w", key, err)
+		}
+		s.count(false)
+	}
+	return nil
+}
+
+func (s *Seeder) seedTestimonials(ctx context.Context) error {
+	for _, f := range s.fixtures.Testimonials {
+		existing, _, err := s.svc.Testimonial.GetAllTestimonials(ctx, testimonial.GetAllTestimonialParams{Page: 1, Limit: lookupLimit, Order: "id", Sort: "ASC", Name: f.Name})
+		if err != nil {
+			return err
+		}
+
+		id := 0
+		for _, t := range existing {
+			if t.Name == f.Name {
+				id = t.ID
+				break
+			}
+		}
+
+		if id == 0 {
+			if f.Name == "" {
+				return fmt.Errorf("testimonial: name is required")
+			}
+			res, err := s.svc.Testimonial.CreateTestimonial(ctx, testimonial.CreateTestimonialRequest{
+				Name:       f.Name,
+				Via:        f.Via,
+				Role:       f.Role,
+				Message:    f.Message,
+				WorkingAt:  f.WorkingAt,
+				CompanyURL: f.CompanyURL,
+			})
+			if err != nil {
+				return fmt.Errorf("testimonial %q: %w", f.Name, err)
+			}
+			if err := s.svc.Testimonial.ChangeStatusTestimonial(ctx, res.ID, utils.BoolToYN(f.IsUsed)); err != nil {
+				return fmt.Errorf("testimonial %q: %w", f.Name, err)
+			}
+			s.count(true)
+			continue
+		}
+
+		err = s.svc.Testimonial.UpdateTestimonial(ctx, testimonial.UpdateTestimonialRequest{
+			ID:         id,
+			Name:       f.Name,
+			Via:        f.Via,
+			Role:       f.Role,
+			Message:    f.Message,
+			WorkingAt:  f.WorkingAt,
+			CompanyURL: f.CompanyURL,
+			IsUsed:     utils.BoolToYN(f.IsUsed),
+		})
+		if err != nil {
+			return fmt.Errorf("testimonial %q: %w", f.Name, err)
+		}
+		s.count(false)
+	}
+	return nil
+}
+
+func (s *Seeder) seedBlogs(ctx context.Context) error {
+	for _, f := range s.fixtures.Blogs {
+		slug := utils.StringToSlug(f.Slug)
+
+		authorID, ok := s.authors[f.Author]
+		if !ok && f.Author != "" {
+			return fmt.Errorf("blog %q: author %q is not in the fixtures", slug, f.Author)
+		}
+		topicIDs, err := resolve(s.topics, f.Topics, "topic")
+		if err != nil {
+			return fmt.Errorf("blog %q: %w", slug, err)
+		}
+		target, err := targetStatus(f.Status)
+		if err != nil {
+			return fmt.Errorf("blog %q: %w", slug, err)
+		}
+
+		existing, _, err := s.svc.Blog.GetAllBlogs(ctx, blog.GetAllBlogParams{Page: 1, Limit: 1, Order: "id", Sort: "ASC", Slug: slug})
+		if err != nil {
+			return err
+		}
+
+		var current blog.BlogResponse
+		created := len(existing) == 0
+		if created {
+			file, err := s.image(f.Image, "blog "+f.Title)
+			if err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+			req := blog.CreateBlogRequest{
+				TopicIds:        topicIDs,
+				AuthorID:        authorID,
+				Title:           f.Title,
+				DescriptionHTML: f.DescriptionHTML,
+				BannerFile:      file,
+				Summary:         f.Summary,
+				Slug:            slug,
+			}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+			if current, err = s.svc.Blog.CreateBlog(ctx, s.actor, req); err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+		} else {
+			current = existing[0]
+		}
+
+		//? is_highlight can only be set by an update, so new blogs get one as well
+		if !created || f.IsHighlight {
+			relations, err := s.svc.Blog.GetBlogByIdWithRelations(ctx, current.ID)
+			if err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+			//? keep the content images the blog already links
+			images := []string{}
+			for _, img := range relations.ContentImages {
+				images = append(images, img.BlogContentImageUrl)
+			}
+			topics := []blog.UpdateBlogTopicDTO{}
+			for _, id := range topicIDs {
+				topics = append(topics, blog.UpdateBlogTopicDTO{TopicID: id})
+			}
+
+			var file *multipart.FileHeader
+			if current.BannerFileName == "" {
+				if file, err = s.image(f.Image, "blog "+f.Title); err != nil {
+					return fmt.Errorf("blog %q: %w", slug, err)
+				}
+			}
+			req := blog.UpdateBlogRequest{
+				ID:              current.ID,
+				TopicIds:        topics,
+				ContentImages:   images,
+				AuthorID:        authorID,
+				Title:           f.Title,
+				DescriptionHTML: f.DescriptionHTML,
+				BannerFile:      file,
+				Summary:         f.Summary,
+				Slug:            slug,
+				IsHighlight:     utils.BoolToYN(f.IsHighlight),
+				Version:         relations.Version,
+			}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+			if _, err := s.svc.Blog.UpdateBlog(ctx, s.actor, req); err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+		}
+
+		for _, status := range editorial.Path(editorial.ParseStatus(current.Status), target) {
+			req := blog.BlogChangeStatusRequest{ID: current.ID, Status: string(status)}
+			if _, err := s.svc.Blog.ChangeStatusBlog(ctx, s.actor, req); err != nil {
+				return fmt.Errorf("blog %q: %w", slug, err)
+			}
+		}
+		s.count(created)
+	}
+	return nil
+}
+
+func (s *Seeder) seedProjects(ctx context.Context) error {
+	for _, f := range s.fixtures.Projects {
+		slug := utils.StringToSlug(f.Slug)
+
+		techIDs, err := resolve(s.technologies, f.Technologies, "technology")
+		if err != nil {
+			return fmt.Errorf("project %q: %w", slug, err)
+		}
+		target, err := targetStatus(f.Status)
+		if err != nil {
+			return fmt.Errorf("project %q: %w", slug, err)
+		}
+
+		existing, _, err := s.svc.Project.GetAllProjects(ctx, project.GetAllProjectParams{Page: 1, Limit: 1, Order: "id", Sort: "ASC", Slug: slug})
+		if err != nil {
+			return err
+		}
+
+		var current project.ProjectResponse
+		created := len(existing) == 0
+		if created {
+			file, err := s.image(f.Image, "project "+f.Title)
+			if err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+			req := project.CreateProjectRequest{
+				Title:         f.Title,
+				Description:   f.Description,
+				ImageFile:     file,
+				RepositoryUrl: f.RepositoryUrl,
+				Summary:       f.Summary,
+				Slug:          slug,
+				TechnologyIds: techIDs,
+			}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+			if current, err = s.svc.Project.CreateProject(ctx, s.actor, req); err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+		} else {
+			current = existing[0]
+		}
+
+		//? is_highlight can only be set by an update, so new projects get one as well
+		if !created || f.IsHighlight {
+			relations, err := s.svc.Project.GetProjectByIdWithRelations(ctx, current.ID)
+			if err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+			//? keep the content images the project already links
+			images := []string{}
+			for _, img := range relations.ContentImages {
+				images = append(images, img.ImageUrl)
+			}
+			techs := []project.ProjectTechUpdatePayload{}
+			for _, id := range techIDs {
+				techs = append(techs, project.ProjectTechUpdatePayload{TechID: id})
+			}
+
+			var file *multipart.FileHeader
+			if current.ImageFileName == "" {
+				if file, err = s.image(f.Image, "project "+f.Title); err != nil {
+					return fmt.Errorf("project %q: %w", slug, err)
+				}
+			}
+			req := project.UpdateProjectRequest{
+				Id:            current.ID,
+				Title:         f.Title,
+				Description:   f.Description,
+				ImageFile:     file,
+				RepositoryUrl: f.RepositoryUrl,
+				Summary:       f.Summary,
+				Slug:          slug,
+				IsHighlight:   utils.BoolToYN(f.IsHighlight),
+				TechnologyIds: techs,
+				ProjectImages: images,
+				Version:       relations.Version,
+			}
+			if err := validate(&req); err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+			if _, err := s.svc.Project.UpdateProject(ctx, req); err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+		}
+
+		for _, status := range editorial.Path(editorial.ParseStatus(current.Status), target) {
+			req := project.ProjectChangeStatusRequest{ID: current.ID, Status: string(status)}
+			if _, err := s.svc.Project.ChangeStatusProject(ctx, s.actor, req); err != nil {
+				return fmt.Errorf("project %q: %w", slug, err)
+			}
+		}
+		s.count(created)
+	}
+	return nil
+}
+
+// resolve maps names to the ids seeded earlier in this run.
+func resolve(ids map[string]int, names []string, kind string) ([]int, error) {
+	resolved := []int{}
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("%s %q is not in the fixtures", kind, name)
+		}
+		resolved = append(resolved, id)
+	}
+	return resolved, nil
+}
+
+// targetStatus defaults to Published, since fixtures mostly exist to fill the
+// public endpoints.
+func targetStatus(value string) (editorial.Status, error) {
+	if value == "" {
+		return editorial.StatusPublished, nil
+	}
+	status := editorial.Status(value)
+	if editorial.Path(editorial.StatusDraft, status) == nil {
+		return "", fmt.Errorf("unknown status %q", value)
+	}
+	return status, nil
+}
@@ -2,16 +2,13 @@ package statistic
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	statistic := r.Group("/statistics")
@@ -23,3 +20,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		statistic.POST("/delete", h.DeleteStatistic)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	statistic := r.Group("/statistics")
+	{
+		statistic.GET("", h.GetAll)
+		statistic.GET("/:id", h.GetStatisticById)
+		statistic.POST("", h.CreateStatistic)
+		statistic.PATCH("/:id", h.PatchStatistic)
+		statistic.DELETE("/:id", h.DeleteStatisticById)
+	}
+}
@@ -1,20 +1,21 @@
 package statistic
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllStatisticParams) ([]Statistic, int, error)
-	FindById(id int) (Statistic, error)
-	CreateStatistic(p CreateStatisticRequest) (Statistic, error)
-	CreateStatisticWithTx(p CreateStatisticRequest, tx *gorm.DB) (Statistic, error)
-	UpdateStatistic(p UpdateStatisticRequest) error
-	DeleteStatistic(id int) (Statistic, error)
+	FindAll(ctx context.Context, params GetAllStatisticParams) ([]Statistic, int, error)
+	FindById(ctx context.Context, id int) (Statistic, error)
+	CreateStatistic(ctx context.Context, p CreateStatisticRequest) (Statistic, error)
+	CreateStatisticWithTx(ctx context.Context, p CreateStatisticRequest, tx *gorm.DB) (Statistic, error)
+	UpdateStatistic(ctx context.Context, p UpdateStatisticRequest) error
+	DeleteStatistic(ctx context.Context, id int) (Statistic, error)
 }
 
 type repository struct {
@@ -25,7 +26,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllStatisticParams) ([]Statistic, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllStatisticParams) ([]Statistic, int, error) {
 	var statistic []Statistic
 	var totalCount int
 
@@ -42,8 +43,8 @@ func (r *repository) FindAll(params GetAllStatisticParams) ([]Statistic, int, er
 
 	//? field "type"
 	if params.Type != "" {
-		whereClauses = append(whereClauses, "(type LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Type+"%")
+		whereClauses = append(whereClauses, "(LOWER(type) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Type))
 	}
 
 	//? field "min_likes"
@@ -71,24 +72,13 @@ func (r *repository) FindAll(params GetAllStatisticParams) ([]Statistic, int, er
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -102,7 +92,7 @@ func (r *repository) FindAll(params GetAllStatisticParams) ([]Statistic, int, er
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -134,7 +124,7 @@ func (r *repository) FindAll(params GetAllStatisticParams) ([]Statistic, int, er
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&statistic).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&statistic).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -143,22 +133,22 @@ func (r *repository) FindAll(params GetAllStatisticParams) ([]Statistic, int, er
 	return statistic, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (Statistic, error) {
+func (r *repository) FindById(ctx context.Context, id int) (Statistic, error) {
 	var data Statistic
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateStatistic(p CreateStatisticRequest) (Statistic, error) {
+func (r *repository) CreateStatistic(ctx context.Context, p CreateStatisticRequest) (Statistic, error) {
 	data := Statistic{
 		Likes: p.Likes,
 		Views: p.Views,
 		Type:  p.Type}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateStatisticWithTx(p CreateStatisticRequest, tx *gorm.DB) (Statistic, error) {
+func (r *repository) CreateStatisticWithTx(ctx context.Context, p CreateStatisticRequest, tx *gorm.DB) (Statistic, error) {
 	data := Statistic{
 		Likes: p.Likes,
 		Views: p.Views,
@@ -167,26 +157,26 @@ func (r *repository) CreateStatisticWithTx(p CreateStatisticRequest, tx *gorm.DB
 	return data, err
 }
 
-func (r *repository) UpdateStatistic(p UpdateStatisticRequest) error {
+func (r *repository) UpdateStatistic(ctx context.Context, p UpdateStatisticRequest) error {
 	data := Statistic{
 		ID:    p.ID,
 		Likes: p.Likes,
 		Views: p.Views,
 		Type:  p.Type}
-	err := r.db.Updates(&data).Error
+	err := r.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
 
-func (r *repository) DeleteStatistic(id int) (Statistic, error) {
+func (r *repository) DeleteStatistic(ctx context.Context, id int) (Statistic, error) {
 	var data Statistic
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Statistic{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return Statistic{}, err
 	}
 
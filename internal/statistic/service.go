@@ -1,14 +1,18 @@
 package statistic
 
-import "gorm.io/gorm"
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
 
 type Service interface {
-	GetAllStatistics(params GetAllStatisticParams) ([]StatisticResponse, int, error)
-	GetStatisticById(id int) (StatisticResponse, error)
-	CreateStatistic(p CreateStatisticRequest) (StatisticResponse, error)
-	CreateStatisticWithTx(p CreateStatisticRequest, tx *gorm.DB) (StatisticResponse, error)
-	UpdateStatistic(p UpdateStatisticRequest) error
-	DeleteStatistic(id int) (Statistic, error)
+	GetAllStatistics(ctx context.Context, params GetAllStatisticParams) ([]StatisticResponse, int, error)
+	GetStatisticById(ctx context.Context, id int) (StatisticResponse, error)
+	CreateStatistic(ctx context.Context, p CreateStatisticRequest) (StatisticResponse, error)
+	CreateStatisticWithTx(ctx context.Context, p CreateStatisticRequest, tx *gorm.DB) (StatisticResponse, error)
+	UpdateStatistic(ctx context.Context, p UpdateStatisticRequest) error
+	DeleteStatistic(ctx context.Context, id int) (Statistic, error)
 }
 
 type service struct {
@@ -19,8 +23,8 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) GetAllStatistics(params GetAllStatisticParams) ([]StatisticResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllStatistics(ctx context.Context, params GetAllStatisticParams) ([]StatisticResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -32,37 +36,37 @@ func (s *service) GetAllStatistics(params GetAllStatisticParams) ([]StatisticRes
 	return result, total, nil
 }
 
-func (s *service) GetStatisticById(id int) (StatisticResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetStatisticById(ctx context.Context, id int) (StatisticResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return StatisticResponse{}, err
 	}
 	return ToStatisticResponse(data), nil
 }
 
-func (s *service) CreateStatistic(p CreateStatisticRequest) (StatisticResponse, error) {
-	data, err := s.repo.CreateStatistic(p)
+func (s *service) CreateStatistic(ctx context.Context, p CreateStatisticRequest) (StatisticResponse, error) {
+	data, err := s.repo.CreateStatistic(ctx, p)
 	if err != nil {
 		return StatisticResponse{}, err
 	}
 	return ToStatisticResponse(data), nil
 }
 
-func (s *service) CreateStatisticWithTx(p CreateStatisticRequest, tx *gorm.DB) (StatisticResponse, error) {
-	data, err := s.repo.CreateStatisticWithTx(p, tx)
+func (s *service) CreateStatisticWithTx(ctx context.Context, p CreateStatisticRequest, tx *gorm.DB) (StatisticResponse, error) {
+	data, err := s.repo.CreateStatisticWithTx(ctx, p, tx)
 	if err != nil {
 		return StatisticResponse{}, err
 	}
 	return ToStatisticResponse(data), nil
 }
 
-func (s *service) UpdateStatistic(p UpdateStatisticRequest) error {
-	_, err := s.repo.FindById(p.ID)
+func (s *service) UpdateStatistic(ctx context.Context, p UpdateStatisticRequest) error {
+	_, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.UpdateStatistic(p)
+	err = s.repo.UpdateStatistic(ctx, p)
 	if err != nil {
 		return err
 	}
@@ -70,8 +74,8 @@ func (s *service) UpdateStatistic(p UpdateStatisticRequest) error {
 	return nil
 }
 
-func (s *service) DeleteStatistic(id int) (Statistic, error) {
-	data, err := s.repo.DeleteStatistic(id)
+func (s *service) DeleteStatistic(ctx context.Context, id int) (Statistic, error) {
+	data, err := s.repo.DeleteStatistic(ctx, id)
 	if err != nil {
 		return Statistic{}, err
 	}
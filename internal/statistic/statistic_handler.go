@@ -48,9 +48,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllStatistics(params)
+	data, total_records, err := h.service.GetAllStatistics(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -62,9 +62,9 @@ func (h *handler) GetStatisticById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetStatisticById(id)
+	data, err := h.service.GetStatisticById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -74,29 +74,54 @@ func (h *handler) CreateStatistic(c *gin.Context) {
 	// Validate the struct using validator
 	var req CreateStatisticRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CreateStatistic(req)
+	data, err := h.service.CreateStatistic(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateStatistic(c *gin.Context) {
 	var req UpdateStatisticRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	err := h.service.UpdateStatistic(req)
+	h.updateStatistic(c, req)
+}
+
+// PatchStatistic updates the fields the body names and keeps the others.
+func (h *handler) PatchStatistic(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetStatisticById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UpdateStatisticRequest{ID: id, Likes: &current.Likes, Views: &current.Views, Type: current.Type}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.updateStatistic(c, req)
+}
+
+func (h *handler) updateStatistic(c *gin.Context, req UpdateStatisticRequest) {
+	err := h.service.UpdateStatistic(c.Request.Context(), req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -106,15 +131,25 @@ func (h *handler) UpdateStatistic(c *gin.Context) {
 func (h *handler) DeleteStatistic(c *gin.Context) {
 	var req StatisticDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteStatistic(c, req.ID)
+}
+
+func (h *handler) DeleteStatisticById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteStatistic(c, id)
+}
 
-	data, err := h.service.DeleteStatistic(id)
+func (h *handler) deleteStatistic(c *gin.Context, id int) {
+	data, err := h.service.DeleteStatistic(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
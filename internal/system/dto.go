@@ -0,0 +1,56 @@
+package system
+
+import "database/sql"
+
+const (
+	CheckStatusUp   = "up"
+	CheckStatusDown = "down"
+)
+
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs int64   `json:"latency_ms"`
+	Error     *string `json:"error"`
+}
+
+type ReadinessResponse struct {
+	Ready  bool                   `json:"ready"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type DBPoolStats struct {
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+type SystemInfoResponse struct {
+	Version       string            `json:"version"`
+	Commit        string            `json:"commit"`
+	GoVersion     string            `json:"go_version"`
+	StartedAt     string            `json:"started_at"`
+	Uptime        string            `json:"uptime"`
+	UptimeSeconds int64             `json:"uptime_seconds"`
+	DBPool        DBPoolStats       `json:"db_pool"`
+	Migration     *MigrationVersion `json:"migration"`
+}
+
+func ToDBPoolStats(s sql.DBStats) DBPoolStats {
+	return DBPoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration.String(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
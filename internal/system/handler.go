@@ -0,0 +1,30 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+type handler struct {
+	service     Service
+	userService user.Service
+}
+
+// RegisterProbeRoutes mounts the unauthenticated liveness and readiness probes.
+func RegisterProbeRoutes(r *gin.Engine, service Service) {
+	h := handler{service: service}
+
+	r.GET("/healthz", h.Healthz)
+	r.GET("/readyz", h.Readyz)
+}
+
+func RegisterRoutes(r *gin.RouterGroup, service Service, userService user.Service) {
+	h := handler{service: service, userService: userService}
+
+	system := r.Group("/system")
+	system.Use(utils.RequireUserLogin())
+	{
+		system.GET("/info", h.GetInfo)
+	}
+}
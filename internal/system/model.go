@@ -0,0 +1,19 @@
+package system
+
+import "time"
+
+// Version and Commit are stamped at build time:
+//
+//	go build -ldflags "-X .../internal/system.Version=v1.2.0 -X .../internal/system.Commit=$(git rev-parse --short HEAD)"
+var (
+	Version = "dev"
+	Commit  = "unknown"
+)
+
+var startedAt = time.Now()
+
+// MigrationVersion mirrors the single row golang-migrate keeps in schema_migrations.
+type MigrationVersion struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+}
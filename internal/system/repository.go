@@ -0,0 +1,72 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Ping(ctx context.Context) error
+	PoolStats(ctx context.Context) (sql.DBStats, error)
+	FindMigrationVersion(ctx context.Context) (*MigrationVersion, error)
+}
+
+type repository struct {
+	db     *gorm.DB
+	readDB *gorm.DB
+}
+
+func NewRepository(db, readDB *gorm.DB) Repository {
+	return &repository{db: db, readDB: readDB}
+}
+
+// Ping checks the primary and, when one is configured, the read replica.
+func (r *repository) Ping(ctx context.Context) error {
+	if err := pingDB(ctx, r.db); err != nil {
+		return err
+	}
+	if r.readDB != r.db {
+		if err := pingDB(ctx, r.readDB); err != nil {
+			return fmt.Errorf("replica: %w", err)
+		}
+	}
+	return nil
+}
+
+func pingDB(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (r *repository) PoolStats(ctx context.Context) (sql.DBStats, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
+
+// FindMigrationVersion returns nil when migrations have never been applied.
+func (r *repository) FindMigrationVersion(ctx context.Context) (*MigrationVersion, error) {
+	if !r.db.WithContext(ctx).Migrator().HasTable("schema_migrations") {
+		return nil, nil
+	}
+
+	var data []MigrationVersion
+	err := r.db.WithContext(ctx).Raw(`SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&data).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return &data[0], nil
+}
@@ -0,0 +1,89 @@
+package system
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+const readinessTimeout = 3 * time.Second
+
+type Service interface {
+	CheckReadiness(ctx context.Context) ReadinessResponse
+	GetInfo(ctx context.Context, actor user.Actor) (SystemInfoResponse, error)
+}
+
+type service struct {
+	repo    Repository
+	storage utils.Storage
+}
+
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
+}
+
+func (s *service) CheckReadiness(ctx context.Context) ReadinessResponse {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	checks := map[string]func(context.Context) error{
+		"database": s.repo.Ping,
+		"storage":  s.storage.Ping,
+	}
+
+	res := ReadinessResponse{Ready: true, Checks: map[string]CheckResult{}}
+	for name, check := range checks {
+		result := runCheck(ctx, check)
+		if result.Status != CheckStatusUp {
+			res.Ready = false
+		}
+		res.Checks[name] = result
+	}
+
+	return res
+}
+
+func runCheck(ctx context.Context, check func(context.Context) error) CheckResult {
+	start := time.Now()
+	err := check(ctx)
+
+	result := CheckResult{Status: CheckStatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		message := err.Error()
+		result.Status = CheckStatusDown
+		result.Error = &message
+	}
+	return result
+}
+
+func (s *service) GetInfo(ctx context.Context, actor user.Actor) (SystemInfoResponse, error) {
+	if !actor.IsAdmin() {
+		return SystemInfoResponse{}, utils.ErrForbidden
+	}
+
+	stats, err := s.repo.PoolStats(ctx)
+	if err != nil {
+		return SystemInfoResponse{}, err
+	}
+
+	migration, err := s.repo.FindMigrationVersion(ctx)
+	if err != nil {
+		return SystemInfoResponse{}, err
+	}
+
+	uptime := time.Since(startedAt)
+
+	return SystemInfoResponse{
+		Version:       Version,
+		Commit:        Commit,
+		GoVersion:     runtime.Version(),
+		StartedAt:     startedAt.Format("2006-01-02 15:04:05"),
+		Uptime:        uptime.Round(time.Second).String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+		DBPool:        ToDBPoolStats(stats),
+		Migration:     migration,
+	}, nil
+}
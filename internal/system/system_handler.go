@@ -0,0 +1,52 @@
+package system
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/user"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
+
+// Healthz only reports that the process is serving requests.
+func (h *handler) Healthz(c *gin.Context) {
+	utils.Success(c, "ok", gin.H{"status": "ok"})
+}
+
+func (h *handler) Readyz(c *gin.Context) {
+	data := h.service.CheckReadiness(c.Request.Context())
+	if !data.Ready {
+		//? the probe is public: the failed checks are listed as the fields of the
+		//? problem by name only, their errors name hosts and ports and are logged
+		var failed []utils.FieldError
+		for name, check := range data.Checks {
+			if check.Error != nil {
+				utils.LoggerFrom(c.Request.Context()).WithField("check", name).Error("readiness check failed: ", *check.Error)
+				failed = append(failed, utils.FieldError{Field: name, Message: check.Status})
+			}
+		}
+		sort.Slice(failed, func(i, j int) bool { return failed[i].Field < failed[j].Field })
+		problem := utils.NewProblem(http.StatusServiceUnavailable, "not ready")
+		problem.Errors = failed
+		utils.WriteProblem(c, problem)
+		return
+	}
+
+	utils.Success(c, "ready", data)
+}
+
+func (h *handler) GetInfo(c *gin.Context) {
+	actor, ok := user.AuthActor(c, h.userService)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.GetInfo(c.Request.Context(), actor)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.Success(c, "success get data", data)
+}
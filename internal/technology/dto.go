@@ -21,6 +21,7 @@ type UpdateTechnologyRequest struct {
 	LogoFile        *multipart.FileHeader `json:"logo_file"`
 	IsMajor         string                `json:"is_major" validate:"required,oneof=Y N"`
 	Link            *string               `json:"link"`
+	Version         int                   `json:"version" validate:"required"`
 }
 
 type CreateTechnologyDTO struct {
@@ -34,6 +35,7 @@ type CreateTechnologyDTO struct {
 
 type UpdateTechnologyDTO struct {
 	ID              int
+	Version         int
 	Name            string
 	DescriptionHTML string
 	LogoUrl         string
@@ -51,6 +53,7 @@ type TechnologyResponse struct {
 	IsMajor         string  `json:"is_major"`
 	CreatedAt       string  `json:"created_at"`
 	Link            *string `json:"link"`
+	Version         int     `json:"version"`
 }
 
 type TechnologyUpdateResponse struct {
@@ -87,6 +90,7 @@ func ToTechnologyResponse(p Technology) TechnologyResponse {
 		LogoFileName:    p.LogoFileName,
 		IsMajor:         utils.BoolToYN(p.IsMajor),
 		Link:            p.Link,
+		Version:         p.Version,
 		CreatedAt:       p.CreatedAt.Format("2006-01-02"),
 	}
 }
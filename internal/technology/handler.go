@@ -2,17 +2,16 @@ package technology
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/config"
 )
 
 type handler struct {
 	service Service
+	upload  config.UploadConfig
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
-	h := handler{service: service}
+func RegisterRoutes(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
 
 	technology := r.Group("/technologies")
 	{
@@ -23,3 +22,17 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		technology.POST("/delete", h.DeleteTechnology)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service, upload config.UploadConfig) {
+	h := handler{service: service, upload: upload}
+
+	technology := r.Group("/technologies")
+	{
+		technology.GET("", h.GetAll)
+		technology.GET("/:id", h.GetTechnologyById)
+		technology.POST("", h.CreateTechnology)
+		technology.PATCH("/:id", h.PatchTechnology)
+		technology.DELETE("/:id", h.DeleteTechnologyById)
+	}
+}
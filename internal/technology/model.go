@@ -14,6 +14,7 @@ type Technology struct {
 	LogoFileName    string  `json:"logo_file_name"`
 	IsMajor         bool    `json:"is_major"`
 	Link            *string `json:"link"`
+	Version         int     `json:"version" gorm:"not null;default:1"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
@@ -1,19 +1,21 @@
 package technology
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllTechnologyParams) ([]Technology, int, error)
-	FindById(id int) (Technology, error)
-	CreateTechnology(p CreateTechnologyDTO) (Technology, error)
-	UpdateTechnology(p UpdateTechnologyDTO) error
-	DeleteTechnology(id int) (Technology, error)
+	FindAll(ctx context.Context, params GetAllTechnologyParams) ([]Technology, int, error)
+	FindById(ctx context.Context, id int) (Technology, error)
+	CreateTechnology(ctx context.Context, p CreateTechnologyDTO) (Technology, error)
+	UpdateTechnology(ctx context.Context, p UpdateTechnologyDTO) error
+	DeleteTechnology(ctx context.Context, id int) (Technology, error)
 }
 
 type repository struct {
@@ -24,7 +26,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllTechnologyParams) ([]Technology, int, error) {
 	var technology []Technology
 	var totalCount int
 
@@ -41,14 +43,14 @@ func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int,
 
 	//? field "name"
 	if params.Name != "" {
-		whereClauses = append(whereClauses, "(name LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Name+"%")
+		whereClauses = append(whereClauses, "(LOWER(name) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Name))
 	}
 
 	//? field "description_html"
 	if params.DescriptionHTML != "" {
-		whereClauses = append(whereClauses, "(description_html LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.DescriptionHTML+"%")
+		whereClauses = append(whereClauses, "(LOWER(description_html) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.DescriptionHTML))
 	}
 
 	//? field "is_major"
@@ -59,24 +61,13 @@ func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int,
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -90,7 +81,7 @@ func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int,
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -106,6 +97,7 @@ func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int,
 			logo_file_name,
 			is_major,
 			link,
+			version,
 			created_at
 		FROM technologies
 	`
@@ -125,7 +117,7 @@ func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int,
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&technology).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&technology).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -134,16 +126,16 @@ func (r *repository) FindAll(params GetAllTechnologyParams) ([]Technology, int,
 	return technology, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (Technology, error) {
+func (r *repository) FindById(ctx context.Context, id int) (Technology, error) {
 	var data Technology
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	if err == gorm.ErrRecordNotFound {
 		return Technology{}, gorm.ErrRecordNotFound
 	}
 	return data, err
 }
 
-func (r *repository) CreateTechnology(p CreateTechnologyDTO) (Technology, error) {
+func (r *repository) CreateTechnology(ctx context.Context, p CreateTechnologyDTO) (Technology, error) {
 	data := Technology{
 		Name:            p.Name,
 		DescriptionHTML: p.DescriptionHTML,
@@ -151,12 +143,13 @@ func (r *repository) CreateTechnology(p CreateTechnologyDTO) (Technology, error)
 		LogoFileName:    p.LogoFileName,
 		IsMajor:         p.IsMajor,
 		Link:            p.Link,
+		Version:         1,
 	}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateTechnology(p UpdateTechnologyDTO) error {
+func (r *repository) UpdateTechnology(ctx context.Context, p UpdateTechnologyDTO) error {
 
 	updateMap := map[string]interface{}{
 		"id":               p.ID,
@@ -168,24 +161,19 @@ func (r *repository) UpdateTechnology(p UpdateTechnologyDTO) error {
 		"link":             p.Link,
 		"updated_at":       time.Now(),
 	}
-	err := r.db.Table("technologies").Where("id = ?", p.ID).Updates(updateMap).Error
-	if err != nil {
-		return err
-	}
-
-	return err
+	return utils.UpdateVersioned(r.db.WithContext(ctx), "technologies", p.ID, p.Version, updateMap)
 }
 
-func (r *repository) DeleteTechnology(id int) (Technology, error) {
+func (r *repository) DeleteTechnology(ctx context.Context, id int) (Technology, error) {
 	var data Technology
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Technology{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&data).Error; err != nil {
 		return Technology{}, err
 	}
 
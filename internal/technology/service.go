@@ -7,23 +7,24 @@ import (
 )
 
 type Service interface {
-	GetAllTechnologies(params GetAllTechnologyParams) ([]TechnologyResponse, int, error)
-	GetTechnologyById(id int) (TechnologyResponse, error)
-	CreateTechnology(p CreateTechnologyRequest) (TechnologyResponse, error)
-	UpdateTechnology(p UpdateTechnologyRequest) error
-	DeleteTechnology(id int) (Technology, error)
+	GetAllTechnologies(ctx context.Context, params GetAllTechnologyParams) ([]TechnologyResponse, int, error)
+	GetTechnologyById(ctx context.Context, id int) (TechnologyResponse, error)
+	CreateTechnology(ctx context.Context, p CreateTechnologyRequest) (TechnologyResponse, error)
+	UpdateTechnology(ctx context.Context, p UpdateTechnologyRequest) error
+	DeleteTechnology(ctx context.Context, id int) (Technology, error)
 }
 
 type service struct {
-	repo Repository
+	repo    Repository
+	storage utils.Storage
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(r Repository, storage utils.Storage) Service {
+	return &service{repo: r, storage: storage}
 }
 
-func (s *service) GetAllTechnologies(params GetAllTechnologyParams) ([]TechnologyResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllTechnologies(ctx context.Context, params GetAllTechnologyParams) ([]TechnologyResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -35,16 +36,16 @@ func (s *service) GetAllTechnologies(params GetAllTechnologyParams) ([]Technolog
 	return result, total, nil
 }
 
-func (s *service) GetTechnologyById(id int) (TechnologyResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetTechnologyById(ctx context.Context, id int) (TechnologyResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return TechnologyResponse{}, err
 	}
 	return ToTechnologyResponse(data), nil
 }
 
-func (s *service) CreateTechnology(p CreateTechnologyRequest) (TechnologyResponse, error) {
-	logoRes, err := utils.HandlUploadFile(p.LogoFile, "technology")
+func (s *service) CreateTechnology(ctx context.Context, p CreateTechnologyRequest) (TechnologyResponse, error) {
+	logoRes, err := s.storage.Upload(ctx, p.LogoFile, "technology")
 	if err != nil {
 		return TechnologyResponse{}, err
 	}
@@ -58,20 +59,23 @@ func (s *service) CreateTechnology(p CreateTechnologyRequest) (TechnologyRespons
 		Link:            p.Link,
 	}
 
-	data, err := s.repo.CreateTechnology(payload)
+	data, err := s.repo.CreateTechnology(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), logoRes.FileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), logoRes.FileName)
 		return TechnologyResponse{}, err
 	}
 	return ToTechnologyResponse(data), nil
 }
 
-func (s *service) UpdateTechnology(p UpdateTechnologyRequest) error {
+func (s *service) UpdateTechnology(ctx context.Context, p UpdateTechnologyRequest) error {
 	//todo: Get Technology
-	technology, err := s.repo.FindById(p.ID)
+	technology, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
+	if err := utils.CheckVersion(p.Version, technology.Version); err != nil {
+		return err
+	}
 
 	//todo: set oldFileName
 	oldFileName := ""
@@ -84,7 +88,7 @@ func (s *service) UpdateTechnology(p UpdateTechnologyRequest) error {
 
 	//todo: Upload File
 	if p.LogoFile != nil {
-		logoRes, err := utils.HandlUploadFile(p.LogoFile, "technology")
+		logoRes, err := s.storage.Upload(ctx, p.LogoFile, "technology")
 		if err != nil {
 			return err
 		}
@@ -98,6 +102,7 @@ func (s *service) UpdateTechnology(p UpdateTechnologyRequest) error {
 
 	payload := UpdateTechnologyDTO{
 		ID:              p.ID,
+		Version:         p.Version,
 		Name:            p.Name,
 		DescriptionHTML: p.DescriptionHTML,
 		LogoUrl:         newFileURL,
@@ -106,22 +111,24 @@ func (s *service) UpdateTechnology(p UpdateTechnologyRequest) error {
 		Link:            p.Link,
 	}
 
-	err = s.repo.UpdateTechnology(payload)
+	err = s.repo.UpdateTechnology(ctx, payload)
 	if err != nil {
-		_ = utils.DeleteFromMinio(context.Background(), newFileName)
+		if p.LogoFile != nil {
+			_ = s.storage.Delete(context.WithoutCancel(ctx), newFileName)
+		}
 		return err
 	}
 
 	//todo: Delete Old Image
 	if oldFileName != newFileName {
-		_ = utils.DeleteFromMinio(context.Background(), oldFileName)
+		_ = s.storage.Delete(context.WithoutCancel(ctx), oldFileName)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteTechnology(id int) (Technology, error) {
-	data, err := s.repo.DeleteTechnology(id)
+func (s *service) DeleteTechnology(ctx context.Context, id int) (Technology, error) {
+	data, err := s.repo.DeleteTechnology(ctx, id)
 	if err != nil {
 		return Technology{}, err
 	}
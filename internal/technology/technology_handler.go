@@ -47,9 +47,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllTechnologies(params)
+	data, total_records, err := h.service.GetAllTechnologies(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -61,11 +61,12 @@ func (h *handler) GetTechnologyById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetTechnologyById(id)
+	data, err := h.service.GetTechnologyById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
+	utils.SetETag(c, data.Version)
 	utils.Success(c, "success get data", data)
 }
 
@@ -73,14 +74,13 @@ func (h *handler) ValidateLogo(c *gin.Context, validationCheck []string) (file *
 	if len(validationCheck) == 0 {
 		validationCheck = []string{"required", "extension", "size"}
 	}
-	var maxSize int64 = 2 * 1024 * 1024
-	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".webp"}
+	maxSize := h.upload.MaxImageSize
+	allowedExtensions := h.upload.ImageExtensions
 
 	// Step 1: Get the file
 	logo_file, err := c.FormFile("logo_file")
 	if err != nil && slices.Contains(validationCheck, "required") {
-		err_name := fmt.Sprintf("%s is required", "logo_file")
-		errors = utils.GenerateFieldErrorResponse("logo_file", err_name)
+		errors = utils.GenerateFieldErrorResponse("logo_file", "{0} is required", "logo_file")
 		return nil, errors, err
 	}
 
@@ -95,8 +95,7 @@ func (h *handler) ValidateLogo(c *gin.Context, validationCheck []string) (file *
 		// Step 3: Validate size
 		if logo_file.Size > maxSize && slices.Contains(validationCheck, "size") {
 			err = fmt.Errorf("validation Error")
-			err_name := fmt.Sprintf("%s exceeds max size", "logo_file")
-			errors := utils.GenerateFieldErrorResponse("logo_file", err_name)
+			errors := utils.GenerateFieldErrorResponse("logo_file", "{0} exceeds max size", "logo_file")
 			return nil, errors, err
 		}
 	}
@@ -126,70 +125,108 @@ func (h *handler) CreateTechnology(c *gin.Context) {
 	}
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	data, err := h.service.CreateTechnology(req)
+	data, err := h.service.CreateTechnology(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateTechnology(c *gin.Context) {
 	// Validate the struct using validator
 	id, _ := strconv.Atoi(c.PostForm("id"))
 
-	name := c.PostForm("name")
-	description_html := c.PostForm("description_html")
-	is_major := c.PostForm("is_major")
-	link := c.PostForm("link")
+	h.updateTechnology(c, UpdateTechnologyRequest{ID: id, Link: new(string)})
+}
 
-	validationCheck := []string{"extension", "size"}
-	logo_file, errors, err := h.ValidateLogo(c, validationCheck)
+// PatchTechnology updates the fields the form names and keeps the others.
+func (h *handler) PatchTechnology(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetTechnologyById(c.Request.Context(), id)
 	if err != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	req := UpdateTechnologyRequest{
+	h.updateTechnology(c, UpdateTechnologyRequest{
 		ID:              id,
-		Name:            name,
-		DescriptionHTML: description_html,
-		LogoFile:        logo_file,
-		IsMajor:         is_major,
-		Link:            &link,
+		Name:            current.Name,
+		DescriptionHTML: current.DescriptionHTML,
+		IsMajor:         current.IsMajor,
+		Link:            current.Link,
+		Version:         current.Version,
+	})
+}
+
+// updateTechnology overlays the form values on req before saving it, provided
+// the technology is still at the version the client sent.
+func (h *handler) updateTechnology(c *gin.Context, req UpdateTechnologyRequest) {
+	req.Name = utils.PostFormOr(c, "name", req.Name)
+	req.DescriptionHTML = utils.PostFormOr(c, "description_html", req.DescriptionHTML)
+	req.IsMajor = utils.PostFormOr(c, "is_major", req.IsMajor)
+	if link, ok := c.GetPostForm("link"); ok {
+		req.Link = &link
+	}
+	version, ok := utils.RequestVersion(c, req.Version)
+	if !ok {
+		return
 	}
+	req.Version = version
+
+	validationCheck := []string{"extension", "size"}
+	logo_file, errors, err := h.ValidateLogo(c, validationCheck)
+	if err != nil {
+		utils.ErrorValidation(c, http.StatusBadRequest, err.Error(), errors)
+		return
+	}
+	req.LogoFile = logo_file
 
 	if verr := utils.ValidateRequest(&req); verr != nil {
-		utils.ErrorValidation(c, http.StatusBadRequest, "Validation Error", verr)
+		utils.ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", verr)
 		return
 	}
 
-	err = h.service.UpdateTechnology(req)
+	err = h.service.UpdateTechnology(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	utils.SetETag(c, req.Version+1)
 	utils.Success(c, "success updated data", nil)
 }
 
 func (h *handler) DeleteTechnology(c *gin.Context) {
 	var req TechnologyDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteTechnology(c, req.ID)
+}
+
+func (h *handler) DeleteTechnologyById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteTechnology(c, id)
+}
 
-	data, err := h.service.DeleteTechnology(id)
+func (h *handler) deleteTechnology(c *gin.Context, id int) {
+	data, err := h.service.DeleteTechnology(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -2,16 +2,13 @@ package testimonial
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	testimonial := r.Group("/testimonials")
@@ -25,3 +22,18 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		testimonial.POST("/bulk-change-status", h.ChangeMultiStatusTestimonial)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	testimonial := r.Group("/testimonials")
+	{
+		testimonial.GET("", h.GetAll)
+		testimonial.GET("/:id", h.GetTestimonialById)
+		testimonial.POST("", h.CreateTestimonial)
+		testimonial.PATCH("/:id", h.PatchTestimonial)
+		testimonial.DELETE("/:id", h.DeleteTestimonialById)
+		testimonial.PATCH("", h.ChangeMultiStatusTestimonial)
+	}
+}
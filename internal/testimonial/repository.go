@@ -1,22 +1,24 @@
 package testimonial
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllTestimonialParams) ([]Testimonial, int, error)
-	FindById(id int) (Testimonial, error)
-	FindByMultiId(ids []int) ([]Testimonial, error)
-	CreateTestimonial(p CreateTestimonialDTO) (Testimonial, error)
-	UpdateTestimonial(p UpdateTestimonialDTO) error
-	DeleteTestimonial(id int) (Testimonial, error)
-	ChangeStatusTestimonial(id int, isUsed bool) error
-	ChangeMultiStatusTestimonial(ids []int, isUsed bool) error
+	FindAll(ctx context.Context, params GetAllTestimonialParams) ([]Testimonial, int, error)
+	FindById(ctx context.Context, id int) (Testimonial, error)
+	FindByMultiId(ctx context.Context, ids []int) ([]Testimonial, error)
+	CreateTestimonial(ctx context.Context, p CreateTestimonialDTO) (Testimonial, error)
+	UpdateTestimonial(ctx context.Context, p UpdateTestimonialDTO) error
+	DeleteTestimonial(ctx context.Context, id int) (Testimonial, error)
+	ChangeStatusTestimonial(ctx context.Context, id int, isUsed bool) error
+	ChangeMultiStatusTestimonial(ctx context.Context, ids []int, isUsed bool) error
 }
 
 type repository struct {
@@ -27,7 +29,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllTestimonialParams) ([]Testimonial, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllTestimonialParams) ([]Testimonial, int, error) {
 	var testimonial []Testimonial
 	var totalCount int
 
@@ -44,20 +46,20 @@ func (r *repository) FindAll(params GetAllTestimonialParams) ([]Testimonial, int
 
 	//? field "name"
 	if params.Name != "" {
-		whereClauses = append(whereClauses, "(name LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Name+"%")
+		whereClauses = append(whereClauses, "(LOWER(name) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Name))
 	}
 
 	//? field "role"
 	if params.Role != "" {
-		whereClauses = append(whereClauses, "(role LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Role+"%")
+		whereClauses = append(whereClauses, "(LOWER(role) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Role))
 	}
 
 	//? field "working_at"
 	if params.WorkingAt != "" {
-		whereClauses = append(whereClauses, "(working_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.WorkingAt+"%")
+		whereClauses = append(whereClauses, "(LOWER(working_at) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.WorkingAt))
 	}
 
 	//? field "is_used"
@@ -68,24 +70,13 @@ func (r *repository) FindAll(params GetAllTestimonialParams) ([]Testimonial, int
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -99,7 +90,7 @@ func (r *repository) FindAll(params GetAllTestimonialParams) ([]Testimonial, int
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -135,7 +126,7 @@ func (r *repository) FindAll(params GetAllTestimonialParams) ([]Testimonial, int
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&testimonial).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&testimonial).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -144,19 +135,19 @@ func (r *repository) FindAll(params GetAllTestimonialParams) ([]Testimonial, int
 	return testimonial, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (Testimonial, error) {
+func (r *repository) FindById(ctx context.Context, id int) (Testimonial, error) {
 	var data Testimonial
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) FindByMultiId(ids []int) ([]Testimonial, error) {
+func (r *repository) FindByMultiId(ctx context.Context, ids []int) ([]Testimonial, error) {
 	var datas []Testimonial
-	err := r.db.Table("testimonials").Where("id IN ?", ids).Scan(&datas).Error
+	err := r.db.WithContext(ctx).Table("testimonials").Where("id IN ?", ids).Scan(&datas).Error
 	return datas, err
 }
 
-func (r *repository) CreateTestimonial(p CreateTestimonialDTO) (Testimonial, error) {
+func (r *repository) CreateTestimonial(ctx context.Context, p CreateTestimonialDTO) (Testimonial, error) {
 	data := Testimonial{
 		Name:       p.Name,
 		Via:        p.Via,
@@ -166,11 +157,11 @@ func (r *repository) CreateTestimonial(p CreateTestimonialDTO) (Testimonial, err
 		CompanyURL: p.CompanyURL,
 		IsUsed:     p.IsUsed,
 	}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateTestimonial(p UpdateTestimonialDTO) error {
+func (r *repository) UpdateTestimonial(ctx context.Context, p UpdateTestimonialDTO) error {
 	updates := map[string]interface{}{
 		"id":          p.ID,
 		"name":        p.Name,
@@ -182,23 +173,23 @@ func (r *repository) UpdateTestimonial(p UpdateTestimonialDTO) error {
 		"is_used":     p.IsUsed,
 		"updated_at":  time.Now(),
 	}
-	err := r.db.Table("testimonials").Where("id = ?", p.ID).Updates(updates).Error
+	err := r.db.WithContext(ctx).Table("testimonials").Where("id = ?", p.ID).Updates(updates).Error
 	if err != nil {
 		return err
 	}
 	return err
 }
 
-func (r *repository) DeleteTestimonial(id int) (Testimonial, error) {
+func (r *repository) DeleteTestimonial(ctx context.Context, id int) (Testimonial, error) {
 	var data Testimonial
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Testimonial{}, err // return if not found or any error
 	}
 
 	// Step 2: Hard Delete
-	if err := r.db.Unscoped().Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Delete(&data).Error; err != nil {
 		return Testimonial{}, err
 	}
 
@@ -206,22 +197,22 @@ func (r *repository) DeleteTestimonial(id int) (Testimonial, error) {
 	return data, nil
 }
 
-func (r *repository) ChangeStatusTestimonial(id int, isUsed bool) error {
+func (r *repository) ChangeStatusTestimonial(ctx context.Context, id int, isUsed bool) error {
 	updates := map[string]interface{}{
 		"is_used": isUsed,
 	}
-	err := r.db.Table("testimonials").Where("id = ?", id).Updates(updates).Error
+	err := r.db.WithContext(ctx).Table("testimonials").Where("id = ?", id).Updates(updates).Error
 	if err != nil {
 		return err
 	}
 	return err
 }
 
-func (r *repository) ChangeMultiStatusTestimonial(ids []int, isUsed bool) error {
+func (r *repository) ChangeMultiStatusTestimonial(ctx context.Context, ids []int, isUsed bool) error {
 	updates := map[string]interface{}{
 		"is_used": isUsed,
 	}
-	err := r.db.Table("testimonials").Where("id IN ?", ids).Updates(updates).Error
+	err := r.db.WithContext(ctx).Table("testimonials").Where("id IN ?", ids).Updates(updates).Error
 	if err != nil {
 		return err
 	}
@@ -1,15 +1,19 @@
 package testimonial
 
-import "fmt"
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
 
 type Service interface {
-	GetAllTestimonials(params GetAllTestimonialParams) ([]TestimonialResponse, int, error)
-	GetTestimonialById(id int) (TestimonialResponse, error)
-	CreateTestimonial(p CreateTestimonialRequest) (TestimonialResponse, error)
-	UpdateTestimonial(p UpdateTestimonialRequest) error
-	DeleteTestimonial(id int) (Testimonial, error)
-	ChangeStatusTestimonial(id int, isUsed string) error
-	ChangeMultiStatusTestimonial(ids []int, isUsed string) error
+	GetAllTestimonials(ctx context.Context, params GetAllTestimonialParams) ([]TestimonialResponse, int, error)
+	GetTestimonialById(ctx context.Context, id int) (TestimonialResponse, error)
+	CreateTestimonial(ctx context.Context, p CreateTestimonialRequest) (TestimonialResponse, error)
+	UpdateTestimonial(ctx context.Context, p UpdateTestimonialRequest) error
+	DeleteTestimonial(ctx context.Context, id int) (Testimonial, error)
+	ChangeStatusTestimonial(ctx context.Context, id int, isUsed string) error
+	ChangeMultiStatusTestimonial(ctx context.Context, ids []int, isUsed string) error
 }
 
 type service struct {
@@ -20,8 +24,8 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) GetAllTestimonials(params GetAllTestimonialParams) ([]TestimonialResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllTestimonials(ctx context.Context, params GetAllTestimonialParams) ([]TestimonialResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -33,15 +37,15 @@ func (s *service) GetAllTestimonials(params GetAllTestimonialParams) ([]Testimon
 	return result, total, nil
 }
 
-func (s *service) GetTestimonialById(id int) (TestimonialResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetTestimonialById(ctx context.Context, id int) (TestimonialResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return TestimonialResponse{}, err
 	}
 	return ToTestimonialResponse(data), nil
 }
 
-func (s *service) CreateTestimonial(p CreateTestimonialRequest) (TestimonialResponse, error) {
+func (s *service) CreateTestimonial(ctx context.Context, p CreateTestimonialRequest) (TestimonialResponse, error) {
 	payload := CreateTestimonialDTO{
 		Name:       p.Name,
 		Via:        p.Via,
@@ -52,15 +56,15 @@ func (s *service) CreateTestimonial(p CreateTestimonialRequest) (TestimonialResp
 		IsUsed:     false,
 	}
 
-	data, err := s.repo.CreateTestimonial(payload)
+	data, err := s.repo.CreateTestimonial(ctx, payload)
 	if err != nil {
 		return TestimonialResponse{}, err
 	}
 	return ToTestimonialResponse(data), nil
 }
 
-func (s *service) UpdateTestimonial(p UpdateTestimonialRequest) error {
-	_, err := s.repo.FindById(p.ID)
+func (s *service) UpdateTestimonial(ctx context.Context, p UpdateTestimonialRequest) error {
+	_, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
@@ -76,7 +80,7 @@ func (s *service) UpdateTestimonial(p UpdateTestimonialRequest) error {
 		IsUsed:     p.IsUsed == "Y",
 	}
 
-	err = s.repo.UpdateTestimonial(payload)
+	err = s.repo.UpdateTestimonial(ctx, payload)
 	if err != nil {
 		return err
 	}
@@ -84,43 +88,43 @@ func (s *service) UpdateTestimonial(p UpdateTestimonialRequest) error {
 	return nil
 }
 
-func (s *service) DeleteTestimonial(id int) (Testimonial, error) {
-	data, err := s.repo.DeleteTestimonial(id)
+func (s *service) DeleteTestimonial(ctx context.Context, id int) (Testimonial, error) {
+	data, err := s.repo.DeleteTestimonial(ctx, id)
 	if err != nil {
 		return Testimonial{}, err
 	}
 	return data, nil
 }
 
-func (s *service) ChangeStatusTestimonial(id int, isUsed string) error {
-	_, err := s.repo.FindById(id)
+func (s *service) ChangeStatusTestimonial(ctx context.Context, id int, isUsed string) error {
+	_, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	isUsedBool := isUsed == "Y"
 
-	err = s.repo.ChangeStatusTestimonial(id, isUsedBool)
+	err = s.repo.ChangeStatusTestimonial(ctx, id, isUsedBool)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *service) ChangeMultiStatusTestimonial(ids []int, isUsed string) error {
-	countData, err := s.repo.FindByMultiId(ids)
+func (s *service) ChangeMultiStatusTestimonial(ctx context.Context, ids []int, isUsed string) error {
+	countData, err := s.repo.FindByMultiId(ctx, ids)
 	if err != nil {
 		return err
 	}
 
 	if len(countData) != len(ids) {
-		err := fmt.Errorf("some testimonial_ids not found in database")
+		err := utils.NewError("some testimonial_ids not found in database")
 		return err
 	}
 
 	isUsedBool := isUsed == "Y"
 
-	err = s.repo.ChangeMultiStatusTestimonial(ids, isUsedBool)
+	err = s.repo.ChangeMultiStatusTestimonial(ctx, ids, isUsedBool)
 	if err != nil {
 		return err
 	}
@@ -46,9 +46,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllTestimonials(params)
+	data, total_records, err := h.service.GetAllTestimonials(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -60,9 +60,9 @@ func (h *handler) GetTestimonialById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetTestimonialById(id)
+	data, err := h.service.GetTestimonialById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -72,29 +72,63 @@ func (h *handler) CreateTestimonial(c *gin.Context) {
 	// Validate the struct using validator
 	var req CreateTestimonialRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CreateTestimonial(req)
+	data, err := h.service.CreateTestimonial(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateTestimonial(c *gin.Context) {
 	var req UpdateTestimonialRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.updateTestimonial(c, req)
+}
+
+// PatchTestimonial updates the fields the body names and keeps the others.
+func (h *handler) PatchTestimonial(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetTestimonialById(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UpdateTestimonialRequest{
+		ID:         id,
+		Name:       current.Name,
+		Via:        current.Via,
+		Role:       current.Role,
+		Message:    current.Message,
+		WorkingAt:  current.WorkingAt,
+		CompanyURL: current.CompanyURL,
+		IsUsed:     current.IsUsed,
+	}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
+	req.ID = id
+
+	h.updateTestimonial(c, req)
+}
 
-	err := h.service.UpdateTestimonial(req)
+func (h *handler) updateTestimonial(c *gin.Context, req UpdateTestimonialRequest) {
+	err := h.service.UpdateTestimonial(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -104,15 +138,25 @@ func (h *handler) UpdateTestimonial(c *gin.Context) {
 func (h *handler) DeleteTestimonial(c *gin.Context) {
 	var req TestimonialDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteTestimonial(c, req.ID)
+}
+
+func (h *handler) DeleteTestimonialById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteTestimonial(c, id)
+}
 
-	data, err := h.service.DeleteTestimonial(id)
+func (h *handler) deleteTestimonial(c *gin.Context, id int) {
+	data, err := h.service.DeleteTestimonial(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -121,13 +165,13 @@ func (h *handler) DeleteTestimonial(c *gin.Context) {
 func (h *handler) ChangeStatusTestimonial(c *gin.Context) {
 	var req TestimonialChangeStatusRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	err := h.service.ChangeStatusTestimonial(req.ID, req.IsUsed)
+	err := h.service.ChangeStatusTestimonial(c.Request.Context(), req.ID, req.IsUsed)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success change status", nil)
@@ -136,13 +180,13 @@ func (h *handler) ChangeStatusTestimonial(c *gin.Context) {
 func (h *handler) ChangeMultiStatusTestimonial(c *gin.Context) {
 	var req TestimonialChangeMultiStatusRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	err := h.service.ChangeMultiStatusTestimonial(req.IDs, req.IsUsed)
+	err := h.service.ChangeMultiStatusTestimonial(c.Request.Context(), req.IDs, req.IsUsed)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success change status", nil)
@@ -2,16 +2,13 @@ package topic
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	topic := r.Group("/topics")
@@ -24,3 +21,18 @@ func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
 		topic.POST("/check-has-ids", h.CheckTopicIds)
 	}
 }
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	topic := r.Group("/topics")
+	{
+		topic.GET("", h.GetAll)
+		topic.GET("/:id", h.GetTopicById)
+		topic.POST("", h.CreateTopic)
+		topic.PATCH("/:id", h.PatchTopic)
+		topic.DELETE("/:id", h.DeleteTopicById)
+		topic.POST("/check-has-ids", h.CheckTopicIds)
+	}
+}
@@ -1,20 +1,21 @@
 package topic
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllTopicParams) ([]Topic, int, error)
-	FindById(id int) (Topic, error)
-	CreateTopic(p CreateTopicRequest) (Topic, error)
-	UpdateTopic(p UpdateTopicRequest) error
-	DeleteTopic(id int) (Topic, error)
-	CheckTopicIds(ids []int) ([]Topic, error)
+	FindAll(ctx context.Context, params GetAllTopicParams) ([]Topic, int, error)
+	FindById(ctx context.Context, id int) (Topic, error)
+	CreateTopic(ctx context.Context, p CreateTopicRequest) (Topic, error)
+	UpdateTopic(ctx context.Context, p UpdateTopicRequest) error
+	DeleteTopic(ctx context.Context, id int) (Topic, error)
+	CheckTopicIds(ctx context.Context, ids []int) ([]Topic, error)
 }
 
 type repository struct {
@@ -25,7 +26,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllTopicParams) ([]Topic, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllTopicParams) ([]Topic, int, error) {
 	var topics []Topic
 	var totalCount int
 
@@ -42,29 +43,18 @@ func (r *repository) FindAll(params GetAllTopicParams) ([]Topic, int, error) {
 
 	//? field "name"
 	if params.Name != "" {
-		whereClauses = append(whereClauses, "(name LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Name+"%")
+		whereClauses = append(whereClauses, "(LOWER(name) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Name))
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -78,7 +68,7 @@ func (r *repository) FindAll(params GetAllTopicParams) ([]Topic, int, error) {
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -108,7 +98,7 @@ func (r *repository) FindAll(params GetAllTopicParams) ([]Topic, int, error) {
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&topics).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&topics).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -117,37 +107,37 @@ func (r *repository) FindAll(params GetAllTopicParams) ([]Topic, int, error) {
 	return topics, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (Topic, error) {
+func (r *repository) FindById(ctx context.Context, id int) (Topic, error) {
 	var data Topic
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) CreateTopic(p CreateTopicRequest) (Topic, error) {
+func (r *repository) CreateTopic(ctx context.Context, p CreateTopicRequest) (Topic, error) {
 	data := Topic{
 		Name: p.Name}
-	err := r.db.Create(&data).Error
+	err := r.db.WithContext(ctx).Create(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateTopic(p UpdateTopicRequest) error {
+func (r *repository) UpdateTopic(ctx context.Context, p UpdateTopicRequest) error {
 	data := Topic{
 		ID:   p.ID,
 		Name: p.Name}
-	err := r.db.Updates(&data).Error
+	err := r.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
 
-func (r *repository) DeleteTopic(id int) (Topic, error) {
+func (r *repository) DeleteTopic(ctx context.Context, id int) (Topic, error) {
 	var data Topic
 
 	// Step 1: Find by ID
-	if err := r.db.First(&data, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
 		return Topic{}, err // return if not found or any error
 	}
 
 	// Step 2: Delete
-	if err := r.db.Unscoped().Delete(&data).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Delete(&data).Error; err != nil {
 		return Topic{}, err
 	}
 
@@ -155,8 +145,8 @@ func (r *repository) DeleteTopic(id int) (Topic, error) {
 	return data, nil
 }
 
-func (r *repository) CheckTopicIds(ids []int) ([]Topic, error) {
+func (r *repository) CheckTopicIds(ctx context.Context, ids []int) ([]Topic, error) {
 	var data []Topic
-	err := r.db.Where("id in (?)", ids).Select("id", "name").Find(&data).Error
+	err := r.db.WithContext(ctx).Where("id in (?)", ids).Select("id", "name").Find(&data).Error
 	return data, err
 }
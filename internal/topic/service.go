@@ -1,14 +1,18 @@
 package topic
 
-import "fmt"
+import (
+	"context"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+)
 
 type Service interface {
-	GetAllTopics(params GetAllTopicParams) ([]TopicResponse, int, error)
-	GetTopicById(id int) (TopicResponse, error)
-	CreateTopic(p CreateTopicRequest) (TopicResponse, error)
-	UpdateTopic(p UpdateTopicRequest) error
-	DeleteTopic(id int) (Topic, error)
-	CheckTopicIds(ids []int) ([]TopicHasCheckResponse, error)
+	GetAllTopics(ctx context.Context, params GetAllTopicParams) ([]TopicResponse, int, error)
+	GetTopicById(ctx context.Context, id int) (TopicResponse, error)
+	CreateTopic(ctx context.Context, p CreateTopicRequest) (TopicResponse, error)
+	UpdateTopic(ctx context.Context, p UpdateTopicRequest) error
+	DeleteTopic(ctx context.Context, id int) (Topic, error)
+	CheckTopicIds(ctx context.Context, ids []int) ([]TopicHasCheckResponse, error)
 }
 
 type service struct {
@@ -19,8 +23,8 @@ func NewService(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) GetAllTopics(params GetAllTopicParams) ([]TopicResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllTopics(ctx context.Context, params GetAllTopicParams) ([]TopicResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -32,29 +36,29 @@ func (s *service) GetAllTopics(params GetAllTopicParams) ([]TopicResponse, int,
 	return result, total, nil
 }
 
-func (s *service) GetTopicById(id int) (TopicResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetTopicById(ctx context.Context, id int) (TopicResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return TopicResponse{}, err
 	}
 	return ToTopicResponse(data), nil
 }
 
-func (s *service) CreateTopic(p CreateTopicRequest) (TopicResponse, error) {
-	data, err := s.repo.CreateTopic(p)
+func (s *service) CreateTopic(ctx context.Context, p CreateTopicRequest) (TopicResponse, error) {
+	data, err := s.repo.CreateTopic(ctx, p)
 	if err != nil {
 		return TopicResponse{}, err
 	}
 	return ToTopicResponse(data), nil
 }
 
-func (s *service) UpdateTopic(p UpdateTopicRequest) error {
-	_, err := s.repo.FindById(p.ID)
+func (s *service) UpdateTopic(ctx context.Context, p UpdateTopicRequest) error {
+	_, err := s.repo.FindById(ctx, p.ID)
 	if err != nil {
 		return err
 	}
 
-	err = s.repo.UpdateTopic(p)
+	err = s.repo.UpdateTopic(ctx, p)
 	if err != nil {
 		return err
 	}
@@ -62,22 +66,22 @@ func (s *service) UpdateTopic(p UpdateTopicRequest) error {
 	return nil
 }
 
-func (s *service) DeleteTopic(id int) (Topic, error) {
-	data, err := s.repo.DeleteTopic(id)
+func (s *service) DeleteTopic(ctx context.Context, id int) (Topic, error) {
+	data, err := s.repo.DeleteTopic(ctx, id)
 	if err != nil {
 		return Topic{}, err
 	}
 	return data, nil
 }
 
-func (s *service) CheckTopicIds(ids []int) ([]TopicHasCheckResponse, error) {
-	data, err := s.repo.CheckTopicIds(ids)
+func (s *service) CheckTopicIds(ctx context.Context, ids []int) ([]TopicHasCheckResponse, error) {
+	data, err := s.repo.CheckTopicIds(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(data) != len(ids) {
-		err := fmt.Errorf("some topic_ids not found in database")
+		err := utils.NewError("some topic_ids not found in database")
 		return nil, err
 	}
 
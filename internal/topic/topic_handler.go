@@ -40,9 +40,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllTopics(params)
+	data, total_records, err := h.service.GetAllTopics(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -54,9 +54,9 @@ func (h *handler) GetTopicById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetTopicById(id)
+	data, err := h.service.GetTopicById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -66,29 +66,54 @@ func (h *handler) CreateTopic(c *gin.Context) {
 	// Validate the struct using validator
 	var req CreateTopicRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CreateTopic(req)
+	data, err := h.service.CreateTopic(c.Request.Context(), req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.Success(c, "success get data", data)
+	utils.CreatedRecord(c, "success create data", data.ID, data)
 }
 
 func (h *handler) UpdateTopic(c *gin.Context) {
 	var req UpdateTopicRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	err := h.service.UpdateTopic(req)
+	h.updateTopic(c, req)
+}
+
+// PatchTopic updates the fields the body names and keeps the others.
+func (h *handler) PatchTopic(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetTopicById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UpdateTopicRequest{ID: id, Name: current.Name}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.updateTopic(c, req)
+}
+
+func (h *handler) updateTopic(c *gin.Context, req UpdateTopicRequest) {
+	err := h.service.UpdateTopic(c.Request.Context(), req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -98,15 +123,25 @@ func (h *handler) UpdateTopic(c *gin.Context) {
 func (h *handler) DeleteTopic(c *gin.Context) {
 	var req TopicDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteTopic(c, req.ID)
+}
+
+func (h *handler) DeleteTopicById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteTopic(c, id)
+}
 
-	data, err := h.service.DeleteTopic(id)
+func (h *handler) deleteTopic(c *gin.Context, id int) {
+	data, err := h.service.DeleteTopic(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", data)
@@ -115,13 +150,13 @@ func (h *handler) DeleteTopic(c *gin.Context) {
 func (h *handler) CheckTopicIds(c *gin.Context) {
 	var req TopicCheckIdsRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	data, err := h.service.CheckTopicIds(req.Ids)
+	data, err := h.service.CheckTopicIds(c.Request.Context(), req.Ids)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success fetch data", data)
@@ -10,9 +10,33 @@ type UserResponse struct {
 	ID        int    `json:"id"`
 	Username  string `json:"username"`
 	Email     string `json:"email"`
+	Role      string `json:"role"`
+	AuthorID  *int   `json:"author_id"`
 	CreatedAt string `json:"created_at"`
 }
 
+type UserLinkAuthorRequest struct {
+	ID       int  `json:"id" binding:"required"`
+	AuthorID *int `json:"author_id"`
+}
+
+type UserCreateRequest struct {
+	Username string `json:"username" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=6"`
+	Role     string `json:"role" validate:"required,oneof=admin editor author"`
+}
+
+type UserResetPasswordRequest struct {
+	ID       int    `json:"id" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+type UserChangeRoleRequest struct {
+	ID   int    `json:"id" validate:"required"`
+	Role string `json:"role" validate:"required,oneof=admin editor author"`
+}
+
 type UserDeleteRequest struct {
 	ID int `json:"id" binding:"required"`
 }
@@ -32,6 +56,8 @@ func ToUserResponse(p User) UserResponse {
 		ID:        p.ID,
 		Username:  p.Username,
 		Email:     p.Email,
+		Role:      p.Role,
+		AuthorID:  p.AuthorID,
 		CreatedAt: p.CreatedAt.Format("2006-01-02"),
 	}
 }
@@ -2,23 +2,39 @@ package user
 
 import (
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 )
 
 type handler struct {
 	service Service
 }
 
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	repo := NewRepository(db)
-	service := NewService(repo)
+func RegisterRoutes(r *gin.RouterGroup, service Service) {
 	h := handler{service: service}
 
 	user := r.Group("/users")
+	//? accounts are managed by a logged-in user only, never by an API key
+	user.Use(utils.RequireUserLogin())
 	{
 		user.GET("", h.GetAll)
 		user.GET("/:id", h.GetUserById)
 		user.POST("/update", h.UpdateUser)
 		user.POST("/delete", h.DeleteUser)
+		user.POST("/link-author", h.LinkAuthor)
+	}
+}
+
+// RegisterRoutesV2 mounts the RESTful routes, which take the ID from the path.
+func RegisterRoutesV2(r *gin.RouterGroup, service Service) {
+	h := handler{service: service}
+
+	user := r.Group("/users")
+	user.Use(utils.RequireUserLogin())
+	{
+		user.GET("", h.GetAll)
+		user.GET("/:id", h.GetUserById)
+		user.PATCH("/:id", h.PatchUser)
+		user.DELETE("/:id", h.DeleteUserById)
+		user.PUT("/:id/author", h.PutUserAuthor)
 	}
 }
@@ -6,12 +6,41 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleAuthor = "author"
+)
+
 type User struct {
 	ID        int    `json:"id" gorm:"primaryKey"`
 	Username  string `json:"username"`
 	Email     string `json:"email"`
 	Password  string `json:"password"`
+	Role      string `json:"role"`
+	AuthorID  *int   `json:"author_id"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
+
+// Actor is the authenticated user performing a request.
+type Actor struct {
+	UserID   int
+	Role     string
+	AuthorID *int
+}
+
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// CanReview reports whether the actor may be assigned as a reviewer.
+func (a Actor) CanReview() bool {
+	return a.Role == RoleAdmin || a.Role == RoleEditor
+}
+
+// OwnsAuthor reports whether the actor's linked author profile is authorID.
+func (a Actor) OwnsAuthor(authorID int) bool {
+	return a.AuthorID != nil && *a.AuthorID == authorID
+}
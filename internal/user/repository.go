@@ -1,19 +1,26 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindAll(params GetAllUserParams) ([]User, int, error)
-	FindById(id int) (User, error)
-	UpdateUser(user User) (User, error)
-	DeleteUser(id int) error
-	CheckUniqueEmail(email string) (bool, error)
+	FindAll(ctx context.Context, params GetAllUserParams) ([]User, int, error)
+	FindById(ctx context.Context, id int) (User, error)
+	CreateUser(ctx context.Context, user User) (User, error)
+	UpdateUser(ctx context.Context, user User) (User, error)
+	UpdatePassword(ctx context.Context, id int, password string) error
+	UpdateRole(ctx context.Context, id int, role string) error
+	DeleteUser(ctx context.Context, id int) error
+	CountByRole(ctx context.Context, role string) (int, error)
+	CheckUniqueEmail(ctx context.Context, email string) (bool, error)
+	FindByAuthorId(ctx context.Context, authorID int) (User, error)
+	LinkAuthor(ctx context.Context, id int, authorID *int) error
 }
 
 type repository struct {
@@ -24,7 +31,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(params GetAllUserParams) ([]User, int, error) {
+func (r *repository) FindAll(ctx context.Context, params GetAllUserParams) ([]User, int, error) {
 	var users []User
 	var totalCount int
 
@@ -41,35 +48,24 @@ func (r *repository) FindAll(params GetAllUserParams) ([]User, int, error) {
 
 	//? field "username"
 	if params.Username != "" {
-		whereClauses = append(whereClauses, "(username LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Username+"%")
+		whereClauses = append(whereClauses, "(LOWER(username) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Username))
 	}
 
 	//? field "email"
 	if params.Email != "" {
-		whereClauses = append(whereClauses, "(email LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.Email+"%")
+		whereClauses = append(whereClauses, "(LOWER(email) LIKE ?)")
+		queryArgs = append(queryArgs, utils.ContainsPattern(params.Email))
 	}
 
 	//? field "created_at"
-	if len(params.CreatedAt) == 1 {
-		whereClauses = append(whereClauses, "(created_at LIKE ?)")
-		queryArgs = append(queryArgs, "%"+params.CreatedAt[0]+"%")
-	} else if len(params.CreatedAt) == 2 {
-		// Parse the dates and adjust the time for the range
-		startDate, err := time.Parse("2006-01-02", params.CreatedAt[0])
+	if len(params.CreatedAt) > 0 {
+		clause, args, err := utils.DateFilter("created_at", params.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
-		endDate, err := time.Parse("2006-01-02", params.CreatedAt[1])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		startDate = startDate.Truncate(24 * time.Hour)        // Start at 00:00:00
-		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // End at 23:59:59.999
-		whereClauses = append(whereClauses, "(created_at BETWEEN ? AND ?)")
-		queryArgs = append(queryArgs, startDate, endDate)
+		whereClauses = append(whereClauses, clause)
+		queryArgs = append(queryArgs, args...)
 	}
 
 	//? Construct the WHERE clause
@@ -83,7 +79,7 @@ func (r *repository) FindAll(params GetAllUserParams) ([]User, int, error) {
 		%s`, rawCountSQL, whereSQL)
 
 	// Add LIMIT and OFFSET arguments
-	err := r.db.Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
+	err := r.db.WithContext(ctx).Raw(finalCountSQL, queryArgs...).Scan(&totalCount).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -95,6 +91,8 @@ func (r *repository) FindAll(params GetAllUserParams) ([]User, int, error) {
 			id,
 			username,
 			email,
+			role,
+			author_id,
 			created_at
 		FROM users
 	`
@@ -114,7 +112,7 @@ func (r *repository) FindAll(params GetAllUserParams) ([]User, int, error) {
 	queryArgs = append(queryArgs, params.Limit, offset)
 
 	// Execute the raw SQL query
-	err = r.db.Raw(finalSQL, queryArgs...).Scan(&users).Error
+	err = r.db.WithContext(ctx).Raw(finalSQL, queryArgs...).Scan(&users).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -123,26 +121,37 @@ func (r *repository) FindAll(params GetAllUserParams) ([]User, int, error) {
 	return users, totalCount, nil
 }
 
-func (r *repository) FindById(id int) (User, error) {
+func (r *repository) FindById(ctx context.Context, id int) (User, error) {
 	var data User
-	err := r.db.Where("id = ?", id).First(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&data).Error
 	return data, err
 }
 
-func (r *repository) UpdateUser(user User) (User, error) {
-	err := r.db.Save(&user).Error
+func (r *repository) CreateUser(ctx context.Context, user User) (User, error) {
+	err := r.db.WithContext(ctx).Create(&user).Error
+	return user, err
+}
+
+func (r *repository) UpdateUser(ctx context.Context, user User) (User, error) {
+	err := r.db.WithContext(ctx).Save(&user).Error
 	return user, err
 }
 
-func (r *repository) DeleteUser(id int) error {
+func (r *repository) DeleteUser(ctx context.Context, id int) error {
 	var data User
-	err := r.db.Where("id = ?", id).Delete(&data).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&data).Error
 	return err
 }
 
-func (r *repository) CheckUniqueEmail(email string) (bool, error) {
+func (r *repository) CountByRole(ctx context.Context, role string) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&User{}).Where("role = ?", role).Count(&count).Error
+	return int(count), err
+}
+
+func (r *repository) CheckUniqueEmail(ctx context.Context, email string) (bool, error) {
 	var user User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 
 	if err == gorm.ErrRecordNotFound {
 		return true, nil
@@ -150,3 +159,21 @@ func (r *repository) CheckUniqueEmail(email string) (bool, error) {
 
 	return false, nil
 }
+
+func (r *repository) FindByAuthorId(ctx context.Context, authorID int) (User, error) {
+	var data User
+	err := r.db.WithContext(ctx).Where("author_id = ?", authorID).First(&data).Error
+	return data, err
+}
+
+func (r *repository) LinkAuthor(ctx context.Context, id int, authorID *int) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("author_id", authorID).Error
+}
+
+func (r *repository) UpdatePassword(ctx context.Context, id int, password string) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("password", password).Error
+}
+
+func (r *repository) UpdateRole(ctx context.Context, id int, role string) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("role", role).Error
+}
@@ -1,24 +1,39 @@
 package user
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/internal/author"
+	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
 
 type Service interface {
-	GetAllUsers(params GetAllUserParams) ([]UserResponse, int, error)
-	GetUserById(id int) (UserResponse, error)
-	UpdateUser(user User) (UserResponse, error)
-	DeleteUser(id int) error
+	GetAllUsers(ctx context.Context, params GetAllUserParams) ([]UserResponse, int, error)
+	GetUserById(ctx context.Context, id int) (UserResponse, error)
+	CreateUser(ctx context.Context, req UserCreateRequest) (UserResponse, error)
+	UpdateUser(ctx context.Context, actor Actor, user User) (UserResponse, error)
+	ResetPassword(ctx context.Context, req UserResetPasswordRequest) error
+	ChangeRole(ctx context.Context, req UserChangeRoleRequest) (UserResponse, error)
+	DeleteUser(ctx context.Context, actor Actor, id int) error
+	GetActor(ctx context.Context, userID int) (Actor, error)
+	LinkAuthor(ctx context.Context, actor Actor, req UserLinkAuthorRequest) (UserResponse, error)
 }
 
 type service struct {
-	repo Repository
+	authorService author.Service
+	repo          Repository
 }
 
-func NewService(r Repository) Service {
-	return &service{repo: r}
+func NewService(authorSvc author.Service, r Repository) Service {
+	return &service{authorService: authorSvc, repo: r}
 }
 
-func (s *service) GetAllUsers(params GetAllUserParams) ([]UserResponse, int, error) {
-	datas, total, err := s.repo.FindAll(params)
+func (s *service) GetAllUsers(ctx context.Context, params GetAllUserParams) ([]UserResponse, int, error) {
+	datas, total, err := s.repo.FindAll(ctx, params)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -30,43 +45,178 @@ func (s *service) GetAllUsers(params GetAllUserParams) ([]UserResponse, int, err
 	return result, total, nil
 }
 
-func (s *service) GetUserById(id int) (UserResponse, error) {
-	data, err := s.repo.FindById(id)
+func (s *service) GetUserById(ctx context.Context, id int) (UserResponse, error) {
+	data, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return UserResponse{}, err
+	}
+	return ToUserResponse(data), nil
+}
+
+// CreateUser adds a user with any role. Unlike register, which always creates
+// authors, it is meant for operators, e.g. to create the first admin.
+func (s *service) CreateUser(ctx context.Context, req UserCreateRequest) (UserResponse, error) {
+	//todo: Check Unique Email
+	is_unique, _ := s.repo.CheckUniqueEmail(ctx, req.Email)
+	if !is_unique {
+		return UserResponse{}, utils.NewError("email already exist")
+	}
+
+	hashPass, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return UserResponse{}, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	data, err := s.repo.CreateUser(ctx, User{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: string(hashPass),
+		Role:     req.Role,
+	})
 	if err != nil {
 		return UserResponse{}, err
 	}
 	return ToUserResponse(data), nil
 }
 
-func (s *service) UpdateUser(user User) (UserResponse, error) {
+// UpdateUser changes the username and email of a user. Admins may update
+// anyone, other users only themselves.
+func (s *service) UpdateUser(ctx context.Context, actor Actor, user User) (UserResponse, error) {
+	if !actor.IsAdmin() && actor.UserID != user.ID {
+		return UserResponse{}, utils.ErrForbidden
+	}
+
 	//todo: Get User
-	oldData, err := s.repo.FindById(user.ID)
+	oldData, err := s.repo.FindById(ctx, user.ID)
 	if err != nil {
 		return UserResponse{}, err
 	}
 
 	if oldData.Email != user.Email {
 		//todo: Check Unique Email
-		is_unique, _ := s.repo.CheckUniqueEmail(user.Email)
+		is_unique, _ := s.repo.CheckUniqueEmail(ctx, user.Email)
 		if !is_unique {
-			return UserResponse{}, fmt.Errorf("email already exist")
+			return UserResponse{}, utils.NewError("email already exist")
 		}
 	}
 
 	user.CreatedAt = oldData.CreatedAt
 	user.Password = oldData.Password
+	user.Role = oldData.Role
+	user.AuthorID = oldData.AuthorID
 
-	data, err := s.repo.UpdateUser(user)
+	data, err := s.repo.UpdateUser(ctx, user)
 	if err != nil {
 		return UserResponse{}, err
 	}
 	return ToUserResponse(data), nil
 }
 
-func (s *service) DeleteUser(id int) error {
-	err := s.repo.DeleteUser(id)
+func (s *service) ResetPassword(ctx context.Context, req UserResetPasswordRequest) error {
+	//todo: Get User
+	_, err := s.repo.FindById(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	hashPass, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.repo.UpdatePassword(ctx, req.ID, string(hashPass))
+}
+
+func (s *service) ChangeRole(ctx context.Context, req UserChangeRoleRequest) (UserResponse, error) {
+	//todo: Get User
+	data, err := s.repo.FindById(ctx, req.ID)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	if err := s.repo.UpdateRole(ctx, req.ID, req.Role); err != nil {
+		return UserResponse{}, err
+	}
+
+	data.Role = req.Role
+	return ToUserResponse(data), nil
+}
+
+// DeleteUser removes a user. Only admins may delete users, never themselves
+// nor the last admin, so the users can always be managed.
+func (s *service) DeleteUser(ctx context.Context, actor Actor, id int) error {
+	if !actor.IsAdmin() || actor.UserID == id {
+		return utils.ErrForbidden
+	}
+
+	//todo: Get User
+	data, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if data.Role == RoleAdmin {
+		admins, err := s.repo.CountByRole(ctx, RoleAdmin)
+		if err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return utils.ErrForbidden
+		}
+	}
+
+	err = s.repo.DeleteUser(ctx, id)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+func (s *service) GetActor(ctx context.Context, userID int) (Actor, error) {
+	data, err := s.repo.FindById(ctx, userID)
+	if err != nil {
+		return Actor{}, utils.NewError("user {0} not found", userID)
+	}
+
+	return Actor{
+		UserID:   data.ID,
+		Role:     data.Role,
+		AuthorID: data.AuthorID,
+	}, nil
+}
+
+func (s *service) LinkAuthor(ctx context.Context, actor Actor, req UserLinkAuthorRequest) (UserResponse, error) {
+	if !actor.IsAdmin() {
+		return UserResponse{}, utils.ErrForbidden
+	}
+
+	//todo: Get User
+	data, err := s.repo.FindById(ctx, req.ID)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	if req.AuthorID != nil {
+		//todo: Check Author Id
+		_, err := s.authorService.GetAuthorById(ctx, *req.AuthorID)
+		if err != nil {
+			return UserResponse{}, err
+		}
+
+		//todo: Check Author Not Linked To Another User
+		linkedUser, err := s.repo.FindByAuthorId(ctx, *req.AuthorID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return UserResponse{}, err
+		}
+		if err == nil && linkedUser.ID != data.ID {
+			return UserResponse{}, utils.NewError("author_id {0} is already linked to user {1}", *req.AuthorID, linkedUser.ID)
+		}
+	}
+
+	err = s.repo.LinkAuthor(ctx, data.ID, req.AuthorID)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	data.AuthorID = req.AuthorID
+	return ToUserResponse(data), nil
+}
@@ -9,6 +9,25 @@ import (
 	"github.com/rogersovich/go-portofolio-clean-arch-v4/pkg/utils"
 )
 
+// AuthActor loads the authenticated user, writing a 401 when it cannot. Why
+// the user could not be loaded is logged, not told to the client.
+func AuthActor(c *gin.Context, service Service) (Actor, bool) {
+	userID, ok := utils.GetAuthUserID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return Actor{}, false
+	}
+
+	actor, err := service.GetActor(c.Request.Context(), userID)
+	if err != nil {
+		utils.LoggerFrom(c.Request.Context()).WithError(err).Warn("load authenticated user")
+		utils.Error(c, http.StatusUnauthorized, "Unauthorized request")
+		return Actor{}, false
+	}
+
+	return actor, true
+}
+
 func (h *handler) GetAll(c *gin.Context) {
 	page := utils.GetQueryParamInt(c, "page", 1) // Default to page 1
 	limit := utils.GetQueryParamInt(c, "limit", 10)
@@ -42,9 +61,9 @@ func (h *handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	data, total_records, err := h.service.GetAllUsers(params)
+	data, total_records, err := h.service.GetAllUsers(c.Request.Context(), params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.PaginatedSuccess(c, "success get all data", data, page, limit, total_records)
@@ -56,9 +75,9 @@ func (h *handler) GetUserById(c *gin.Context) {
 		utils.Error(c, http.StatusBadRequest, "invalid ID")
 		return
 	}
-	data, err := h.service.GetUserById(id)
+	data, err := h.service.GetUserById(c.Request.Context(), id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success get data", data)
@@ -68,7 +87,37 @@ func (h *handler) UpdateUser(c *gin.Context) {
 	// Validate the struct using validator
 	var req UserUpdateRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.updateUser(c, req)
+}
+
+// PatchUser updates the fields the body names and keeps the others.
+func (h *handler) PatchUser(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	current, err := h.service.GetUserById(c.Request.Context(), id)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	req := UserUpdateRequest{ID: id, Username: current.Username, Email: current.Email}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.updateUser(c, req)
+}
+
+func (h *handler) updateUser(c *gin.Context, req UserUpdateRequest) {
+	actor, ok := AuthActor(c, h.service)
+	if !ok {
 		return
 	}
 
@@ -78,9 +127,9 @@ func (h *handler) UpdateUser(c *gin.Context) {
 		Email:    req.Email,
 	}
 
-	data, err := h.service.UpdateUser(payload)
+	data, err := h.service.UpdateUser(c.Request.Context(), actor, payload)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success updated data", data)
@@ -90,16 +139,72 @@ func (h *handler) DeleteUser(c *gin.Context) {
 	// Validate the struct using validator
 	var req UserDeleteRequest
 
-	if !utils.ValidateStruct(c, &req, c.ShouldBindJSON(&req)) {
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
 		return
 	}
 
-	id := req.ID
+	h.deleteUser(c, req.ID)
+}
 
-	err := h.service.DeleteUser(id)
+func (h *handler) DeleteUserById(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+	h.deleteUser(c, id)
+}
+
+func (h *handler) deleteUser(c *gin.Context, id int) {
+	actor, ok := AuthActor(c, h.service)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteUser(c.Request.Context(), actor, id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err.Error())
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.Success(c, "success deleted data", nil)
 }
+
+func (h *handler) LinkAuthor(c *gin.Context) {
+	var req UserLinkAuthorRequest
+
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	h.linkAuthor(c, req)
+}
+
+// PutUserAuthor links the user in the path to the author in the body, or
+// unlinks it when author_id is null.
+func (h *handler) PutUserAuthor(c *gin.Context) {
+	id, ok := utils.PathID(c)
+	if !ok {
+		return
+	}
+
+	req := UserLinkAuthorRequest{ID: id}
+	if !utils.ValidateStruct(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+	req.ID = id
+
+	h.linkAuthor(c, req)
+}
+
+func (h *handler) linkAuthor(c *gin.Context, req UserLinkAuthorRequest) {
+	actor, ok := AuthActor(c, h.service)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.LinkAuthor(c.Request.Context(), actor, req)
+	if err != nil {
+		utils.ErrorFrom(c, http.StatusInternalServerError, err)
+		return
+	}
+	utils.Success(c, "success link author", data)
+}
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP INDEX uq_users_author_id,
+    DROP COLUMN author_id,
+    DROP COLUMN role;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'author' AFTER password,
+    ADD COLUMN author_id BIGINT UNSIGNED NULL AFTER role,
+    ADD UNIQUE KEY uq_users_author_id (author_id);
+
+-- Accounts created before roles existed keep full access
+UPDATE users SET role = 'admin';
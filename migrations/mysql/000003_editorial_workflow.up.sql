@@ -0,0 +1,24 @@
+-- Collapse the legacy PUBLISHED/Published/UNPUBLISHED/Unpublished spellings
+UPDATE blogs SET status = IF(UPPER(status) = 'PUBLISHED', 'Published', 'Draft');
+UPDATE projects SET status = IF(UPPER(status) = 'PUBLISHED', 'Published', 'Draft');
+
+ALTER TABLE blogs
+    ADD COLUMN reviewer_id BIGINT UNSIGNED NULL AFTER status;
+
+ALTER TABLE projects
+    ADD COLUMN reviewer_id BIGINT UNSIGNED NULL AFTER status;
+
+CREATE TABLE IF NOT EXISTS editorial_events (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    content_type VARCHAR(20) NOT NULL,
+    content_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    action VARCHAR(30) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reviewer_id BIGINT UNSIGNED NULL,
+    comment TEXT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    KEY idx_editorial_events_content (content_type, content_id)
+);
@@ -0,0 +1,15 @@
+-- Count the updates of the records admins edit, for optimistic concurrency
+ALTER TABLE abouts
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+
+ALTER TABLE blogs
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+
+ALTER TABLE experiences
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+
+ALTER TABLE projects
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+
+ALTER TABLE technologies
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+-- Idempotency-Key of create requests and the response replayed to retries
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    scope VARCHAR(100) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    response_status SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+    response_content_type VARCHAR(100) NOT NULL DEFAULT '',
+    response_body MEDIUMTEXT NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_idempotency_keys_scope_key (scope, idempotency_key),
+    KEY idx_idempotency_keys_expires_at (expires_at)
+);
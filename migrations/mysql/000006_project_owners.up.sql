@@ -0,0 +1,3 @@
+-- Record who created a project, its owner in the editorial workflow
+ALTER TABLE projects
+    ADD COLUMN owner_id BIGINT UNSIGNED NULL AFTER status;
@@ -0,0 +1,3 @@
+-- Response headers replayed to retries next to the body, e.g. Location and ETag
+ALTER TABLE idempotency_keys
+    ADD COLUMN response_headers VARCHAR(2048) NOT NULL DEFAULT '' AFTER response_content_type;
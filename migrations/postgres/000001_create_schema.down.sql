@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS testimonials;
+DROP TABLE IF EXISTS experiences;
+DROP TABLE IF EXISTS project_content_images;
+DROP TABLE IF EXISTS project_technologies;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS blog_content_images;
+DROP TABLE IF EXISTS blog_topics;
+DROP TABLE IF EXISTS blogs;
+DROP TABLE IF EXISTS technologies;
+DROP TABLE IF EXISTS topics;
+DROP TABLE IF EXISTS reading_times;
+DROP TABLE IF EXISTS statistics;
+DROP TABLE IF EXISTS abouts;
+DROP TABLE IF EXISTS authors;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,222 @@
+-- PostgreSQL has no legacy database to upgrade, so this migration creates the
+-- schema as it stood when the MySQL migrations started (plus api_keys).
+-- Later migrations are ported one to one and keep the same version numbers.
+
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS authors (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    avatar_url VARCHAR(255) NOT NULL DEFAULT '',
+    avatar_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at);
+
+CREATE TABLE IF NOT EXISTS abouts (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description_html TEXT NOT NULL,
+    avatar_url VARCHAR(255) NOT NULL DEFAULT '',
+    avatar_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    is_used BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_abouts_deleted_at ON abouts (deleted_at);
+
+CREATE TABLE IF NOT EXISTS statistics (
+    id BIGSERIAL PRIMARY KEY,
+    likes INTEGER NULL,
+    views INTEGER NULL,
+    type VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_statistics_deleted_at ON statistics (deleted_at);
+
+CREATE TABLE IF NOT EXISTS reading_times (
+    id BIGSERIAL PRIMARY KEY,
+    minutes INTEGER NOT NULL,
+    text_length INTEGER NOT NULL,
+    estimated_seconds DOUBLE PRECISION NOT NULL,
+    word_count INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_reading_times_deleted_at ON reading_times (deleted_at);
+
+CREATE TABLE IF NOT EXISTS topics (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_topics_deleted_at ON topics (deleted_at);
+
+CREATE TABLE IF NOT EXISTS technologies (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description_html TEXT NOT NULL,
+    logo_url VARCHAR(255) NOT NULL DEFAULT '',
+    logo_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    is_major BOOLEAN NOT NULL DEFAULT FALSE,
+    link VARCHAR(255) NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_technologies_deleted_at ON technologies (deleted_at);
+
+CREATE TABLE IF NOT EXISTS blogs (
+    id BIGSERIAL PRIMARY KEY,
+    statistic_id BIGINT NOT NULL,
+    reading_time_id BIGINT NOT NULL,
+    author_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description_html TEXT NOT NULL,
+    banner_url VARCHAR(255) NOT NULL DEFAULT '',
+    banner_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    summary TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    is_highlight BOOLEAN NOT NULL DEFAULT FALSE,
+    published_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_blogs_deleted_at ON blogs (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_blogs_slug ON blogs (slug);
+
+CREATE TABLE IF NOT EXISTS blog_topics (
+    id BIGSERIAL PRIMARY KEY,
+    blog_id BIGINT NOT NULL,
+    topic_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_blog_topics_deleted_at ON blog_topics (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_blog_topics_blog_id ON blog_topics (blog_id);
+
+CREATE TABLE IF NOT EXISTS blog_content_images (
+    id BIGSERIAL PRIMARY KEY,
+    blog_id BIGINT NULL,
+    image_url VARCHAR(255) NOT NULL,
+    image_file_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_blog_content_images_deleted_at ON blog_content_images (deleted_at);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id BIGSERIAL PRIMARY KEY,
+    statistic_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    image_url VARCHAR(255) NOT NULL DEFAULT '',
+    image_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    repository_url VARCHAR(255) NULL,
+    summary TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    is_highlight BOOLEAN NOT NULL DEFAULT FALSE,
+    published_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_projects_slug ON projects (slug);
+
+CREATE TABLE IF NOT EXISTS project_technologies (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    technology_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_project_technologies_deleted_at ON project_technologies (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_project_technologies_project_id ON project_technologies (project_id);
+
+CREATE TABLE IF NOT EXISTS project_content_images (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NULL,
+    image_url VARCHAR(255) NOT NULL,
+    image_file_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_project_content_images_deleted_at ON project_content_images (deleted_at);
+
+CREATE TABLE IF NOT EXISTS experiences (
+    id BIGSERIAL PRIMARY KEY,
+    position VARCHAR(255) NOT NULL,
+    company_name VARCHAR(255) NOT NULL,
+    work_type VARCHAR(50) NOT NULL,
+    country VARCHAR(100) NOT NULL,
+    city VARCHAR(100) NULL,
+    summary_html TEXT NOT NULL,
+    from_date TIMESTAMPTZ NOT NULL,
+    to_date TIMESTAMPTZ NULL,
+    comp_image_url VARCHAR(255) NOT NULL DEFAULT '',
+    comp_image_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    comp_website_url VARCHAR(255) NOT NULL DEFAULT '',
+    is_current BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_experiences_deleted_at ON experiences (deleted_at);
+
+CREATE TABLE IF NOT EXISTS testimonials (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    via VARCHAR(255) NULL,
+    role VARCHAR(255) NULL,
+    message TEXT NULL,
+    working_at VARCHAR(255) NULL,
+    company_url VARCHAR(255) NULL,
+    is_used BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_testimonials_deleted_at ON testimonials (deleted_at);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    CONSTRAINT uq_api_keys_key_hash UNIQUE (key_hash)
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_deleted_at ON api_keys (deleted_at);
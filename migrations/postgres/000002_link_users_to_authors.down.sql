@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP CONSTRAINT uq_users_author_id,
+    DROP COLUMN author_id,
+    DROP COLUMN role;
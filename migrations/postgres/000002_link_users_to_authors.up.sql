@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'author',
+    ADD COLUMN author_id BIGINT NULL,
+    ADD CONSTRAINT uq_users_author_id UNIQUE (author_id);
+
+-- Accounts created before roles existed keep full access
+UPDATE users SET role = 'admin';
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS editorial_events;
+
+ALTER TABLE projects
+    DROP COLUMN reviewer_id;
+
+ALTER TABLE blogs
+    DROP COLUMN reviewer_id;
+
+UPDATE blogs SET status = 'Unpublished' WHERE status <> 'Published';
+UPDATE projects SET status = 'Unpublished' WHERE status <> 'Published';
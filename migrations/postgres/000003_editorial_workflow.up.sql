@@ -0,0 +1,23 @@
+-- Collapse the legacy PUBLISHED/Published/UNPUBLISHED/Unpublished spellings
+UPDATE blogs SET status = CASE WHEN UPPER(status) = 'PUBLISHED' THEN 'Published' ELSE 'Draft' END;
+UPDATE projects SET status = CASE WHEN UPPER(status) = 'PUBLISHED' THEN 'Published' ELSE 'Draft' END;
+
+ALTER TABLE blogs
+    ADD COLUMN reviewer_id BIGINT NULL;
+
+ALTER TABLE projects
+    ADD COLUMN reviewer_id BIGINT NULL;
+
+CREATE TABLE IF NOT EXISTS editorial_events (
+    id BIGSERIAL PRIMARY KEY,
+    content_type VARCHAR(20) NOT NULL,
+    content_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    action VARCHAR(30) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reviewer_id BIGINT NULL,
+    comment TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_editorial_events_content ON editorial_events (content_type, content_id);
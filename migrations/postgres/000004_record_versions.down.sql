@@ -0,0 +1,14 @@
+ALTER TABLE technologies
+    DROP COLUMN version;
+
+ALTER TABLE projects
+    DROP COLUMN version;
+
+ALTER TABLE experiences
+    DROP COLUMN version;
+
+ALTER TABLE blogs
+    DROP COLUMN version;
+
+ALTER TABLE abouts
+    DROP COLUMN version;
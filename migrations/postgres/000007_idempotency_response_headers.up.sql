@@ -0,0 +1,3 @@
+-- Response headers replayed to retries next to the body, e.g. Location and ETag
+ALTER TABLE idempotency_keys
+    ADD COLUMN response_headers TEXT NOT NULL DEFAULT '';
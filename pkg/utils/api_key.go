@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+const (
+	APIKeyPrefix = "pfk_"
+
+	ScopeReadOnly     = "read-only"
+	ScopeContentWrite = "content-write"
+
+	AuthTypeJWT    = "jwt"
+	AuthTypeAPIKey = "api_key"
+)
+
+var AllowedAPIKeyScopes = []string{ScopeReadOnly, ScopeContentWrite}
+
+// APIKeyPrincipal is the identity resolved from a valid API key.
+type APIKeyPrincipal struct {
+	KeyID    int
+	UserID   int
+	Username string
+	Scopes   []string
+}
+
+// APIKeyAuthenticator resolves a raw API key to its owner, or returns an error when the key is unknown, revoked or expired.
+type APIKeyAuthenticator func(ctx context.Context, rawKey string) (APIKeyPrincipal, error)
+
+// GenerateAPIKey returns a new random key and the short prefix shown to users to identify it.
+func GenerateAPIKey() (rawKey string, displayPrefix string, err error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	secret := hex.EncodeToString(buf)
+	rawKey = APIKeyPrefix + secret
+	displayPrefix = APIKeyPrefix + secret[:8]
+
+	return rawKey, displayPrefix, nil
+}
+
+// HashAPIKey hashes a raw key for storage and lookup. Keys carry 192 bits of entropy, so a fast hash is sufficient.
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+func SplitScopes(scopes string) []string {
+	var result []string
+	for _, s := range strings.Split(scopes, ",") {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func HasScope(scopes []string, scope string) bool {
+	return slices.Contains(scopes, scope)
+}
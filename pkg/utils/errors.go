@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// ErrForbidden is returned by services when the caller may not act on a resource.
+var ErrForbidden = errors.New("you are not allowed to perform this action")
+
+// StatusError is a service error that should be reported with a specific HTTP
+// status, or the handler's own when Status is zero. Its message is localized
+// for the client.
+type StatusError struct {
+	Status  int
+	Message Message
+}
+
+func (e *StatusError) Error() string {
+	return e.Message.String()
+}
+
+// NewStatusError returns the error of status with message, an English
+// template whose {0}, {1}... placeholders are replaced by params.
+func NewStatusError(status int, message string, params ...any) error {
+	return &StatusError{Status: status, Message: NewMessage(message, params...)}
+}
+
+// NewError is NewStatusError leaving the status to the handler.
+func NewError(message string, params ...any) error {
+	return NewStatusError(0, message, params...)
+}
+
+// ErrorMessage returns the message of err to show clients.
+func ErrorMessage(err error) Message {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Error() == err.Error() {
+		return statusErr.Message
+	}
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		return NewMessage("version {0} is stale, the current version is {1}", conflict.Version, conflict.Current)
+	}
+	return Message{Text: err.Error()}
+}
+
+// StatusFromError maps known service errors to an HTTP status, or returns fallback.
+func StatusFromError(err error, fallback int) int {
+	if errors.Is(err, ErrForbidden) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		return http.StatusConflict
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Status != 0 {
+		return statusErr.Status
+	}
+
+	return fallback
+}
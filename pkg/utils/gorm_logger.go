@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// QueryStats counts the queries a request ran and the time spent in them.
+// Handlers may query concurrently (e.g. errgroup), so fields are atomic.
+type QueryStats struct {
+	count atomic.Int64
+	nanos atomic.Int64
+}
+
+func (s *QueryStats) Count() int64 {
+	return s.count.Load()
+}
+
+func (s *QueryStats) Duration() time.Duration {
+	return time.Duration(s.nanos.Load())
+}
+
+type queryStatsKey struct{}
+
+func WithQueryStats(ctx context.Context, stats *QueryStats) context.Context {
+	return context.WithValue(ctx, queryStatsKey{}, stats)
+}
+
+func QueryStatsFrom(ctx context.Context) *QueryStats {
+	stats, _ := ctx.Value(queryStatsKey{}).(*QueryStats)
+	return stats
+}
+
+// GormLogger adapts gorm logging to the request-scoped logrus logger. Failed
+// queries are logged as errors, queries slower than SlowThreshold as warnings
+// and every query at debug level. Bind parameters, and the string and numeric
+// literals of SQL rendered with its values, are replaced by "?" unless
+// LogParams is set, so user data, IDs and secrets stay out of the logs.
+type GormLogger struct {
+	SlowThreshold time.Duration
+	LogParams     bool
+	level         gormlogger.LogLevel
+}
+
+func NewGormLogger(slowThreshold time.Duration, logParams bool) *GormLogger {
+	return &GormLogger{SlowThreshold: slowThreshold, LogParams: logParams, level: gormlogger.Info}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		LoggerFrom(ctx).Infof(msg, args...)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		LoggerFrom(ctx).Warnf(msg, args...)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		LoggerFrom(ctx).Errorf(msg, args...)
+	}
+}
+
+// sqlStringLiteral matches quoted values that gorm interpolated into the SQL.
+var sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// sqlNumericLiteral matches interpolated numbers, e.g. IDs, with the character
+// before them; digits within identifiers and $1 placeholders are left alone.
+var sqlNumericLiteral = regexp.MustCompile(`(^|[^\w$.])\d+(?:\.\d+)?\b`)
+
+// ParamsFilter is called by gorm before the SQL is rendered for Trace.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.LogParams {
+		return sql, params
+	}
+	return sql, nil
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+
+	if stats := QueryStatsFrom(ctx); stats != nil {
+		stats.count.Add(1)
+		stats.nanos.Add(int64(elapsed))
+	}
+
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	entry := LoggerFrom(ctx)
+	isSlow := l.SlowThreshold > 0 && elapsed > l.SlowThreshold
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	if !failed && !isSlow && !entry.Logger.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+
+	sql, rows := fc()
+	if !l.LogParams {
+		// Raw(...).Scan renders its SQL with values already inlined, so
+		// ParamsFilter alone does not cover it.
+		sql = sqlStringLiteral.ReplaceAllString(sql, "?")
+		sql = sqlNumericLiteral.ReplaceAllString(sql, "${1}?")
+	}
+	fields := logrus.Fields{
+		"sql":      sql,
+		"rows":     rows,
+		"duration": elapsed,
+	}
+
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		entry.WithFields(fields).WithError(err).Error("query failed")
+	case isSlow && l.level >= gormlogger.Warn:
+		entry.WithFields(fields).Warn(fmt.Sprintf("slow query (>%s)", l.SlowThreshold))
+	case l.level >= gormlogger.Info:
+		entry.WithFields(fields).Debug("query")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"math"
 	"net/http"
-	"os"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -56,20 +55,56 @@ func Created(c *gin.Context, message string, data interface{}) {
 	})
 }
 
+// CreatedRecord answers the create of record id. POST on a collection gets
+// 201 with the Location of the record, e.g. /api/v2/topics/7; the v1 /store
+// routes keep the 200 their clients expect.
+func CreatedRecord(c *gin.Context, message string, id int, data interface{}) {
+	if isStoreRoute(c) {
+		Success(c, message, data)
+		return
+	}
+	SetLocation(c, id)
+	Created(c, message, data)
+}
+
+// SetLocation points the Location header at record id of the collection the
+// request posted to. The v1 /store routes get none.
+func SetLocation(c *gin.Context, id int) {
+	if isStoreRoute(c) {
+		return
+	}
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+strconv.Itoa(id))
+}
+
+func isStoreRoute(c *gin.Context) bool {
+	return strings.HasSuffix(c.FullPath(), "/store")
+}
+
+// Error writes the problem of statusCode with message as its detail.
 func Error(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, gin.H{
-		"data":    nil,
-		"message": message,
-		"status":  "error",
-	})
+	WriteProblem(c, NewProblem(statusCode, message))
 }
 
-func ErrorValidation(c *gin.Context, statusCode int, message string, errors interface{}) {
-	c.JSON(statusCode, gin.H{
-		"status":  "error",
-		"message": message,
-		"errors":  errors,
-	})
+// ErrorFrom writes the problem of err, a service error, with the status
+// StatusFromError maps it to or fallback. A version conflict carries the ETag
+// of the current version, and fails with 412 when the client sent If-Match.
+func ErrorFrom(c *gin.Context, fallback int, err error) {
+	problem := NewErrorProblem(err, fallback)
+	if problem.Type == ProblemTypeConflict {
+		SetETag(c, problem.CurrentVersion)
+		if c.GetHeader("If-Match") != "" {
+			problem.Status = http.StatusPreconditionFailed
+			problem.Title = http.StatusText(http.StatusPreconditionFailed)
+		}
+	}
+	WriteProblem(c, problem)
+}
+
+// ErrorValidation writes a validation problem listing the invalid fields.
+func ErrorValidation(c *gin.Context, statusCode int, message string, errors []FieldError) {
+	problem := NewValidationProblem(message, errors)
+	problem.Status = statusCode
+	WriteProblem(c, problem)
 }
 
 func PaginatedSuccess(c *gin.Context, message string, data interface{}, page, limit, total int) {
@@ -124,20 +159,6 @@ func PaginatedSuccess(c *gin.Context, message string, data interface{}, page, li
 	})
 }
 
-func GetIsProduction() bool {
-	env := strings.ToLower(os.Getenv("APP_ENV"))
-	return env == "production"
-}
-
-func GetProtocol() string {
-	isProduction := GetIsProduction()
-	if isProduction {
-		return "https"
-	}
-
-	return "http" // default development
-}
-
 func PrintJSON(v any) {
 	jsonBytes, _ := json.MarshalIndent(v, "", "  ")
 	fmt.Println(string(jsonBytes))
@@ -311,6 +332,26 @@ func GetQueryParamInt(c *gin.Context, key string, defaultValue int) int {
 	return value
 }
 
+// PathID parses the :id path parameter, answering 400 when it is not a
+// positive number.
+func PathID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		Error(c, http.StatusBadRequest, "invalid ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// PostFormOr returns the form value of key, or fallback when the form does
+// not have the key at all. Partial updates use it to keep current values.
+func PostFormOr(c *gin.Context, key, fallback string) string {
+	if value, ok := c.GetPostForm(key); ok {
+		return value
+	}
+	return fallback
+}
+
 func SliceIntToPlaceholder(ids []int) string {
 	// Create a slice of "?" placeholders equal to the length of the input slice
 	placeholders := make([]string, len(ids))
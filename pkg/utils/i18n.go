@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the language messages are written in. English needs no
+// catalog: a message is its own English text, and the key of its translation
+// in every other catalog.
+const DefaultLocale = "en"
+
+//go:embed locales/*.yaml
+var catalogFiles embed.FS
+
+var translators = ut.New(en.New(), en.New(), id.New())
+
+const translatorKey = "translator"
+
+func init() {
+	if err := loadCatalogs(); err != nil {
+		panic(err)
+	}
+}
+
+// loadCatalogs adds the translations of locales/<locale>.yaml, a map from
+// the English message to its translation, to the translator of the locale.
+func loadCatalogs() error {
+	files, err := catalogFiles.ReadDir("locales")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		locale := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		trans, found := translators.GetTranslator(locale)
+		if !found {
+			return fmt.Errorf("catalog %s: unsupported locale", file.Name())
+		}
+
+		raw, err := catalogFiles.ReadFile("locales/" + file.Name())
+		if err != nil {
+			return err
+		}
+		var catalog map[string]string
+		if err := yaml.Unmarshal(raw, &catalog); err != nil {
+			return fmt.Errorf("catalog %s: %w", file.Name(), err)
+		}
+		for key, text := range catalog {
+			if err := trans.Add(key, text, false); err != nil {
+				return fmt.Errorf("catalog %s: %w", file.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Message is a text shown to clients: an English template whose {0}, {1}...
+// placeholders are replaced by params.
+type Message struct {
+	Text   string
+	Params []string
+}
+
+// NewMessage returns the message of text, formatting params with fmt.Sprint.
+func NewMessage(text string, params ...any) Message {
+	m := Message{Text: text}
+	for _, param := range params {
+		m.Params = append(m.Params, fmt.Sprint(param))
+	}
+	return m
+}
+
+// String renders m in English.
+func (m Message) String() string {
+	text := m.Text
+	for i, param := range m.Params {
+		text = strings.ReplaceAll(text, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return text
+}
+
+// In renders m with the catalog of trans, falling back to English when the
+// catalog has no translation.
+func (m Message) In(trans ut.Translator) string {
+	if trans != nil && trans.Locale() != DefaultLocale {
+		if text, err := trans.T(m.Text, m.Params...); err == nil {
+			return text
+		}
+	}
+	return m.String()
+}
+
+// Translator returns the translator negotiated from the Accept-Language
+// header of the request, or the English one.
+func Translator(c *gin.Context) ut.Translator {
+	if trans, ok := c.Get(translatorKey); ok {
+		return trans.(ut.Translator)
+	}
+	trans, _ := translators.FindTranslator(AcceptedLanguages(c.GetHeader("Accept-Language"))...)
+	c.Set(translatorKey, trans)
+	return trans
+}
+
+// Localize renders the English text of a message for the client of c.
+func Localize(c *gin.Context, text string) string {
+	return Message{Text: text}.In(Translator(c))
+}
+
+// AcceptedLanguages lists the languages of an Accept-Language header by
+// preference, each followed by its base language (id-ID, then id).
+func AcceptedLanguages(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil || q <= 0 {
+				continue
+			}
+			quality = q
+		}
+		languages = append(languages, language{tag: strings.ReplaceAll(tag, "-", "_"), quality: quality})
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := make([]string, 0, len(languages)*2)
+	for _, l := range languages {
+		tags = append(tags, l.tag)
+		if base, _, ok := strings.Cut(l.tag, "_"); ok {
+			tags = append(tags, base)
+		}
+	}
+	return tags
+}
@@ -1,51 +1,200 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 )
 
-func getSecretJWT() []byte {
-	envSecret := os.Getenv("JWT_SECRET")
-	jwtSecret := []byte(envSecret)
+const minRSAKeyBits = 2048
 
-	return jwtSecret
+// signingKey is one entry of the key set. Retired keys only carry a public key
+// and are kept so tokens they signed stay valid until they expire.
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+type keySet struct {
+	active   *signingKey
+	keys     map[string]*signingKey
+	tokenTTL time.Duration
+}
+
+var jwtKeys *keySet
+
+// InitJWTKeys loads every PEM key from dir. The file name without extension
+// is the key id (kid); activeKid selects the signing key and defaults to the
+// last kid in lexical order. Tokens expire after tokenTTL. Startup must fail
+// on any error.
+func InitJWTKeys(dir, activeKid string, tokenTTL time.Duration) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no JWT keys found in %s", dir)
+	}
+	sort.Strings(files)
+
+	set := &keySet{keys: map[string]*signingKey{}, tokenTTL: tokenTTL}
+	var lastPrivateKid string
+
+	for _, file := range files {
+		kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), ".pem"), ".pub")
+		if _, exists := set.keys[kid]; exists {
+			return fmt.Errorf("duplicate JWT key id %q", kid)
+		}
+
+		key, err := loadSigningKey(file, kid)
+		if err != nil {
+			return err
+		}
+
+		set.keys[kid] = key
+		if key.private != nil {
+			lastPrivateKid = kid
+		}
+	}
+
+	if activeKid == "" {
+		activeKid = lastPrivateKid
+	}
+
+	active, ok := set.keys[activeKid]
+	if !ok || active.private == nil {
+		return fmt.Errorf("active JWT key %q not found or has no private key", activeKid)
+	}
+	set.active = active
+
+	jwtKeys = set
+	return nil
+}
+
+// InitEphemeralJWTKey signs tokens with a fresh Ed25519 key that only lives
+// in memory, so tokens stop validating after a restart. It is meant for the
+// demo mode, where no key files are provisioned.
+func InitEphemeralJWTKey(tokenTTL time.Duration) error {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	key := &signingKey{kid: "ephemeral", method: jwt.SigningMethodEdDSA, private: private, public: public}
+	jwtKeys = &keySet{
+		active:   key,
+		keys:     map[string]*signingKey{key.kid: key},
+		tokenTTL: tokenTTL,
+	}
+	return nil
+}
+
+func loadSigningKey(file, kid string) (*signingKey, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("JWT key %s is not PEM encoded", file)
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("JWT key %s has unsupported PEM type %q", file, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT key %s: %w", file, err)
+	}
+
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("JWT key %s must be RSA or Ed25519, got %T", file, parsed)
+	}
+
+	if rsaKey, ok := key.public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("JWT key %s is too weak: %d bits, need at least %d", file, rsaKey.N.BitLen(), minRSAKeyBits)
+	}
+
+	return key, nil
 }
 
 // GenerateJWT generates JWT token
-func GenerateJWT(username string) (string, error) {
+func GenerateJWT(userID int, username string) (string, error) {
+	if jwtKeys == nil {
+		return "", fmt.Errorf("JWT keys are not initialized")
+	}
+
 	// Create JWT claims
 	claims := jwt.MapClaims{
+		"user_id":  userID,
 		"username": username,
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(), // Token expires in 7 days
+		"iat":      time.Now().Unix(),
+		"exp":      time.Now().Add(jwtKeys.tokenTTL).Unix(),
 	}
 
-	// Create token with claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Set JWT secret
-	jwtSecret := getSecretJWT()
+	// Create token with claims, the kid tells verifiers which key to use
+	token := jwt.NewWithClaims(jwtKeys.active.method, claims)
+	token.Header["kid"] = jwtKeys.active.kid
 
 	// Generate signed token string
-	return token.SignedString(jwtSecret)
+	return token.SignedString(jwtKeys.active.private)
 }
 
 // ValidateJWT validates a JWT token
 func ValidateJWT(tokenString string) (*jwt.Token, error) {
-	// Set JWT secret
-	jwtSecret := getSecretJWT()
+	if jwtKeys == nil {
+		return nil, fmt.Errorf("JWT keys are not initialized")
+	}
 
 	// Parse the token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Ensure token is signed with the correct signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := jwtKeys.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("invalid token")
+		}
+
+		// Ensure token is signed with the method that belongs to the key
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("invalid token")
 		}
-		return jwtSecret, nil
-	})
+		return key.public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -53,3 +202,51 @@ func ValidateJWT(tokenString string) (*jwt.Token, error) {
 
 	return token, nil
 }
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSHandler serves the public half of every active and retired key so other
+// services can verify tokens.
+func JWKSHandler(c *gin.Context) {
+	keys := []JWK{}
+	if jwtKeys != nil {
+		kids := make([]string, 0, len(jwtKeys.keys))
+		for kid := range jwtKeys.keys {
+			kids = append(kids, kid)
+		}
+		sort.Strings(kids)
+
+		for _, kid := range kids {
+			keys = append(keys, toJWK(jwtKeys.keys[kid]))
+		}
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
+
+func toJWK(key *signingKey) JWK {
+	jwk := JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+
+	switch k := key.public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	}
+
+	return jwk
+}
@@ -1,6 +1,11 @@
 package utils
 
-import "github.com/sirupsen/logrus"
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
 
 var Logger = logrus.New()
 
@@ -10,3 +15,45 @@ func InitLogger() {
 	})
 	Logger.SetLevel(logrus.InfoLevel)
 }
+
+// ConfigureLogger applies the configured format ("text" or "json") and level.
+func ConfigureLogger(format, level string) error {
+	switch format {
+	case "json":
+		Logger.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		Logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	Logger.SetLevel(parsed)
+	return nil
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying entry, so code further down the
+// call chain logs with the same request fields.
+func WithLogger(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey{}, entry)
+}
+
+// WithLogFields adds fields to the logger carried by ctx.
+func WithLogFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return WithLogger(ctx, LoggerFrom(ctx).WithFields(fields))
+}
+
+// LoggerFrom returns the request-scoped logger, or the global one outside a request.
+func LoggerFrom(ctx context.Context) *logrus.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(loggerKey{}).(*logrus.Entry); ok {
+			return entry
+		}
+	}
+	return logrus.NewEntry(Logger)
+}
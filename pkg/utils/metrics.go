@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsRegistry holds every metric served on /metrics.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	uploadSizeBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "upload_size_bytes",
+		Help:    "Size of files uploaded to object storage by folder.",
+		Buckets: prometheus.ExponentialBuckets(16*1024, 2, 10), // 16KiB .. 8MiB
+	}, []string{"folder"})
+
+	storageOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "storage_operation_duration_seconds",
+		Help:    "Object storage call latency by operation and result.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+
+	contentViewsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "content_views_total",
+		Help: "Views recorded for public content by type (Blog, Project).",
+	}, []string{"type"})
+
+	contentLikesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "content_likes_total",
+		Help: "Likes recorded for public content by type (Blog, Project).",
+	}, []string{"type"})
+
+	loginsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_total",
+		Help: "Login attempts by result (success, failure).",
+	}, []string{"result"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		uploadSizeBytes,
+		storageOperationDuration,
+		contentViewsTotal,
+		contentLikesTotal,
+		loginsTotal,
+	)
+}
+
+// RegisterDBMetrics exposes the connection pool stats of sqlDB as gauges.
+func RegisterDBMetrics(sqlDB *sql.DB, dbName string) error {
+	return MetricsRegistry.Register(collectors.NewDBStatsCollector(sqlDB, dbName))
+}
+
+// MetricsHandler serves the registry in Prometheus text format.
+func MetricsHandler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{}))
+}
+
+// MetricsMiddleware records request count and latency per route template, so
+// /api/blogs/1 and /api/blogs/2 share the /api/blogs/:id series.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+func observeUploadSize(folder string, size int64) {
+	uploadSizeBytes.WithLabelValues(folder).Observe(float64(size))
+}
+
+func observeStorage(operation string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	storageOperationDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
+
+// RecordContentViews adds n views for a content type; non-positive n is ignored.
+func RecordContentViews(contentType string, n int) {
+	if n > 0 {
+		contentViewsTotal.WithLabelValues(contentType).Add(float64(n))
+	}
+}
+
+// RecordContentLikes adds n likes for a content type; non-positive n is ignored.
+func RecordContentLikes(contentType string, n int) {
+	if n > 0 {
+		contentLikesTotal.WithLabelValues(contentType).Add(float64(n))
+	}
+}
+
+func RecordLogin(success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	loginsTotal.WithLabelValues(result).Inc()
+}
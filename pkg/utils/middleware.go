@@ -1,27 +1,106 @@
 package utils
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
+// RequestIDMiddleware honours a well-formed incoming X-Request-ID or generates
+// one, echoes it in the response and attaches a request-scoped logger to the
+// request context. It must run before every other middleware.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		entry := Logger.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"method":     c.Request.Method,
+			"route":      route,
+		})
+		c.Request = c.Request.WithContext(WithLogger(c.Request.Context(), entry))
+
+		c.Next()
+	}
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// addRequestLogFields enriches the request-scoped logger, e.g. once the user is known.
+func addRequestLogFields(c *gin.Context, fields logrus.Fields) {
+	c.Request = c.Request.WithContext(WithLogFields(c.Request.Context(), fields))
+}
+
+// TimeoutMiddleware puts a deadline on the request context so DB queries and
+// storage calls made for the request are cancelled once it passes. A zero
+// timeout leaves the context untouched.
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 func LoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		method := c.Request.Method
+
+		stats := &QueryStats{}
+		c.Request = c.Request.WithContext(WithQueryStats(c.Request.Context(), stats))
+		if traceID := TraceIDFrom(c.Request.Context()); traceID != "" {
+			addRequestLogFields(c, logrus.Fields{"trace_id": traceID})
+		}
+
 		c.Next()
 		duration := time.Since(start)
-		Logger.WithFields(map[string]interface{}{
-			"method":   method,
-			"path":     path,
-			"status":   c.Writer.Status(),
-			"duration": duration,
+		LoggerFrom(c.Request.Context()).WithFields(logrus.Fields{
+			"path":       path,
+			"status":     c.Writer.Status(),
+			"duration":   duration,
+			"db_queries": stats.Count(),
+			"db_time":    stats.Duration(),
 		}).Info("request-log")
 	}
 }
@@ -30,18 +109,13 @@ func RecoveryWithLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if rec := recover(); rec != nil {
-				Logger.WithFields(logrus.Fields{
-					"method": c.Request.Method,
-					"path":   c.Request.URL.Path,
-					"error":  rec,
+				LoggerFrom(c.Request.Context()).WithFields(logrus.Fields{
+					"path":  c.Request.URL.Path,
+					"error": rec,
 				}).Error("🔥 Panic recovered")
 
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"data":    nil,
-					"message": "Internal server error",
-					"status":  "error",
-					"error":   rec,
-				})
+				Error(c, http.StatusInternalServerError, "Internal server error")
+				c.Abort()
 			}
 		}()
 		c.Next()
@@ -71,11 +145,125 @@ func JWTMiddleware() gin.HandlerFunc {
 
 		// Set the token claims in the context for further use if needed (e.g., username)
 		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("username", claims["username"])
-			c.Set("email", claims["email"])
+			setJWTClaims(c, claims)
+			addRequestLogFields(c, logrus.Fields{"user_id": c.GetInt("user_id"), "auth_type": AuthTypeJWT})
 		}
 
 		// Continue to the next handler
 		c.Next()
 	}
 }
+
+// AuthMiddleware accepts either a bearer JWT or an API key. API keys are read from
+// the X-API-Key header or from "Authorization: Bearer pfk_...".
+func AuthMiddleware(apiKeyAuth APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader("X-API-Key")
+		bearer := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if rawKey == "" && IsAPIKey(bearer) {
+			rawKey = bearer
+		}
+
+		if rawKey == "" {
+			if bearer == "" {
+				Error(c, http.StatusUnauthorized, "Unauthorized request")
+				c.Abort()
+				return
+			}
+
+			token, err := ValidateJWT(bearer)
+			if err != nil || !token.Valid {
+				Error(c, http.StatusUnauthorized, "Invalid or expired token")
+				c.Abort()
+				return
+			}
+
+			if claims, ok := token.Claims.(jwt.MapClaims); ok {
+				setJWTClaims(c, claims)
+			}
+			addRequestLogFields(c, logrus.Fields{"user_id": c.GetInt("user_id"), "auth_type": AuthTypeJWT})
+
+			c.Next()
+			return
+		}
+
+		principal, err := apiKeyAuth(c.Request.Context(), rawKey)
+		if err != nil {
+			Error(c, http.StatusUnauthorized, "Invalid or expired API key")
+			c.Abort()
+			return
+		}
+
+		//? read-only keys may only issue safe requests
+		isSafeMethod := c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions
+		if !isSafeMethod && !HasScope(principal.Scopes, ScopeContentWrite) {
+			Error(c, http.StatusForbidden, "API key scope does not allow this request")
+			c.Abort()
+			return
+		}
+
+		c.Set("auth_type", AuthTypeAPIKey)
+		c.Set("api_key_id", principal.KeyID)
+		c.Set("user_id", principal.UserID)
+		c.Set("username", principal.Username)
+		c.Set("scopes", principal.Scopes)
+		addRequestLogFields(c, logrus.Fields{"user_id": principal.UserID, "auth_type": AuthTypeAPIKey, "api_key_id": principal.KeyID})
+
+		c.Next()
+	}
+}
+
+// RequireAuthType rejects requests that were not authenticated with the given method,
+// e.g. to keep API keys from managing other API keys.
+func RequireAuthType(authType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("auth_type") != authType {
+			Error(c, http.StatusForbidden, "This endpoint requires a user login")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireUserLogin keeps API keys, which are meant for content scripts, away
+// from account and system routes: keys, users and diagnostics.
+func RequireUserLogin() gin.HandlerFunc {
+	return RequireAuthType(AuthTypeJWT)
+}
+
+// DeprecationMiddleware marks the responses of a superseded API version with
+// a Deprecation header (RFC 9745) and, when successor returns a path for the
+// matched route, a Link to its replacement.
+func DeprecationMiddleware(since time.Time, successor func(route string) string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if link := successor(c.FullPath()); link != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", link))
+		}
+		c.Next()
+	}
+}
+
+// GetAuthUserID returns the id of the authenticated user set by the auth middleware.
+func GetAuthUserID(c *gin.Context) (int, bool) {
+	userID, ok := c.Get("user_id")
+	if !ok {
+		return 0, false
+	}
+	id, ok := userID.(int)
+	return id, ok && id != 0
+}
+
+func setJWTClaims(c *gin.Context, claims jwt.MapClaims) {
+	c.Set("auth_type", AuthTypeJWT)
+	c.Set("username", claims["username"])
+	c.Set("email", claims["email"])
+	c.Set("scopes", AllowedAPIKeyScopes)
+
+	// JSON numbers are decoded as float64
+	if userID, ok := claims["user_id"].(float64); ok {
+		c.Set("user_id", int(userID))
+	}
+}
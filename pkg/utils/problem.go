@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of every error response.
+const ProblemContentType = "application/problem+json"
+
+// Problem types with more meaning than their HTTP status. Other problems use
+// about:blank, whose title is the status text.
+const (
+	ProblemTypeBlank      = "about:blank"
+	ProblemTypeValidation = "/problems/validation"
+	ProblemTypeConflict   = "/problems/version-conflict"
+)
+
+// Problem is the body of every error response: an RFC 7807 problem details
+// object, with the field errors of a validation failure, the current version
+// of a record updated concurrently and the request id as extension members.
+type Problem struct {
+	Type           string       `json:"type"`
+	Title          string       `json:"title"`
+	Status         int          `json:"status"`
+	Detail         string       `json:"detail,omitempty"`
+	Errors         []FieldError `json:"errors,omitempty"`
+	CurrentVersion int          `json:"current_version,omitempty"`
+	RequestID      string       `json:"request_id,omitempty"`
+
+	// detail is the message of Detail, when it has parameters.
+	detail Message
+}
+
+// NewProblem returns the about:blank problem of status.
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   ProblemTypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// NewValidationProblem returns the problem of a request whose fields are
+// invalid.
+func NewValidationProblem(detail string, errors []FieldError) Problem {
+	return Problem{
+		Type:   ProblemTypeValidation,
+		Title:  "Validation Error",
+		Status: http.StatusBadRequest,
+		Detail: detail,
+		Errors: errors,
+	}
+}
+
+// NewErrorProblem returns the about:blank problem of a service error, with
+// the status StatusFromError maps it to.
+func NewErrorProblem(err error, fallback int) Problem {
+	p := NewProblem(StatusFromError(err, fallback), err.Error())
+	p.detail = ErrorMessage(err)
+
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		p.Type = ProblemTypeConflict
+		p.Title = "Version Conflict"
+		p.CurrentVersion = conflict.Current
+	}
+	return p
+}
+
+// WriteProblem writes p as the response, filling in the request id. Its
+// title, detail and field errors are localized to the language of the
+// request.
+func WriteProblem(c *gin.Context, p Problem) {
+	trans := Translator(c)
+
+	p.Title = Message{Text: p.Title}.In(trans)
+	if p.detail.Text == "" {
+		p.detail = Message{Text: p.Detail}
+	}
+	p.Detail = p.detail.In(trans)
+	if p.Errors != nil {
+		fieldErrors := make([]FieldError, len(p.Errors))
+		for i, fe := range p.Errors {
+			if fe.message.Text == "" {
+				fe.message = Message{Text: fe.Message}
+			}
+			fe.Message = fe.message.In(trans)
+			fieldErrors[i] = fe
+		}
+		p.Errors = fieldErrors
+	}
+
+	if p.RequestID == "" {
+		p.RequestID = c.GetString("request_id")
+	}
+	c.Header("Content-Type", ProblemContentType)
+	c.Header("Content-Language", trans.Locale())
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	c.JSON(p.Status, p)
+}
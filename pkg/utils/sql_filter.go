@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern returns the argument for a "LOWER(column) LIKE ?" clause.
+// MySQL compares case-insensitively by default but PostgreSQL does not, so
+// both sides are lowercased; LIKE wildcards in the search term are escaped.
+func ContainsPattern(search string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(search)) + "%"
+}
+
+// DateFilter builds the WHERE clause for a date query param such as
+// created_at. One value matches the year, month or day it names (2024,
+// 2024-05, 2024-05-01); two values are an inclusive range of days. The clause
+// compares the column directly, so it works on DATETIME and TIMESTAMP columns
+// in every dialect.
+func DateFilter(column string, values []string) (string, []interface{}, error) {
+	start, end, err := DateRange(column, values)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("(%s >= ? AND %s < ?)", column, column), []interface{}{start, end}, nil
+}
+
+// DateRange returns the half-open interval [start, end) a date query param
+// selects, following the same rules as DateFilter. It is used directly by
+// repositories that filter in Go instead of SQL.
+func DateRange(column string, values []string) (time.Time, time.Time, error) {
+	switch len(values) {
+	case 1:
+		return parseDatePrefix(values[0])
+
+	case 2:
+		startDate, err := time.ParseInLocation("2006-01-02", values[0], time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		endDate, err := time.ParseInLocation("2006-01-02", values[1], time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return startDate, endDate.AddDate(0, 0, 1), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("%s expects one date or a from,to range", column)
+}
+
+// parseDatePrefix returns the half-open interval covered by a year, month or day.
+func parseDatePrefix(value string) (time.Time, time.Time, error) {
+	layouts := []struct {
+		layout string
+		next   func(time.Time) time.Time
+	}{
+		{"2006-01-02", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+		{"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+		{"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+	}
+
+	for _, l := range layouts {
+		if start, err := time.ParseInLocation(l.layout, value, time.Local); err == nil {
+			return start, l.next(start), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q, expected YYYY, YYYY-MM or YYYY-MM-DD", value)
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"mime/multipart"
+)
+
+// Storage keeps uploaded files. The container builds one from the storage
+// config and hands it to the services: MinioStorage normally, LocalStorage in
+// demo mode.
+type Storage interface {
+	// Upload stores file under folder. A nil file uploads nothing and returns
+	// an empty response, leaving the record without an image.
+	Upload(ctx context.Context, file *multipart.FileHeader, folder string) (UploadResponse, error)
+	// Delete removes one object; a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// DeleteBatch removes objectKeys, batchSize keys per request.
+	DeleteBatch(ctx context.Context, objectKeys []string, batchSize int) error
+	// List returns the objects whose key starts with prefix, e.g. "blog/".
+	List(ctx context.Context, prefix string) ([]StoredObject, error)
+	// Ping checks that storage is reachable.
+	Ping(ctx context.Context) error
+	// Bucket is the bucket name file URLs carry, see MinioParseURLToImageKey.
+	Bucket() string
+}
+
+// uploadFile opens file and passes it to put, see Storage.Upload.
+func uploadFile(ctx context.Context, file *multipart.FileHeader, folder string, put func(context.Context, UploadFileInput, string) (*UploadResponse, error)) (UploadResponse, error) {
+	if file == nil {
+		return UploadResponse{}, nil
+	}
+
+	openedFile, err := file.Open()
+	if err != nil {
+		return UploadResponse{}, err
+	}
+	defer openedFile.Close()
+
+	uploadedData, err := put(ctx, UploadFileInput{FileHeader: file, File: openedFile}, folder)
+	if err != nil {
+		return UploadResponse{}, err
+	}
+
+	return *uploadedData, nil
+}
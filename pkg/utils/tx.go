@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Tx is a unit of work: the transaction handle passed to repositories plus
+// the side effects that must follow its outcome, such as storage cleanup.
+type Tx struct {
+	*gorm.DB
+
+	afterCommit   []func(context.Context) error
+	afterRollback []func(context.Context) error
+}
+
+// AfterCommit registers fn to run once the transaction has committed, e.g.
+// removing files the new rows no longer reference.
+func (t *Tx) AfterCommit(fn func(ctx context.Context) error) {
+	t.afterCommit = append(t.afterCommit, fn)
+}
+
+// AfterRollback registers fn to run when the transaction is rolled back,
+// including on panic, e.g. removing files uploaded for the failed write.
+func (t *Tx) AfterRollback(fn func(ctx context.Context) error) {
+	t.afterRollback = append(t.afterRollback, fn)
+}
+
+// WithTx runs fn inside a transaction on db. It commits when fn returns nil
+// and rolls back when fn returns an error or panics; a panic is re-raised
+// after the rollback hooks ran. Hooks run in registration order on a context
+// that outlives request cancellation, and their errors are only logged, since
+// the transaction outcome is already final.
+//
+// A nil db runs fn without a transaction, for repositories that do not use
+// one (the in-memory demo store); hooks still follow fn's outcome.
+func WithTx(ctx context.Context, db *gorm.DB, fn func(tx *Tx) error) (err error) {
+	t := &Tx{}
+
+	defer func() {
+		if r := recover(); r != nil {
+			runTxHooks(ctx, "rollback", t.afterRollback)
+			panic(r)
+		}
+	}()
+
+	if db == nil {
+		err = fn(t)
+	} else {
+		err = db.WithContext(ctx).Transaction(func(gtx *gorm.DB) error {
+			t.DB = gtx
+			return fn(t)
+		})
+	}
+
+	if err != nil {
+		runTxHooks(ctx, "rollback", t.afterRollback)
+		return err
+	}
+
+	runTxHooks(ctx, "commit", t.afterCommit)
+	return nil
+}
+
+func runTxHooks(ctx context.Context, stage string, hooks []func(context.Context) error) {
+	hookCtx := context.WithoutCancel(ctx)
+	for _, hook := range hooks {
+		if err := hook(hookCtx); err != nil {
+			LoggerFrom(ctx).WithField("stage", stage).Warnf("⚠️ transaction hook failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LocalStorageBucket is the URL path local files are served under. It plays
+// the bucket's role in file URLs, so MinioParseURLToImageKey resolves keys of
+// local files the same way it does for MinIO objects.
+const LocalStorageBucket = "uploads"
+
+// LocalStorage is the Storage of the demo mode, a directory on disk in place
+// of MinIO.
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage stores files under dir, with URLs starting with baseURL,
+// e.g. http://localhost:4000; the router serves Dir under /uploads.
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage dir %s: %w", dir, err)
+	}
+
+	return &LocalStorage{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Dir returns the directory uploads are stored in.
+func (s *LocalStorage) Dir() string {
+	return s.dir
+}
+
+// Bucket returns LocalStorageBucket.
+func (s *LocalStorage) Bucket() string {
+	return LocalStorageBucket
+}
+
+// path maps an object key to a file inside the storage dir, rejecting keys
+// that would escape it.
+func (s *LocalStorage) path(key string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	rel, err := filepath.Rel(s.dir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return path, nil
+}
+
+// Ping checks that the storage dir exists.
+func (s *LocalStorage) Ping(ctx context.Context) error {
+	info, err := os.Stat(s.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("local storage %s is not a directory", s.dir)
+	}
+	return nil
+}
+
+// Upload stores file under folder, see Storage.Upload.
+func (s *LocalStorage) Upload(ctx context.Context, file *multipart.FileHeader, folder string) (UploadResponse, error) {
+	return uploadFile(ctx, file, folder, s.put)
+}
+
+func (s *LocalStorage) put(ctx context.Context, input UploadFileInput, folder string) (*UploadResponse, error) {
+	fileName, _, fileSize := GenerateAdditionalInfo(input, folder)
+
+	_, span := startStorageSpan(ctx, "local", LocalStorageBucket, "put_object",
+		attribute.String("storage.object", fileName),
+		attribute.Int64("storage.object_size", fileSize),
+	)
+	start := time.Now()
+	err := s.write(fileName, input.File)
+	observeStorage("put_object", start, err)
+	EndSpan(span, err)
+	if err != nil {
+		LoggerFrom(ctx).WithField("object", fileName).Error("failed to upload file: ", err)
+		return nil, err
+	}
+	observeUploadSize(folder, fileSize)
+
+	return &UploadResponse{
+		FileURL:  fmt.Sprintf("%s/%s/%s", s.baseURL, LocalStorageBucket, fileName),
+		FileName: fileName,
+	}, nil
+}
+
+func (s *LocalStorage) write(key string, src io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// Delete removes one file; a missing file is not an error, like in MinIO.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	_, span := startStorageSpan(ctx, "local", LocalStorageBucket, "remove_object", attribute.String("storage.object", key))
+	start := time.Now()
+	path, err := s.path(key)
+	if err == nil {
+		if err = os.Remove(path); os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	observeStorage("remove_object", start, err)
+	EndSpan(span, err)
+	return err
+}
+
+// DeleteBatch removes objectKeys one by one, disk has no batch delete.
+func (s *LocalStorage) DeleteBatch(ctx context.Context, objectKeys []string, batchSize int) error {
+	var errs []error
+	for _, key := range objectKeys {
+		if err := s.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// List walks the storage dir like a recursive ListObjects.
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	_, span := startStorageSpan(ctx, "local", LocalStorageBucket, "list_objects", attribute.String("storage.prefix", prefix))
+	start := time.Now()
+	var objects []StoredObject
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, StoredObject{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	observeStorage("list_objects", start, err)
+	EndSpan(span, err)
+	return objects, err
+}
@@ -3,13 +3,10 @@ package utils
 import (
 	"context"
 	"fmt"
-	"log"
 	"mime/multipart"
 	"net/url"
-	"os"
 	"path/filepath"
 	"slices"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type UploadFileInput struct {
@@ -46,42 +44,63 @@ func GenerateAdditionalInfo(input UploadFileInput, folder string) (fileName stri
 	return fileName, contentType, fileSize
 }
 
-func GenerateMinioClient() (*minio.Client, error) {
-	endpoint := os.Getenv("MINIO_ENDPOINT_UPLOAD")
+type MinioConfig struct {
+	UploadEndpoint  string
+	ViewEndpoint    string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	Bucket          string
+	// ViewSSL serves file URLs over https, as production does.
+	ViewSSL bool
+}
 
-	accessKeyID := os.Getenv("MINIO_KEY_ID")
-	secretAccessKey := os.Getenv("MINIO_KEY_SECRET")
-	useSSL := os.Getenv("MINIO_SSL")
+// MinioStorage is the Storage of a normal boot, a bucket in MinIO.
+type MinioStorage struct {
+	cfg    MinioConfig
+	client *minio.Client
+}
 
-	secure, err := strconv.ParseBool(useSSL)
+// NewMinioStorage builds the MinIO client once at startup.
+func NewMinioStorage(cfg MinioConfig) (*MinioStorage, error) {
+	client, err := minio.New(cfg.UploadEndpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Init client
-	minioClient, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
-		Secure: secure,
-	})
+	return &MinioStorage{cfg: cfg, client: client}, nil
+}
+
+// Ping checks that storage is reachable and the bucket exists.
+func (s *MinioStorage) Ping(ctx context.Context) error {
+	ctx, span := startStorageSpan(ctx, "minio", s.cfg.Bucket, "bucket_exists")
+	start := time.Now()
+	exists, err := s.client.BucketExists(ctx, s.cfg.Bucket)
+	observeStorage("bucket_exists", start, err)
+	EndSpan(span, err)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return minioClient, nil
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", s.cfg.Bucket)
+	}
+	return nil
 }
 
-func BuildMinioURL(endpoint, bucket, fileName string) string {
-	return fmt.Sprintf("%s://%s/%s/%s", GetProtocol(), endpoint, bucket, fileName)
+// Bucket returns the configured bucket name.
+func (s *MinioStorage) Bucket() string {
+	return s.cfg.Bucket
 }
 
-func ValidateSize(size int64) (err []FieldError) {
-	// Optional: File size validation (e.g. max 2MB)
-	if size > 2*1024*1024 {
-		errors := GenerateFieldErrorResponse("avatar_file", "File size exceeds 2MB")
-		return errors
+func (s *MinioStorage) buildURL(fileName string) string {
+	protocol := "http"
+	if s.cfg.ViewSSL {
+		protocol = "https"
 	}
-
-	return nil
+	return fmt.Sprintf("%s://%s/%s/%s", protocol, s.cfg.ViewEndpoint, s.cfg.Bucket, fileName)
 }
 
 func ValidateExtension(fileName string, allowedExtensions []string) (err []FieldError) {
@@ -91,9 +110,7 @@ func ValidateExtension(fileName string, allowedExtensions []string) (err []Field
 	}
 	ext := strings.ToLower(filepath.Ext(fileName))
 	if !slices.Contains(allowedExtensions, ext) {
-		message := fmt.Sprintf("File must be %s", FormatAllowedExtensions(allowedExtensions))
-		errors := GenerateFieldErrorResponse("avatar_file", message)
-		return errors
+		return GenerateFieldErrorResponse("avatar_file", "File must be {0}", FormatAllowedExtensions(allowedExtensions))
 	}
 
 	return nil
@@ -116,62 +133,39 @@ func FormatAllowedExtensions(exts []string) string {
 	)
 }
 
-func GenerateFieldErrorResponse(field, message string) []FieldError {
-	errors := []FieldError{
-		{
-			Field:   field,
-			Message: message,
-		},
-	}
-	return errors
+// GenerateFieldErrorResponse lists the error of field, see NewFieldError.
+func GenerateFieldErrorResponse(field, message string, params ...any) []FieldError {
+	return []FieldError{NewFieldError(field, message, params...)}
 }
 
-func HandlUploadFile(file *multipart.FileHeader, folderName string) (UploadResponse, error) {
-	// Step 4: Open file
-	openedFile, err := file.Open()
-	if err != nil {
-		return UploadResponse{}, err
-	}
-	defer openedFile.Close()
-
-	// Step 5: Upload to MinIO
-	payload := UploadFileInput{
-		FileHeader: file,
-		File:       openedFile,
-	}
-
-	uploadedData, err := UploadFile(context.Background(), payload, folderName)
-	if err != nil {
-		return UploadResponse{}, err
-	}
-
-	return *uploadedData, nil
+// Upload stores file under folder, see Storage.Upload.
+func (s *MinioStorage) Upload(ctx context.Context, file *multipart.FileHeader, folder string) (UploadResponse, error) {
+	return uploadFile(ctx, file, folder, s.put)
 }
 
-func UploadFile(ctx context.Context, input UploadFileInput, folder string) (*UploadResponse, error) {
-	endpoint := os.Getenv("MINIO_ENDPOINT_VIEW")
-	bucketName := os.Getenv("MINIO_BUCKET")
-
-	// Init client
-	minioClient, err := GenerateMinioClient()
-	if err != nil {
-		return nil, err
-	}
-
+func (s *MinioStorage) put(ctx context.Context, input UploadFileInput, folder string) (*UploadResponse, error) {
 	// Generate additional info
 	fileName, contentType, fileSize := GenerateAdditionalInfo(input, folder)
 
 	// Upload to MinIO
-	_, err = minioClient.PutObject(ctx, bucketName, fileName, input.File, fileSize, minio.PutObjectOptions{
+	ctx, span := startStorageSpan(ctx, "minio", s.cfg.Bucket, "put_object",
+		attribute.String("storage.object", fileName),
+		attribute.Int64("storage.object_size", fileSize),
+	)
+	start := time.Now()
+	_, err := s.client.PutObject(ctx, s.cfg.Bucket, fileName, input.File, fileSize, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
+	observeStorage("put_object", start, err)
+	EndSpan(span, err)
 	if err != nil {
-		log.Println("err:", err)
+		LoggerFrom(ctx).WithField("object", fileName).Error("failed to upload file: ", err)
 		return nil, err
 	}
+	observeUploadSize(folder, fileSize)
 
 	// Generate public URL (jika public)
-	fileURL := BuildMinioURL(endpoint, bucketName, fileName)
+	fileURL := s.buildURL(fileName)
 
 	// Generate response
 	avatar := UploadResponse{
@@ -182,16 +176,39 @@ func UploadFile(ctx context.Context, input UploadFileInput, folder string) (*Upl
 	return &avatar, nil
 }
 
-func DeleteFromMinio(ctx context.Context, objectPath string) error {
-	bucketName := os.Getenv("MINIO_BUCKET")
+// Delete removes one object from the bucket.
+func (s *MinioStorage) Delete(ctx context.Context, objectPath string) error {
+	ctx, span := startStorageSpan(ctx, "minio", s.cfg.Bucket, "remove_object", attribute.String("storage.object", objectPath))
+	start := time.Now()
+	err := s.client.RemoveObject(ctx, s.cfg.Bucket, objectPath, minio.RemoveObjectOptions{})
+	observeStorage("remove_object", start, err)
+	EndSpan(span, err)
+	return err
+}
 
-	// Init client
-	minioClient, err := GenerateMinioClient()
-	if err != nil {
-		return err
-	}
+// StoredObject is one file in the bucket.
+type StoredObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
 
-	return minioClient.RemoveObject(ctx, bucketName, objectPath, minio.RemoveObjectOptions{})
+// List returns the objects whose key starts with prefix, e.g. "blog/".
+func (s *MinioStorage) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	ctx, span := startStorageSpan(ctx, "minio", s.cfg.Bucket, "list_objects", attribute.String("storage.prefix", prefix))
+	start := time.Now()
+	var objects []StoredObject
+	var err error
+	for obj := range s.client.ListObjects(ctx, s.cfg.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			err = obj.Err
+			break
+		}
+		objects = append(objects, StoredObject{Key: obj.Key, Size: obj.Size, LastModified: obj.LastModified})
+	}
+	observeStorage("list_objects", start, err)
+	EndSpan(span, err)
+	return objects, err
 }
 
 // Function to parse URL and extract the image key
@@ -221,13 +238,8 @@ func MinioParseURLToImageKey(urlImages []string, bucketName string) ([]string, e
 	return imageKeys, nil
 }
 
-func DeleteBulkImagesInBatches(bucketName string, objectKeys []string, batchSize int) error {
-	// Init client
-	minioClient, err := GenerateMinioClient()
-	if err != nil {
-		return err
-	}
-
+// DeleteBatch removes objectKeys in concurrent batches of batchSize.
+func (s *MinioStorage) DeleteBatch(ctx context.Context, objectKeys []string, batchSize int) error {
 	if len(objectKeys) == 0 {
 		return nil // Tidak ada yang perlu dihapus
 	}
@@ -259,14 +271,20 @@ func DeleteBulkImagesInBatches(bucketName string, objectKeys []string, batchSize
 			close(deleteObjectsCh)
 
 			// Use RemoveObjectsWithContext to handle context cancellation/timeouts
-			errorResultCh := minioClient.RemoveObjects(context.Background(), bucketName, deleteObjectsCh, minio.RemoveObjectsOptions{})
+			ctx, span := startStorageSpan(ctx, "minio", s.cfg.Bucket, "remove_objects", attribute.Int("storage.object_count", len(keys)))
+			start := time.Now()
+			var batchErr error
+			errorResultCh := s.client.RemoveObjects(ctx, s.cfg.Bucket, deleteObjectsCh, minio.RemoveObjectsOptions{})
 
 			// Check for errors during deletion.  Crucially, *drain the entire channel*.
 			// RemoveObjects sends *all* errors to the channel, and if you don't
 			// drain it, the goroutine can leak.
 			for rErr := range errorResultCh {
+				batchErr = rErr.Err
 				errCh <- fmt.Errorf("error menghapus objek '%s': %w", rErr.ObjectName, rErr.Err)
 			}
+			observeStorage("remove_objects", start, batchErr)
+			EndSpan(span, batchErr)
 
 		}(batchKeys)
 	}
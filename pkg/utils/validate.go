@@ -3,150 +3,152 @@ package utils
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
+// Validate checks the `validate` tags of request structs; gin checks the
+// `binding` tags with its own engine. Both name fields like the client does.
 var Validate = validator.New()
 
+func init() {
+	Validate.RegisterTagNameFunc(requestFieldName)
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
 type FieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
+
+	// message is the message of Message, when it has parameters.
+	message Message
 }
 
-type ValidationErrorResponse struct {
-	Errors []FieldError `json:"errors"`
+// NewFieldError returns the error of field with message, an English template
+// whose {0}, {1}... placeholders are replaced by params.
+func NewFieldError(field, message string, params ...any) FieldError {
+	m := NewMessage(message, params...)
+	return FieldError{Field: field, Message: m.String(), message: m}
 }
 
-var customMessages = map[string]string{
-	"name.required":        "name is required",
-	"title.required":       "title is required",
-	"avatar_file.required": "avatar_file is required",
-	"description.required": "description is required",
-	"is_major.oneof":       "is_major must be either 'Y' or 'N'",
-	"id.required":          "id is required",
-	"id.numeric":           "id must be numeric",
+// requestFieldName names a struct field by its json tag, or its form tag for
+// multipart requests, so errors point at the field the client sent.
+func requestFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name)
 }
 
-// ValidateStruct handles JSON binding and validation errors, and returns a structured error response.
-// Returns true if validation passed, or false if errors are returned to the client.
-func ValidateStruct(c *gin.Context, requestStruct interface{}, bindErr error) bool {
+// ValidateStruct writes the problem of a failed bind, like
+// c.ShouldBindJSON(&req), and returns false; it returns true when bindErr is
+// nil.
+func ValidateStruct(c *gin.Context, bindErr error) bool {
 	if bindErr == nil {
-		return true // No validation error, continue execution
+		return true
 	}
 
-	var validationErrors validator.ValidationErrors
-
-	// Check if the error is a validation error
-	if errors.As(bindErr, &validationErrors) {
-		var formattedErrors []FieldError
-
-		for _, fieldError := range validationErrors {
-			// Default field name (fallback to struct field name)
-			jsonField := fieldError.Field()
-
-			// Try to get the actual JSON tag from the struct
-			if structField, ok := reflect.TypeOf(requestStruct).Elem().FieldByName(fieldError.StructField()); ok {
-				jsonTag := structField.Tag.Get("json")
-				if jsonTag != "" {
-					jsonField = jsonTag
-				}
-			}
-
-			// Create the key for custom message lookup (e.g. "tech_name.required")
-			messageKey := fmt.Sprintf("%s.%s", jsonField, fieldError.Tag())
-
-			// Look up the custom error message or fallback to default message
-			errorMessage, exists := customMessages[messageKey]
-			if !exists {
-				if fieldError.Tag() == "oneof" {
-					params := formatTypeOf(fieldError.Param())
-					errorMessage = fmt.Sprintf("%s must be one of '%s'", jsonField, params)
-				} else {
-					errorMessage = fmt.Sprintf("%s failed on '%s'", jsonField, fieldError.Tag())
-				}
-			}
-
-			// Append the error in the desired format
-			formattedErrors = append(formattedErrors, FieldError{
-				Field:   jsonField,
-				Message: errorMessage,
-			})
-		}
-
-		// Return all validation errors in the expected JSON format
-		c.JSON(400, gin.H{
-			"errors": formattedErrors,
-		})
+	if fieldErrors := FieldErrors(bindErr); fieldErrors != nil {
+		ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", fieldErrors)
 		return false
 	}
 
 	// Fallback for non-validation binding errors (e.g. malformed JSON)
-	switch err := bindErr.(type) {
-	case *json.SyntaxError:
-		errSyntax := fmt.Sprintf("Malformed JSON at offset %d", err.Offset)
-		Error(c, 400, errSyntax)
-		return false
-	case *json.UnmarshalTypeError:
-		errUnmarshal := fmt.Sprintf("Field '%s' expects type %s, but got value '%s'", err.Field, err.Type, err.Value)
-		Error(c, 400, errUnmarshal)
-		return false
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(bindErr, &syntaxErr):
+		ErrorFrom(c, http.StatusBadRequest, NewError("Malformed JSON at offset {0}", syntaxErr.Offset))
+	case errors.As(bindErr, &typeErr):
+		ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", GenerateFieldErrorResponse(typeErr.Field,
+			"{0} expects type {1}, but got {2}", typeErr.Field, typeErr.Type, typeErr.Value))
 	default:
-		errMessage := "Invalid request body: " + bindErr.Error()
-		Error(c, 400, errMessage)
-		return false
+		ErrorFrom(c, http.StatusBadRequest, NewError("Invalid request body: {0}", bindErr))
 	}
+	return false
 }
 
+// ValidateRequest checks the `validate` tags of data, a pointer to a request
+// struct, returning the invalid fields or nil.
 func ValidateRequest(data interface{}) []FieldError {
-	var validate = validator.New()
+	return FieldErrors(Validate.Struct(data))
+}
 
-	err := validate.Struct(data)
-	if err == nil {
+// FieldErrors lists the fields of a validator error, or returns nil when err
+// is not one.
+func FieldErrors(err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
 		return nil
 	}
 
-	var errs []FieldError
-	val := reflect.ValueOf(data).Elem()
-	typ := val.Type()
-
-	for _, fe := range err.(validator.ValidationErrors) {
-		fieldName := fe.StructField()
-		tag := fe.Tag()
-
-		// get json tag
-		field, _ := typ.FieldByName(fieldName)
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" {
-			jsonTag = strings.ToLower(fieldName)
-		} else {
-			jsonTag = strings.Split(jsonTag, ",")[0] // handle omitempty
-		}
-
-		// get message from custom map
-		customKey := fmt.Sprintf("%s.%s", jsonTag, tag)
-		message, ok := customMessages[customKey]
-		if !ok {
-			// fallback message
-			if fe.Tag() == "oneof" {
-				params := formatTypeOf(fe.Param())
-				message = fmt.Sprintf("%s must be one of '%s'", jsonTag, params)
-			} else {
-				message = fmt.Sprintf("%s is not valid (%s)", jsonTag, tag)
-			}
-		}
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		message, params := fieldMessage(fe)
+		fieldErrors = append(fieldErrors, NewFieldError(fieldPath(fe), message, params...))
+	}
+	return fieldErrors
+}
 
-		errs = append(errs, FieldError{
-			Field:   jsonTag,
-			Message: message,
-		})
+// fieldPath returns the path of the field below the request struct, e.g.
+// topic_ids[0].topic_id.
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
 	}
+	return fe.Field()
+}
 
-	return errs
+// fieldMessage returns the message template of a failed validation, and its
+// parameters: the field, then the param of the tag.
+func fieldMessage(fe validator.FieldError) (string, []any) {
+	field, param := fe.Field(), fe.Param()
+
+	switch fe.Tag() {
+	case "required":
+		return "{0} is required", []any{field}
+	case "oneof":
+		return "{0} must be one of '{1}'", []any{field, formatTypeOf(param)}
+	case "email":
+		return "{0} must be a valid email address", []any{field}
+	case "numeric":
+		return "{0} must be numeric", []any{field}
+	case "min":
+		switch fe.Kind() {
+		case reflect.String:
+			return "{0} must have at least {1} characters", []any{field, param}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return "{0} must have at least {1} items", []any{field, param}
+		}
+		return "{0} must be at least {1}", []any{field, param}
+	case "max":
+		switch fe.Kind() {
+		case reflect.String:
+			return "{0} must have at most {1} characters", []any{field, param}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return "{0} must have at most {1} items", []any{field, param}
+		}
+		return "{0} must be at most {1}", []any{field, param}
+	case "datetime":
+		return "{0} must be a date formatted as {1}", []any{field, param}
+	case "gt":
+		return "{0} must be greater than {1}", []any{field, param}
+	default:
+		return "{0} is not valid ({1})", []any{field, fe.Tag()}
+	}
 }
 
 func formatTypeOf(typeof string) string {
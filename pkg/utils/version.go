@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VersionConflictError is returned by an update based on a version of the
+// record that is no longer the current one: someone saved it in between.
+type VersionConflictError struct {
+	Version int
+	Current int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version %d is stale, the current version is %d", e.Version, e.Current)
+}
+
+// CheckVersion returns a *VersionConflictError unless version is current.
+func CheckVersion(version, current int) error {
+	if version != current {
+		return &VersionConflictError{Version: version, Current: current}
+	}
+	return nil
+}
+
+// UpdateVersioned applies updates to the row id of table when its version is
+// still version, and bumps the version. It returns gorm.ErrRecordNotFound or
+// a *VersionConflictError when no row was updated.
+func UpdateVersioned(db *gorm.DB, table string, id, version int, updates map[string]interface{}) error {
+	updates["version"] = gorm.Expr("version + 1")
+	result := db.Table(table).Where("id = ? AND version = ?", id, version).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var current struct{ Version int }
+	if err := db.Table(table).Select("version").Where("id = ?", id).Take(&current).Error; err != nil {
+		return err
+	}
+	return &VersionConflictError{Version: version, Current: current.Version}
+}
+
+// ETag is the entity tag of a record at version.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// SetETag sets the ETag header to the version of the record in the response.
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", ETag(version))
+}
+
+// RequestVersion returns the version of the record an update is based on: the
+// If-Match ETag or else the version form field, 0 when neither is sent. An
+// If-Match of * matches any version and returns current, the version the
+// handler loaded, or 0 when it loaded none. A weak ETag (W/"3") is compared
+// like the strong one. It writes a 400 problem and returns false when the
+// one sent is malformed.
+func RequestVersion(c *gin.Context, current int) (int, bool) {
+	if ifMatch := strings.TrimSpace(c.GetHeader("If-Match")); ifMatch != "" {
+		if ifMatch == "*" {
+			return current, true
+		}
+		version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+		if err != nil || version <= 0 {
+			Error(c, http.StatusBadRequest, "If-Match must be the ETag of the record")
+			return 0, false
+		}
+		return version, true
+	}
+
+	value, ok := c.GetPostForm("version")
+	if !ok || value == "" {
+		return 0, true
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		ErrorValidation(c, http.StatusBadRequest, "request has invalid fields", GenerateFieldErrorResponse("version", "{0} must be numeric", "version"))
+		return 0, false
+	}
+	return version, true
+}
+
+// IfMatchMiddleware answers 412 to an update carrying If-Match on the routes
+// versioned reports false for: their records have no version, so the
+// precondition cannot hold, and ignoring the header would let the update
+// overwrite changes it was meant to guard against.
+func IfMatchMiddleware(versioned func(method, route string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if c.GetHeader("If-Match") == "" || method == http.MethodGet || method == http.MethodHead {
+			c.Next()
+			return
+		}
+		if !versioned(method, c.FullPath()) {
+			Error(c, http.StatusPreconditionFailed, "If-Match is not supported here, the record has no version")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}